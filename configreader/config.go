@@ -2,14 +2,19 @@ package configreader
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/viper"
 )
 
 // Config represents the parsed configuration from the file.
 type Config struct {
-	MySQL      DBConfig
-	Connection HTTPServerConfig
+	MySQL          DBConfig
+	Connection     HTTPServerConfig
+	Recommendation RecommendationConfig
+	Calendar       CalendarConfig
+	CalDAV         CalDAVConfig
+	Auth           AuthConfig
 }
 
 // DBConfig represents the configuration for a specific database connection.
@@ -36,6 +41,48 @@ type HTTPServerConfig struct {
 	IdleTimeout  int
 }
 
+// RecommendationConfig represents the tuning of the slot recommender.
+type RecommendationConfig struct {
+	StepMinutes int
+}
+
+// CalendarConfig represents the settings of the iCalendar export.
+type CalendarConfig struct {
+	// Domain qualifies event UIDs and user addresses, e.g. scheduler.example.com
+	Domain string
+}
+
+// CalDAVConfig represents the CalDAV server that attendee free/busy is synced from, sync is off without a BaseURL.
+type CalDAVConfig struct {
+	BaseURL string
+	// CalendarPath is the path of the calendar of a user below BaseURL, {user_id} is replaced by the user id
+	CalendarPath string
+	// Username and Password are the basic auth credentials of an account that can read every user's free/busy
+	Username            string
+	Password            string
+	SyncIntervalMinutes int
+	TimeoutSeconds      int
+}
+
+// AuthConfig represents how callers are authenticated, every request is anonymous unless Enabled is set.
+type AuthConfig struct {
+	Enabled bool
+	// JWTSecret verifies HMAC signed bearer tokens, JWTPublicKey is a PEM encoded RSA key that verifies RSA signed ones
+	JWTSecret    string
+	JWTPublicKey string
+	// JWTIssuer and JWTAudience, when set, must match the iss and aud claims of a token
+	JWTIssuer   string
+	JWTAudience string
+	APIKeys     []APIKeyConfig
+}
+
+// APIKeyConfig represents a static API key that authenticates a service account.
+type APIKeyConfig struct {
+	Name  string
+	Key   string
+	Roles []string
+}
+
 func ReadConfigFileOrEnv(configFilePath string) (*Config, error) {
 	// If a config file path is provided, read the configuration from the file, for local development or testing.
 	if configFilePath != "" {
@@ -67,6 +114,28 @@ func ReadConfigFileOrEnv(configFilePath string) (*Config, error) {
 			WriteTimeout: viper.GetInt("HTTP_WRITE_TIMEOUT"),
 			IdleTimeout:  viper.GetInt("HTTP_IDLE_TIMEOUT"),
 		},
+		Recommendation: RecommendationConfig{
+			StepMinutes: viper.GetInt("RECOMMENDATION_STEP_MINUTES"),
+		},
+		Calendar: CalendarConfig{
+			Domain: viper.GetString("CALENDAR_DOMAIN"),
+		},
+		CalDAV: CalDAVConfig{
+			BaseURL:             viper.GetString("CALDAV_BASE_URL"),
+			CalendarPath:        viper.GetString("CALDAV_CALENDAR_PATH"),
+			Username:            viper.GetString("CALDAV_USERNAME"),
+			Password:            viper.GetString("CALDAV_PASSWORD"),
+			SyncIntervalMinutes: viper.GetInt("CALDAV_SYNC_INTERVAL_MINUTES"),
+			TimeoutSeconds:      viper.GetInt("CALDAV_TIMEOUT_SECONDS"),
+		},
+		Auth: AuthConfig{
+			Enabled:      viper.GetBool("AUTH_ENABLED"),
+			JWTSecret:    viper.GetString("AUTH_JWT_SECRET"),
+			JWTPublicKey: viper.GetString("AUTH_JWT_PUBLIC_KEY"),
+			JWTIssuer:    viper.GetString("AUTH_JWT_ISSUER"),
+			JWTAudience:  viper.GetString("AUTH_JWT_AUDIENCE"),
+			APIKeys:      parseAPIKeys(viper.GetString("AUTH_API_KEYS")),
+		},
 	}
 	return config, nil
 
@@ -87,3 +156,20 @@ func ReadConfigFile(configFilePath string) (*Config, error) {
 	}
 	return &config, nil
 }
+
+// parseAPIKeys reads a comma separated list of name:key or name:key:role|role entries
+func parseAPIKeys(value string) []APIKeyConfig {
+	apiKeys := []APIKeyConfig{}
+	for _, entry := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), ":", 3)
+		if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+			continue
+		}
+		apiKey := APIKeyConfig{Name: parts[0], Key: parts[1]}
+		if len(parts) == 3 && parts[2] != "" {
+			apiKey.Roles = strings.Split(parts[2], "|")
+		}
+		apiKeys = append(apiKeys, apiKey)
+	}
+	return apiKeys
+}
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS event_attendee;
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS event_attendee (
+  id INT PRIMARY KEY AUTO_INCREMENT,
+  event_id INT NOT NULL COMMENT 'id of the event table',
+  user_id INT NOT NULL COMMENT 'user id of the attendee',
+  is_required TINYINT(1) NOT NULL DEFAULT 0 COMMENT 'whether the meeting can only happen when this attendee is free',
+  weight DOUBLE NOT NULL DEFAULT 1 COMMENT 'weight of an optional attendee in the recommendation score',
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE KEY uq_event_attendee (event_id, user_id),
+  FOREIGN KEY (event_id) REFERENCES event_detail(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
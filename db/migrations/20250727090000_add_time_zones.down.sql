@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS user_profile;
+
+ALTER TABLE user_availability DROP COLUMN time_zone;
+
+ALTER TABLE event_slot DROP COLUMN time_zone;
+
+ALTER TABLE event_detail DROP COLUMN time_zone;
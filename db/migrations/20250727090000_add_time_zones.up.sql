@@ -0,0 +1,15 @@
+ALTER TABLE event_detail
+  ADD COLUMN time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC' COMMENT 'IANA time zone of the organizer' AFTER duration_minutes;
+
+ALTER TABLE event_slot
+  ADD COLUMN time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC' COMMENT 'IANA time zone the slot was proposed in' AFTER end_time;
+
+ALTER TABLE user_availability
+  ADD COLUMN time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC' COMMENT 'IANA time zone the availability was submitted in' AFTER end_time;
+
+CREATE TABLE IF NOT EXISTS user_profile (
+  user_id INT PRIMARY KEY COMMENT 'user id of the person',
+  time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC' COMMENT 'IANA time zone of the user',
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS user_working_hours (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  user_id INT NOT NULL COMMENT 'user id of the person',
+  weekday TINYINT NOT NULL COMMENT '0 is Sunday, 6 is Saturday',
+  start_minute SMALLINT NOT NULL COMMENT 'minutes after local midnight the working hours start',
+  end_minute SMALLINT NOT NULL COMMENT 'minutes after local midnight the working hours end, 1440 is the end of the day',
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  INDEX idx_user_working_hours_user (user_id, weekday)
+);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS user_availability_rule (
+  id INT PRIMARY KEY AUTO_INCREMENT,
+  event_id INT NOT NULL COMMENT 'id of the event table',
+  user_id INT NOT NULL COMMENT 'user id of the person who is available',
+  start_time DATETIME NOT NULL COMMENT 'start time of the first occurrence',
+  end_time DATETIME NOT NULL COMMENT 'end time of the first occurrence',
+  time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC' COMMENT 'IANA time zone whose wall clock the occurrences follow',
+  rrule VARCHAR(512) NOT NULL COMMENT 'RFC 5545 recurrence rule',
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  INDEX idx_user_availability_rule_event_user (event_id, user_id),
+  FOREIGN KEY (event_id) REFERENCES event_detail(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
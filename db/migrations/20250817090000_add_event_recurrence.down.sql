@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS event_exception;
+
+ALTER TABLE event_detail DROP COLUMN rrule;
@@ -0,0 +1,15 @@
+ALTER TABLE event_detail
+  ADD COLUMN rrule VARCHAR(512) NOT NULL DEFAULT '' COMMENT 'RFC 5545 recurrence rule of the series, empty for a one-off meeting' AFTER time_zone;
+
+CREATE TABLE IF NOT EXISTS event_exception (
+  id INT PRIMARY KEY AUTO_INCREMENT,
+  event_id INT NOT NULL COMMENT 'id of the event table',
+  occurrence_date DATE NOT NULL COMMENT 'date of the occurrence in the time zone of the event',
+  cancelled BOOLEAN NOT NULL DEFAULT FALSE COMMENT 'the occurrence does not take place',
+  start_time DATETIME NULL COMMENT 'start time of a moved occurrence',
+  end_time DATETIME NULL COMMENT 'end time of a moved occurrence',
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  UNIQUE KEY uq_event_exception (event_id, occurrence_date),
+  FOREIGN KEY (event_id) REFERENCES event_detail(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,4 @@
+ALTER TABLE event_detail
+  DROP COLUMN confirmed_end_time,
+  DROP COLUMN confirmed_start_time,
+  DROP COLUMN status;
@@ -0,0 +1,8 @@
+ALTER TABLE event_detail
+  ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'draft' COMMENT 'draft, polling, confirmed or cancelled' AFTER rrule,
+  ADD COLUMN confirmed_start_time DATETIME NULL COMMENT 'start time of the confirmed slot' AFTER status,
+  ADD COLUMN confirmed_end_time DATETIME NULL COMMENT 'end time of the confirmed slot' AFTER confirmed_start_time;
+
+-- events that already received availability are polling
+UPDATE event_detail SET status = 'polling'
+WHERE id IN (SELECT DISTINCT event_id FROM user_availability UNION SELECT DISTINCT event_id FROM user_availability_rule);
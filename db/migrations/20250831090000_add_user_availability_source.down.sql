@@ -0,0 +1,2 @@
+ALTER TABLE user_availability
+  DROP COLUMN source;
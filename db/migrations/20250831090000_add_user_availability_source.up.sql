@@ -0,0 +1,2 @@
+ALTER TABLE user_availability
+  ADD COLUMN source VARCHAR(16) NOT NULL DEFAULT 'manual' COMMENT 'manual when submitted by the user, caldav when synced from their calendar' AFTER time_zone;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS event_resource;
+DROP TABLE IF EXISTS resource_availability;
+DROP TABLE IF EXISTS resource;
@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS resource (
+  id INT PRIMARY KEY AUTO_INCREMENT,
+  name VARCHAR(255) NOT NULL COMMENT 'name shown to users, e.g. Room 4.12',
+  kind VARCHAR(16) NOT NULL COMMENT 'room or equipment',
+  capacity INT NOT NULL DEFAULT 0 COMMENT 'number of people a room holds, 0 for equipment',
+  location VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'building or floor of the resource',
+  features JSON NOT NULL COMMENT 'tags such as projector or whiteboard',
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  INDEX idx_resource_kind (kind, capacity)
+);
+
+CREATE TABLE IF NOT EXISTS resource_availability (
+  id INT PRIMARY KEY AUTO_INCREMENT,
+  resource_id INT NOT NULL COMMENT 'id of the resource table',
+  start_time DATETIME NOT NULL COMMENT 'start of a window the resource can be booked in',
+  end_time DATETIME NOT NULL COMMENT 'end of a window the resource can be booked in',
+  time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC' COMMENT 'IANA time zone the window was submitted in',
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  INDEX idx_resource_availability_resource (resource_id, start_time),
+  FOREIGN KEY (resource_id) REFERENCES resource(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS event_resource (
+  id INT PRIMARY KEY AUTO_INCREMENT,
+  event_id INT NOT NULL COMMENT 'id of the event table',
+  resource_id INT NULL COMMENT 'the resource the event needs, NULL when any resource matching the columns below will do',
+  kind VARCHAR(16) NOT NULL DEFAULT '' COMMENT 'kind of resource needed when resource_id is NULL',
+  min_capacity INT NOT NULL DEFAULT 0 COMMENT 'number of people the resource must hold',
+  features JSON NOT NULL COMMENT 'features the resource must have',
+  booked_resource_id INT NULL COMMENT 'resource reserved when the event was confirmed',
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  INDEX idx_event_resource_booked (booked_resource_id),
+  FOREIGN KEY (event_id) REFERENCES event_detail(id) ON DELETE CASCADE ON UPDATE CASCADE,
+  FOREIGN KEY (resource_id) REFERENCES resource(id),
+  FOREIGN KEY (booked_resource_id) REFERENCES resource(id)
+);
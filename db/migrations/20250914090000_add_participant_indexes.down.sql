@@ -0,0 +1,4 @@
+ALTER TABLE event_attendee
+  DROP INDEX idx_event_attendee_user;
+ALTER TABLE event_detail
+  DROP INDEX idx_event_detail_organizer;
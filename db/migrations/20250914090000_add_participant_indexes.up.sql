@@ -0,0 +1,4 @@
+ALTER TABLE event_detail
+  ADD INDEX idx_event_detail_organizer (organizer_id, status);
+ALTER TABLE event_attendee
+  ADD INDEX idx_event_attendee_user (user_id, event_id);
@@ -0,0 +1,6 @@
+ALTER TABLE event_resource
+  DROP FOREIGN KEY fk_event_resource_booked,
+  DROP FOREIGN KEY fk_event_resource_resource;
+ALTER TABLE event_resource
+  ADD CONSTRAINT event_resource_ibfk_2 FOREIGN KEY (resource_id) REFERENCES resource(id),
+  ADD CONSTRAINT event_resource_ibfk_3 FOREIGN KEY (booked_resource_id) REFERENCES resource(id);
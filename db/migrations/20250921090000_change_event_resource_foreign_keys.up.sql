@@ -0,0 +1,11 @@
+-- a resource that requirements name cannot be deleted, bookings of past and cancelled events are released with it
+ALTER TABLE event_resource
+  DROP FOREIGN KEY event_resource_ibfk_2,
+  DROP FOREIGN KEY event_resource_ibfk_3;
+ALTER TABLE event_resource
+  ADD CONSTRAINT fk_event_resource_resource FOREIGN KEY (resource_id) REFERENCES resource(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+  ADD CONSTRAINT fk_event_resource_booked FOREIGN KEY (booked_resource_id) REFERENCES resource(id) ON DELETE SET NULL ON UPDATE CASCADE;
+
+-- cancelled events no longer hold their resources
+UPDATE event_resource SET booked_resource_id = NULL
+WHERE event_id IN (SELECT id FROM event_detail WHERE status = 'cancelled');
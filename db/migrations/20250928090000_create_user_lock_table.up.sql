@@ -0,0 +1,3 @@
+CREATE TABLE IF NOT EXISTS user_lock (
+  user_id INT PRIMARY KEY COMMENT 'user id of the person, the row is locked while an event of theirs is confirmed'
+);
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/rahulshewale153/meeting-scheduler-api/service"
+)
+
+type CalendarHandler struct {
+	calendarService service.CalendarServiceI
+}
+
+func NewCalendarHandler(calendarService service.CalendarServiceI) *CalendarHandler {
+	return &CalendarHandler{
+		calendarService: calendarService,
+	}
+}
+
+// ExportEvent returns the event as an iCalendar file
+func (h *CalendarHandler) ExportEvent(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.ParseInt(mux.Vars(r)["event_id"], 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid event_id")
+		return
+	}
+
+	calendar, err := h.calendarService.ExportEvent(r.Context(), eventID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	writeCalendar(w, fmt.Sprintf("event-%d.ics", eventID), calendar)
+}
+
+// ExportUserCalendar returns the confirmed events of a user as an iCalendar feed
+func (h *CalendarHandler) ExportUserCalendar(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.ParseInt(mux.Vars(r)["user_id"], 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid user_id")
+		return
+	}
+
+	calendar, err := h.calendarService.ExportUserCalendar(r.Context(), userID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	writeCalendar(w, fmt.Sprintf("user-%d.ics", userID), calendar)
+}
+
+func writeCalendar(w http.ResponseWriter, fileName string, calendar []byte) {
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`inline; filename="%s"`, fileName))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(calendar); err != nil {
+		log.Println("Error writing calendar:", err)
+	}
+}
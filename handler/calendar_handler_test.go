@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	mockService "github.com/rahulshewale153/meeting-scheduler-api/mock/service"
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportEvent(t *testing.T) {
+	mockCalendarService := new(mockService.MockCalendarService)
+	calendarHandler := NewCalendarHandler(mockCalendarService)
+
+	t.Run("invalid event_id, should return bad request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events/abc.ics", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "abc"})
+		w := httptest.NewRecorder()
+
+		calendarHandler.ExportEvent(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("event not found, should return not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events/1.ics", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
+		w := httptest.NewRecorder()
+		mockCalendarService.On("ExportEvent", req.Context(), int64(1)).Return([]byte(nil), &model.NotFoundError{Resource: "event", ID: 1}).Once()
+
+		calendarHandler.ExportEvent(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("valid request, should return the calendar", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events/2.ics", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "2"})
+		w := httptest.NewRecorder()
+		mockCalendarService.On("ExportEvent", req.Context(), int64(2)).Return([]byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"), nil).Once()
+
+		calendarHandler.ExportEvent(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, `inline; filename="event-2.ics"`, w.Header().Get("Content-Disposition"))
+		assert.Equal(t, "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n", w.Body.String())
+	})
+}
+
+func TestExportUserCalendar(t *testing.T) {
+	mockCalendarService := new(mockService.MockCalendarService)
+	calendarHandler := NewCalendarHandler(mockCalendarService)
+
+	t.Run("invalid user_id, should return bad request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users/abc/calendar.ics", nil)
+		req = mux.SetURLVars(req, map[string]string{"user_id": "abc"})
+		w := httptest.NewRecorder()
+
+		calendarHandler.ExportUserCalendar(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("service error, should return internal server error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users/7/calendar.ics", nil)
+		req = mux.SetURLVars(req, map[string]string{"user_id": "7"})
+		w := httptest.NewRecorder()
+		mockCalendarService.On("ExportUserCalendar", req.Context(), int64(7)).Return([]byte(nil), assert.AnError).Once()
+
+		calendarHandler.ExportUserCalendar(w, req)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("valid request, should return the feed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users/7/calendar.ics", nil)
+		req = mux.SetURLVars(req, map[string]string{"user_id": "7"})
+		w := httptest.NewRecorder()
+		mockCalendarService.On("ExportUserCalendar", req.Context(), int64(7)).Return([]byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"), nil).Once()
+
+		calendarHandler.ExportUserCalendar(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `inline; filename="user-7.ics"`, w.Header().Get("Content-Disposition"))
+	})
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rahulshewale153/meeting-scheduler-api/service"
+)
+
+type ConflictHandler struct {
+	conflictService service.ConflictServiceI
+}
+
+func NewConflictHandler(conflictService service.ConflictServiceI) *ConflictHandler {
+	return &ConflictHandler{
+		conflictService: conflictService,
+	}
+}
+
+// GetUserConflicts lists the confirmed meetings of a user that overlap, between ?from= and ?to=
+func (h *ConflictHandler) GetUserConflicts(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.ParseInt(mux.Vars(r)["user_id"], 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid user_id")
+		return
+	}
+
+	query := r.URL.Query()
+	var from, to time.Time
+	if fromStr := query.Get("from"); fromStr != "" {
+		if from, err = time.Parse(time.RFC3339, fromStr); err != nil {
+			writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid from, expected RFC3339 time")
+			return
+		}
+	}
+	if toStr := query.Get("to"); toStr != "" {
+		if to, err = time.Parse(time.RFC3339, toStr); err != nil {
+			writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid to, expected RFC3339 time")
+			return
+		}
+	}
+	loc, err := requestedTimeZone(r)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	conflicts, err := h.conflictService.GetUserConflicts(r.Context(), userID, from, to)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, conflictsInZone(conflicts, loc))
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	mockService "github.com/rahulshewale153/meeting-scheduler-api/mock/service"
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUserConflicts(t *testing.T) {
+	mockConflictService := new(mockService.MockConflictService)
+	conflictHandler := NewConflictHandler(mockConflictService)
+	from := time.Date(2025, 07, 14, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 07, 21, 0, 0, 0, 0, time.UTC)
+
+	t.Run("invalid user_id, should return bad request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users/abc/conflicts", nil)
+		req = mux.SetURLVars(req, map[string]string{"user_id": "abc"})
+		w := httptest.NewRecorder()
+
+		conflictHandler.GetUserConflicts(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid from, should return bad request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users/3/conflicts?from=monday", nil)
+		req = mux.SetURLVars(req, map[string]string{"user_id": "3"})
+		w := httptest.NewRecorder()
+
+		conflictHandler.GetUserConflicts(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Invalid from")
+	})
+
+	t.Run("service error, should return internal server error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users/3/conflicts", nil)
+		req = mux.SetURLVars(req, map[string]string{"user_id": "3"})
+		w := httptest.NewRecorder()
+		mockConflictService.On("GetUserConflicts", req.Context(), int64(3), time.Time{}, time.Time{}).Return([]model.MeetingConflict{}, assert.AnError).Once()
+
+		conflictHandler.GetUserConflicts(w, req)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("valid request, should return the conflicts in the requested zone", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users/3/conflicts?from=2025-07-14T00:00:00Z&to=2025-07-21T00:00:00Z&tz=Asia/Kolkata", nil)
+		req = mux.SetURLVars(req, map[string]string{"user_id": "3"})
+		w := httptest.NewRecorder()
+		start := time.Date(2025, 07, 17, 14, 0, 0, 0, time.UTC)
+		mockConflictService.On("GetUserConflicts", req.Context(), int64(3), from, to).Return([]model.MeetingConflict{{
+			First:   model.Meeting{EventID: 5, Title: "Review", Slot: model.EventSlot{StartTime: start, EndTime: start.Add(time.Hour)}},
+			Second:  model.Meeting{EventID: 6, Title: "Standup", Slot: model.EventSlot{StartTime: start.Add(30 * time.Minute), EndTime: start.Add(time.Hour)}},
+			Overlap: model.EventSlot{StartTime: start.Add(30 * time.Minute), EndTime: start.Add(time.Hour)},
+		}}, nil).Once()
+
+		conflictHandler.GetUserConflicts(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var conflicts []model.MeetingConflict
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &conflicts))
+		assert.Len(t, conflicts, 1)
+		assert.Equal(t, int64(6), conflicts[0].Second.EventID)
+		assert.Contains(t, w.Body.String(), `"start_time":"2025-07-17T20:00:00+05:30"`)
+	})
+}
@@ -2,10 +2,11 @@ package handler
 
 import (
 	"encoding/json"
-	"fmt"
-	"log"
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/rahulshewale153/meeting-scheduler-api/model"
@@ -28,18 +29,17 @@ func (h *EventHandler) InsertEvent(w http.ResponseWriter, r *http.Request) {
 	var req model.EventRequest
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
-		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid request payload")
 		return
 	}
 	if errs, ok := utils.IsValid(req); !ok {
-		log.Printf("Validation failed: %v", errs)
-		http.Error(w, fmt.Sprintf("Validation failed:%v", errs.Error), http.StatusBadRequest)
+		writeValidationError(w, r, errs)
 		return
 	}
 
 	eventID, err := h.eventService.InsertEvent(r.Context(), req)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeServiceError(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusCreated)
@@ -54,25 +54,24 @@ func (h *EventHandler) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 	var req model.EventRequest
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
-		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid request payload")
 		return
 	}
 	if errs, ok := utils.IsValid(req); !ok {
-		log.Printf("Validation failed: %v", errs)
-		http.Error(w, fmt.Sprintf("Validation failed:%v", errs.Error), http.StatusBadRequest)
+		writeValidationError(w, r, errs)
 		return
 	}
 
 	vars := mux.Vars(r)
 	eventIDStr := vars["event_id"]
 	if eventIDStr == "" {
-		http.Error(w, "event_id is required", http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "event_id is required")
 		return
 	}
 
 	eventID, err := strconv.ParseInt(eventIDStr, 10, 64)
 	if err != nil {
-		http.Error(w, "Invalid event_id", http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid event_id")
 		return
 	}
 
@@ -80,7 +79,7 @@ func (h *EventHandler) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 
 	err = h.eventService.UpdateEvent(r.Context(), req)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeServiceError(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
@@ -92,21 +91,278 @@ func (h *EventHandler) DeleteEvent(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	eventIDStr := vars["event_id"]
 	if eventIDStr == "" {
-		http.Error(w, "event_id is required", http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "event_id is required")
 		return
 	}
 
 	eventID, err := strconv.ParseInt(eventIDStr, 10, 64)
 	if err != nil {
-		http.Error(w, "Invalid event_id", http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid event_id")
 		return
 	}
 
 	err = h.eventService.DeleteEvent(r.Context(), eventID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeServiceError(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]string{"message": "Event deleted successfully"})
 }
+
+// GetEvent retrieves an event with its proposed slots
+func (h *EventHandler) GetEvent(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventIDStr := vars["event_id"]
+	if eventIDStr == "" {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "event_id is required")
+		return
+	}
+
+	eventID, err := strconv.ParseInt(eventIDStr, 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid event_id")
+		return
+	}
+
+	loc, err := requestedTimeZone(r)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	event, err := h.eventService.GetEvent(r.Context(), eventID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	// slots are shown in the zone they were proposed in unless the caller asks for another one
+	event.Event = eventInZone(event.Event, loc)
+	event.ProposedSlots = utils.SlotsInZone(event.ProposedSlots, loc)
+	event.Exceptions = exceptionsInZone(event.Exceptions, loc)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(event)
+}
+
+// ListEvents lists events page by page, optionally filtered by organizer, creation time and title
+func (h *EventHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var filter model.EventFilter
+	var err error
+
+	if organizerIDStr := query.Get("organizer_id"); organizerIDStr != "" {
+		filter.OrganizerID, err = strconv.ParseInt(organizerIDStr, 10, 64)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid organizer_id")
+			return
+		}
+	}
+
+	if createdFromStr := query.Get("created_from"); createdFromStr != "" {
+		filter.CreatedFrom, err = time.Parse(time.RFC3339, createdFromStr)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid created_from, expected RFC3339 time")
+			return
+		}
+	}
+
+	if createdToStr := query.Get("created_to"); createdToStr != "" {
+		filter.CreatedTo, err = time.Parse(time.RFC3339, createdToStr)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid created_to, expected RFC3339 time")
+			return
+		}
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		filter.Limit, err = strconv.Atoi(limitStr)
+		if err != nil || filter.Limit < 0 {
+			writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid limit")
+			return
+		}
+	}
+
+	filter.AfterID, err = utils.DecodeCursor(query.Get("cursor"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid cursor")
+		return
+	}
+	filter.Title = query.Get("title")
+
+	filter.Status = query.Get("status")
+	switch filter.Status {
+	case "", model.EventStatusDraft, model.EventStatusPolling, model.EventStatusConfirmed, model.EventStatusCancelled:
+	default:
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid status, expected one of draft, polling, confirmed, cancelled")
+		return
+	}
+
+	loc, err := requestedTimeZone(r)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	events, err := h.eventService.ListEvents(r.Context(), filter)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	for i := range events.Events {
+		events.Events[i] = eventInZone(events.Events[i], loc)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(events)
+}
+
+// AddAttendee invites a user to an event
+func (h *EventHandler) AddAttendee(w http.ResponseWriter, r *http.Request) {
+	var attendee model.Attendee
+	if err := json.NewDecoder(r.Body).Decode(&attendee); err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid request payload")
+		return
+	}
+	if errs, ok := utils.IsValid(attendee); !ok {
+		writeValidationError(w, r, errs)
+		return
+	}
+
+	eventID, err := strconv.ParseInt(mux.Vars(r)["event_id"], 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid event_id")
+		return
+	}
+
+	if err := h.eventService.AddAttendee(r.Context(), eventID, attendee); err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]string{"message": "Attendee added successfully"})
+}
+
+// RemoveAttendee removes a user from the attendees of an event
+func (h *EventHandler) RemoveAttendee(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID, err := strconv.ParseInt(vars["event_id"], 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid event_id")
+		return
+	}
+
+	userID, err := strconv.ParseInt(vars["user_id"], 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid user_id")
+		return
+	}
+
+	if err := h.eventService.RemoveAttendee(r.Context(), eventID, userID); err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Attendee removed successfully"})
+}
+
+// ListAttendees lists the attendees of an event
+func (h *EventHandler) ListAttendees(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.ParseInt(mux.Vars(r)["event_id"], 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid event_id")
+		return
+	}
+
+	attendees, err := h.eventService.ListAttendees(r.Context(), eventID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, attendees)
+}
+
+// SetEventException cancels or moves one occurrence of a recurring event
+func (h *EventHandler) SetEventException(w http.ResponseWriter, r *http.Request) {
+	var exception model.EventException
+	if err := json.NewDecoder(r.Body).Decode(&exception); err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid request payload")
+		return
+	}
+
+	vars := mux.Vars(r)
+	eventID, err := strconv.ParseInt(vars["event_id"], 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid event_id")
+		return
+	}
+	exception.OccurrenceDate = vars["occurrence_date"]
+
+	if err := h.eventService.SetEventException(r.Context(), eventID, exception); err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Exception saved successfully"})
+}
+
+// RemoveEventException restores one occurrence of a recurring event
+func (h *EventHandler) RemoveEventException(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID, err := strconv.ParseInt(vars["event_id"], 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid event_id")
+		return
+	}
+
+	if err := h.eventService.RemoveEventException(r.Context(), eventID, vars["occurrence_date"]); err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Exception removed successfully"})
+}
+
+// ConfirmEvent confirms the slot in the body, or the top recommendation when the body is empty
+func (h *EventHandler) ConfirmEvent(w http.ResponseWriter, r *http.Request) {
+	var slot *model.EventSlot
+	var req model.EventSlot
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid request payload")
+		return
+	}
+	if !req.StartTime.IsZero() || !req.EndTime.IsZero() {
+		slot = &req
+	}
+
+	eventID, err := strconv.ParseInt(mux.Vars(r)["event_id"], 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid event_id")
+		return
+	}
+
+	confirmed, err := h.eventService.ConfirmEvent(r.Context(), eventID, slot)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	// the slot is shown in the zone it was confirmed in
+	confirmed = utils.SlotsInZone([]model.EventSlot{confirmed}, nil)[0]
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"confirmed_slot": confirmed,
+		"message":        "Event confirmed successfully",
+	})
+}
+
+// CancelEvent cancels an event
+func (h *EventHandler) CancelEvent(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.ParseInt(mux.Vars(r)["event_id"], 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid event_id")
+		return
+	}
+
+	if err := h.eventService.CancelEvent(r.Context(), eventID); err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Event cancelled successfully"})
+}
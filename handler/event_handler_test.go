@@ -1,13 +1,17 @@
 package handler
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	mockService "github.com/rahulshewale153/meeting-scheduler-api/mock/service"
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/rahulshewale153/meeting-scheduler-api/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -108,6 +112,18 @@ func TestDeleteEvent(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 
+	t.Run("event does not exist, should return not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/events/2", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "2"})
+		w := httptest.NewRecorder()
+
+		mockEventService.On("DeleteEvent", req.Context(), int64(2)).Return(&model.NotFoundError{Resource: "event", ID: 2}).Once()
+
+		eventHandler.DeleteEvent(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.JSONEq(t, `{"code":"not_found","message":"event 2 not found","resource":"event","id":2}`, w.Body.String())
+	})
+
 	t.Run("valid request, should return no content status", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodDelete, "/events/1", nil)
 		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
@@ -120,3 +136,424 @@ func TestDeleteEvent(t *testing.T) {
 	})
 
 }
+
+func TestGetEvent(t *testing.T) {
+	mockEventService := new(mockService.MockEventService)
+	eventHandler := NewEventHandler(mockEventService)
+
+	t.Run("invalid event ID, should return bad request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events/invalid", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "invalid"})
+		w := httptest.NewRecorder()
+
+		eventHandler.GetEvent(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("service error, should return internal server error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events/1", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
+		w := httptest.NewRecorder()
+
+		mockEventService.On("GetEvent", req.Context(), int64(1)).Return(model.EventDetail{}, assert.AnError).Once()
+
+		eventHandler.GetEvent(w, req)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("event does not exist, should return not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events/2", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "2"})
+		w := httptest.NewRecorder()
+
+		mockEventService.On("GetEvent", req.Context(), int64(2)).Return(model.EventDetail{}, &model.NotFoundError{Resource: "event", ID: 2}).Once()
+
+		eventHandler.GetEvent(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("valid request, should return the event with its slots", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events/1", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
+		w := httptest.NewRecorder()
+
+		eventDetail := model.EventDetail{
+			Event: model.Event{ID: 1, Title: "Test Event", OrganizerID: 1, DurationMinutes: 60},
+			ProposedSlots: []model.EventSlot{
+				{ID: 1, StartTime: time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC), EndTime: time.Date(2023, 10, 1, 11, 0, 0, 0, time.UTC)},
+			},
+		}
+		mockEventService.On("GetEvent", req.Context(), int64(1)).Return(eventDetail, nil).Once()
+
+		eventHandler.GetEvent(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"title":"Test Event"`)
+		assert.Contains(t, w.Body.String(), `"proposed_slots":[{"id":1`)
+	})
+
+	t.Run("invalid tz, should return bad request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events/1?tz=Nowhere/City", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
+		w := httptest.NewRecorder()
+
+		eventHandler.GetEvent(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Invalid tz")
+	})
+
+	t.Run("without tz, should render each slot in the zone it was proposed in", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events/1", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
+		w := httptest.NewRecorder()
+
+		eventDetail := model.EventDetail{
+			Event: model.Event{ID: 1, Title: "Test Event", OrganizerID: 1, DurationMinutes: 60, TimeZone: "Asia/Kolkata"},
+			ProposedSlots: []model.EventSlot{
+				{ID: 1, StartTime: time.Date(2025, 7, 14, 4, 30, 0, 0, time.UTC), EndTime: time.Date(2025, 7, 14, 5, 30, 0, 0, time.UTC), TimeZone: "Asia/Kolkata"},
+			},
+		}
+		mockEventService.On("GetEvent", req.Context(), int64(1)).Return(eventDetail, nil).Once()
+
+		eventHandler.GetEvent(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"start_time":"2025-07-14T10:00:00+05:30"`)
+	})
+
+	t.Run("with tz, should render the slots in the requested zone", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events/1?tz=America/New_York", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
+		w := httptest.NewRecorder()
+
+		eventDetail := model.EventDetail{
+			Event: model.Event{ID: 1, Title: "Test Event", OrganizerID: 1, DurationMinutes: 60, TimeZone: "Asia/Kolkata"},
+			ProposedSlots: []model.EventSlot{
+				{ID: 1, StartTime: time.Date(2025, 7, 14, 4, 30, 0, 0, time.UTC), EndTime: time.Date(2025, 7, 14, 5, 30, 0, 0, time.UTC), TimeZone: "Asia/Kolkata"},
+			},
+		}
+		mockEventService.On("GetEvent", req.Context(), int64(1)).Return(eventDetail, nil).Once()
+
+		eventHandler.GetEvent(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"start_time":"2025-07-14T00:30:00-04:00"`)
+	})
+}
+
+func TestListEvents(t *testing.T) {
+	mockEventService := new(mockService.MockEventService)
+	eventHandler := NewEventHandler(mockEventService)
+
+	t.Run("invalid organizer_id, should return bad request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events?organizer_id=abc", nil)
+		w := httptest.NewRecorder()
+
+		eventHandler.ListEvents(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid created_from, should return bad request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events?created_from=yesterday", nil)
+		w := httptest.NewRecorder()
+
+		eventHandler.ListEvents(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("unknown status, should return bad request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events?status=archived", nil)
+		w := httptest.NewRecorder()
+
+		eventHandler.ListEvents(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid cursor, should return bad request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events?cursor=not-a-cursor!", nil)
+		w := httptest.NewRecorder()
+
+		eventHandler.ListEvents(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("service error, should return internal server error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events", nil)
+		w := httptest.NewRecorder()
+
+		mockEventService.On("ListEvents", req.Context(), model.EventFilter{}).Return(model.EventList{}, assert.AnError).Once()
+
+		eventHandler.ListEvents(w, req)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("valid request, should pass filters to the service and return the page", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events?organizer_id=2&title=sync&status=confirmed&limit=5&created_from=2023-10-01T00:00:00Z&cursor="+utils.EncodeCursor(10), nil)
+		w := httptest.NewRecorder()
+
+		filter := model.EventFilter{
+			OrganizerID: 2,
+			CreatedFrom: time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC),
+			Title:       "sync",
+			Status:      model.EventStatusConfirmed,
+			AfterID:     10,
+			Limit:       5,
+		}
+		eventList := model.EventList{Events: []model.Event{{ID: 11, Title: "Weekly sync"}}, NextCursor: utils.EncodeCursor(11)}
+		mockEventService.On("ListEvents", req.Context(), filter).Return(eventList, nil).Once()
+
+		eventHandler.ListEvents(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"next_cursor":"`+utils.EncodeCursor(11)+`"`)
+		mockEventService.AssertExpectations(t)
+	})
+}
+
+func TestAddAttendee(t *testing.T) {
+	mockEventService := new(mockService.MockEventService)
+	eventHandler := NewEventHandler(mockEventService)
+
+	t.Run("missing user_id, should return validation error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/events/1/attendees", strings.NewReader(`{"required": true}`))
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
+		w := httptest.NewRecorder()
+
+		eventHandler.AddAttendee(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"name":"user_id"`)
+	})
+
+	t.Run("already an attendee, should return conflict", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/events/1/attendees", strings.NewReader(`{"user_id": 2}`))
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
+		w := httptest.NewRecorder()
+
+		mockEventService.On("AddAttendee", req.Context(), int64(1), model.Attendee{UserID: 2}).Return(&model.ConflictError{Message: "user 2 is already an attendee of event 1"}).Once()
+
+		eventHandler.AddAttendee(w, req)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("valid request, should return created status", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/events/1/attendees", strings.NewReader(`{"user_id": 3, "required": true}`))
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
+		w := httptest.NewRecorder()
+
+		mockEventService.On("AddAttendee", req.Context(), int64(1), model.Attendee{UserID: 3, Required: true}).Return(nil).Once()
+
+		eventHandler.AddAttendee(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockEventService.AssertExpectations(t)
+	})
+}
+
+func TestRemoveAttendee(t *testing.T) {
+	mockEventService := new(mockService.MockEventService)
+	eventHandler := NewEventHandler(mockEventService)
+
+	t.Run("invalid user_id, should return bad request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/events/1/attendees/abc", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1", "user_id": "abc"})
+		w := httptest.NewRecorder()
+
+		eventHandler.RemoveAttendee(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("not an attendee, should return not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/events/1/attendees/2", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1", "user_id": "2"})
+		w := httptest.NewRecorder()
+
+		mockEventService.On("RemoveAttendee", req.Context(), int64(1), int64(2)).Return(&model.NotFoundError{Resource: "attendee", ID: 2}).Once()
+
+		eventHandler.RemoveAttendee(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("valid request, should return ok status", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/events/1/attendees/3", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1", "user_id": "3"})
+		w := httptest.NewRecorder()
+
+		mockEventService.On("RemoveAttendee", req.Context(), int64(1), int64(3)).Return(nil).Once()
+
+		eventHandler.RemoveAttendee(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestListAttendees(t *testing.T) {
+	mockEventService := new(mockService.MockEventService)
+	eventHandler := NewEventHandler(mockEventService)
+
+	t.Run("service error, should return internal server error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events/1/attendees", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
+		w := httptest.NewRecorder()
+
+		mockEventService.On("ListAttendees", req.Context(), int64(1)).Return([]model.Attendee{}, assert.AnError).Once()
+
+		eventHandler.ListAttendees(w, req)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("valid request, should return the attendees", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events/1/attendees", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
+		w := httptest.NewRecorder()
+
+		mockEventService.On("ListAttendees", req.Context(), int64(1)).Return([]model.Attendee{{UserID: 2, Required: true, Weight: 1}}, nil).Once()
+
+		eventHandler.ListAttendees(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `[{"user_id":2,"required":true,"weight":1}]`, w.Body.String())
+	})
+}
+
+func TestSetEventException(t *testing.T) {
+	mockEventService := new(mockService.MockEventService)
+	eventHandler := NewEventHandler(mockEventService)
+
+	t.Run("invalid JSON request, should return bad request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/events/1/exceptions/2025-07-21", strings.NewReader(`\invalid_json`))
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1", "occurrence_date": "2025-07-21"})
+		w := httptest.NewRecorder()
+
+		eventHandler.SetEventException(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("valid request, should take the occurrence date from the path", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/events/1/exceptions/2025-07-21", strings.NewReader(`{"occurrence_date": "2025-07-28", "cancelled": true}`))
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1", "occurrence_date": "2025-07-21"})
+		w := httptest.NewRecorder()
+
+		mockEventService.On("SetEventException", req.Context(), int64(1), model.EventException{OccurrenceDate: "2025-07-21", Cancelled: true}).Return(nil).Once()
+
+		eventHandler.SetEventException(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockEventService.AssertExpectations(t)
+	})
+}
+
+func TestRemoveEventException(t *testing.T) {
+	mockEventService := new(mockService.MockEventService)
+	eventHandler := NewEventHandler(mockEventService)
+
+	t.Run("no exception, should return not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/events/1/exceptions/2025-07-21", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1", "occurrence_date": "2025-07-21"})
+		w := httptest.NewRecorder()
+
+		mockEventService.On("RemoveEventException", req.Context(), int64(1), "2025-07-21").Return(&model.NotFoundError{Resource: "exception for 2025-07-21 of event", ID: 1}).Once()
+
+		eventHandler.RemoveEventException(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("valid request, should return ok status", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/events/1/exceptions/2025-07-21", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1", "occurrence_date": "2025-07-21"})
+		w := httptest.NewRecorder()
+
+		mockEventService.On("RemoveEventException", req.Context(), int64(1), "2025-07-21").Return(nil).Once()
+
+		eventHandler.RemoveEventException(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestConfirmEvent(t *testing.T) {
+	mockEventService := new(mockService.MockEventService)
+	eventHandler := NewEventHandler(mockEventService)
+	confirmed := model.EventSlot{
+		StartTime: time.Date(2025, 07, 14, 4, 30, 0, 0, time.UTC),
+		EndTime:   time.Date(2025, 07, 14, 5, 30, 0, 0, time.UTC),
+		TimeZone:  "Asia/Kolkata",
+	}
+
+	t.Run("empty body, should confirm the top recommendation", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/events/1/confirm", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
+		w := httptest.NewRecorder()
+
+		mockEventService.On("ConfirmEvent", req.Context(), int64(1), (*model.EventSlot)(nil)).Return(confirmed, nil).Once()
+
+		eventHandler.ConfirmEvent(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"start_time":"2025-07-14T10:00:00+05:30"`)
+	})
+
+	t.Run("slot in the body, should confirm it", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/events/1/confirm", strings.NewReader(`{"start_time": "2025-07-14T04:30:00Z", "end_time": "2025-07-14T05:30:00Z"}`))
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
+		w := httptest.NewRecorder()
+
+		slot := &model.EventSlot{StartTime: confirmed.StartTime, EndTime: confirmed.EndTime}
+		mockEventService.On("ConfirmEvent", req.Context(), int64(1), slot).Return(confirmed, nil).Once()
+
+		eventHandler.ConfirmEvent(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockEventService.AssertExpectations(t)
+	})
+
+	t.Run("already confirmed, should return conflict", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/events/1/confirm", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
+		w := httptest.NewRecorder()
+
+		mockEventService.On("ConfirmEvent", req.Context(), int64(1), (*model.EventSlot)(nil)).Return(model.EventSlot{}, &model.ConflictError{Message: "event 1 is confirmed and can no longer be changed"}).Once()
+
+		eventHandler.ConfirmEvent(w, req)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("double booking, should return conflict listing the confirmed event", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/events/1/confirm", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
+		w := httptest.NewRecorder()
+
+		conflict := model.MeetingConflict{
+			UserID:  7,
+			First:   model.Meeting{EventID: 1, Slot: confirmed},
+			Second:  model.Meeting{EventID: 4, Title: "Review", Slot: confirmed},
+			Overlap: confirmed,
+		}
+		mockEventService.On("ConfirmEvent", req.Context(), int64(1), (*model.EventSlot)(nil)).Return(model.EventSlot{}, &model.ConflictError{
+			Message:   `event 1 would double-book its participants with confirmed event 4 "Review" of user 7`,
+			Conflicts: []model.MeetingConflict{conflict},
+		}).Once()
+
+		eventHandler.ConfirmEvent(w, req)
+		var body ErrorResponse
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, ErrCodeConflict, body.Code)
+		assert.Len(t, body.Conflicts, 1)
+		assert.Equal(t, int64(4), body.Conflicts[0].Second.EventID)
+		assert.Equal(t, int64(7), body.Conflicts[0].UserID)
+	})
+}
+
+func TestCancelEvent(t *testing.T) {
+	mockEventService := new(mockService.MockEventService)
+	eventHandler := NewEventHandler(mockEventService)
+
+	t.Run("invalid event_id, should return bad request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/events/abc/cancel", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "abc"})
+		w := httptest.NewRecorder()
+
+		eventHandler.CancelEvent(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("valid request, should return ok status", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/events/1/cancel", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
+		w := httptest.NewRecorder()
+
+		mockEventService.On("CancelEvent", req.Context(), int64(1)).Return(nil).Once()
+
+		eventHandler.CancelEvent(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
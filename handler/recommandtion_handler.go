@@ -2,10 +2,15 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
 	"github.com/rahulshewale153/meeting-scheduler-api/service"
 )
 
@@ -23,22 +28,108 @@ func (h *RecommendationHandler) GetRecommendedSlots(w http.ResponseWriter, r *ht
 	vars := mux.Vars(r)
 	eventIDStr := vars["event_id"]
 	if eventIDStr == "" {
-		http.Error(w, "event_id query parameter is required", http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "event_id query parameter is required")
 		return
 	}
 
 	eventID, err := strconv.ParseInt(eventIDStr, 10, 64)
 	if err != nil {
-		http.Error(w, "Invalid event_id", http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid event_id")
 		return
 	}
 
-	recommendedSlots, err := h.RecommendationService.GetRecommendedSlots(r.Context(), eventID)
+	options, err := parseRecommendationOptions(r.URL.Query())
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
 		return
 	}
 
+	loc, err := requestedTimeZone(r)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	recommendedSlots, err := h.RecommendationService.GetRecommendedSlots(r.Context(), eventID, options)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	// slots come back in the zone of the event unless the caller asks for another one
+	if loc != nil {
+		for i := range recommendedSlots {
+			recommendedSlots[i].Slot.StartTime = recommendedSlots[i].Slot.StartTime.In(loc)
+			recommendedSlots[i].Slot.EndTime = recommendedSlots[i].Slot.EndTime.In(loc)
+			for k := range recommendedSlots[i].Occurrences {
+				occurrence := &recommendedSlots[i].Occurrences[k]
+				occurrence.Slot.StartTime = occurrence.Slot.StartTime.In(loc)
+				occurrence.Slot.EndTime = occurrence.Slot.EndTime.In(loc)
+			}
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(recommendedSlots)
 }
+
+// parseRecommendationOptions reads the filter and ordering query parameters, range checks are left to the service
+func parseRecommendationOptions(query url.Values) (model.RecommendationOptions, error) {
+	options := model.RecommendationOptions{
+		TieBreak:     query.Get("tie_break"),
+		WorkingHours: query.Get("working_hours"),
+	}
+	var err error
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		options.Limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			return options, errors.New("Invalid limit")
+		}
+	}
+
+	if minAvailableStr := query.Get("min_available"); minAvailableStr != "" {
+		options.MinAvailable, err = strconv.Atoi(minAvailableStr)
+		if err != nil {
+			return options, errors.New("Invalid min_available")
+		}
+	}
+
+	if requireUsersStr := query.Get("require_users"); requireUsersStr != "" {
+		for _, userIDStr := range strings.Split(requireUsersStr, ",") {
+			userID, err := strconv.ParseInt(strings.TrimSpace(userIDStr), 10, 64)
+			if err != nil {
+				return options, errors.New("Invalid require_users, expected comma separated user ids")
+			}
+			options.RequireUsers = append(options.RequireUsers, userID)
+		}
+	}
+
+	if fromStr := query.Get("from"); fromStr != "" {
+		options.From, err = time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			return options, errors.New("Invalid from, expected RFC3339 time")
+		}
+	}
+
+	if toStr := query.Get("to"); toStr != "" {
+		options.To, err = time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			return options, errors.New("Invalid to, expected RFC3339 time")
+		}
+	}
+
+	if excludeWeekendsStr := query.Get("exclude_weekends"); excludeWeekendsStr != "" {
+		options.ExcludeWeekends, err = strconv.ParseBool(excludeWeekendsStr)
+		if err != nil {
+			return options, errors.New("Invalid exclude_weekends, expected true or false")
+		}
+	}
+
+	if occurrencesStr := query.Get("occurrences"); occurrencesStr != "" {
+		options.Occurrences, err = strconv.Atoi(occurrencesStr)
+		if err != nil {
+			return options, errors.New("Invalid occurrences")
+		}
+	}
+	return options, nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/gorilla/mux"
 	mockService "github.com/rahulshewale153/meeting-scheduler-api/mock/service"
 	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/rahulshewale153/meeting-scheduler-api/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -45,7 +46,7 @@ func TestRecommendationHandler_GetRecommendedSlots(t *testing.T) {
 		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
 		w := httptest.NewRecorder()
 
-		MockRecommendationService.On("GetRecommendedSlots", req.Context(), int64(1)).Return([]model.SlotRecommendation{}, assert.AnError).Once()
+		MockRecommendationService.On("GetRecommendedSlots", req.Context(), int64(1), model.RecommendationOptions{}).Return([]model.SlotRecommendation{}, assert.AnError).Once()
 
 		recommendationHandler.GetRecommendedSlots(w, req)
 
@@ -54,6 +55,20 @@ func TestRecommendationHandler_GetRecommendedSlots(t *testing.T) {
 		}
 	})
 
+	t.Run("GetRecommendedSlots should return not found when the event does not exist", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events/2/recommendation", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "2"})
+		w := httptest.NewRecorder()
+
+		MockRecommendationService.On("GetRecommendedSlots", req.Context(), int64(2), model.RecommendationOptions{}).Return([]model.SlotRecommendation{}, &model.NotFoundError{Resource: "event", ID: 2}).Once()
+
+		recommendationHandler.GetRecommendedSlots(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+		}
+	})
+
 	t.Run("GetRecommendedSlots should return recommended slots when service returns successfully", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/events/1/recommendation", nil)
 		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
@@ -69,7 +84,7 @@ func TestRecommendationHandler_GetRecommendedSlots(t *testing.T) {
 				Unavailable: []int64{3},
 			},
 		}
-		MockRecommendationService.On("GetRecommendedSlots", req.Context(), int64(1)).Return(expectedSlots, nil).Once()
+		MockRecommendationService.On("GetRecommendedSlots", req.Context(), int64(1), model.RecommendationOptions{}).Return(expectedSlots, nil).Once()
 
 		recommendationHandler.GetRecommendedSlots(w, req)
 
@@ -84,4 +99,106 @@ func TestRecommendationHandler_GetRecommendedSlots(t *testing.T) {
 		assert.Equal(t, expectedSlots, actualSlots)
 	})
 
+	t.Run("GetRecommendedSlots should pass the tie_break query parameter to the service", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events/1/recommendation?tie_break=most_available", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
+		w := httptest.NewRecorder()
+
+		options := model.RecommendationOptions{TieBreak: model.TieBreakMostAvailable}
+		MockRecommendationService.On("GetRecommendedSlots", req.Context(), int64(1), options).Return([]model.SlotRecommendation{}, nil).Once()
+
+		recommendationHandler.GetRecommendedSlots(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		MockRecommendationService.AssertExpectations(t)
+	})
+
+	t.Run("GetRecommendedSlots should return bad request when the service rejects the tie_break", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events/1/recommendation?tie_break=random", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
+		w := httptest.NewRecorder()
+
+		validationErr := &utils.ValidationError{}
+		validationErr.Add("tie_break", "random", "tie_break must be one of earliest, latest, most_available")
+		options := model.RecommendationOptions{TieBreak: "random"}
+		MockRecommendationService.On("GetRecommendedSlots", req.Context(), int64(1), options).Return([]model.SlotRecommendation{}, validationErr).Once()
+
+		recommendationHandler.GetRecommendedSlots(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("GetRecommendedSlots should pass the filter query parameters to the service", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events/1/recommendation?limit=5&min_available=2&require_users=1,%203&from=2025-07-14T00:00:00Z&to=2025-07-19T00:00:00Z&exclude_weekends=true&working_hours=exclude&occurrences=6", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
+		w := httptest.NewRecorder()
+
+		options := model.RecommendationOptions{
+			Limit:           5,
+			MinAvailable:    2,
+			RequireUsers:    []int64{1, 3},
+			From:            time.Date(2025, 07, 14, 0, 0, 0, 0, time.UTC),
+			To:              time.Date(2025, 07, 19, 0, 0, 0, 0, time.UTC),
+			ExcludeWeekends: true,
+			WorkingHours:    model.WorkingHoursExclude,
+			Occurrences:     6,
+		}
+		MockRecommendationService.On("GetRecommendedSlots", req.Context(), int64(1), options).Return([]model.SlotRecommendation{}, nil).Once()
+
+		recommendationHandler.GetRecommendedSlots(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		MockRecommendationService.AssertExpectations(t)
+	})
+
+	t.Run("GetRecommendedSlots should return bad request for malformed filter query parameters", func(t *testing.T) {
+		queries := []string{
+			"limit=ten",
+			"min_available=some",
+			"require_users=1,a",
+			"from=yesterday",
+			"to=2025-07-19",
+			"exclude_weekends=maybe",
+			"occurrences=all",
+		}
+		for _, query := range queries {
+			req := httptest.NewRequest(http.MethodGet, "/events/1/recommendation?"+query, nil)
+			req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
+			w := httptest.NewRecorder()
+
+			recommendationHandler.GetRecommendedSlots(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+	})
+
+	t.Run("GetRecommendedSlots should render the slots in the requested tz", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events/1/recommendation?tz=Asia/Kolkata", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
+		w := httptest.NewRecorder()
+
+		slots := []model.SlotRecommendation{{
+			Slot: model.EventSlot{
+				StartTime: time.Date(2025, 07, 14, 4, 30, 0, 0, time.UTC),
+				EndTime:   time.Date(2025, 07, 14, 5, 30, 0, 0, time.UTC),
+			},
+			Available: []int64{1},
+		}}
+		MockRecommendationService.On("GetRecommendedSlots", req.Context(), int64(1), model.RecommendationOptions{}).Return(slots, nil).Once()
+
+		recommendationHandler.GetRecommendedSlots(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"start_time":"2025-07-14T10:00:00+05:30"`)
+	})
+
+	t.Run("GetRecommendedSlots should return bad request for an unknown tz", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events/1/recommendation?tz=Local", nil)
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
+		w := httptest.NewRecorder()
+
+		recommendationHandler.GetRecommendedSlots(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
 }
@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/rahulshewale153/meeting-scheduler-api/service"
+	"github.com/rahulshewale153/meeting-scheduler-api/utils"
+)
+
+type ResourceHandler struct {
+	resourceService service.ResourceServiceI
+}
+
+func NewResourceHandler(resourceService service.ResourceServiceI) *ResourceHandler {
+	return &ResourceHandler{
+		resourceService: resourceService,
+	}
+}
+
+// InsertResource adds a room or a piece of equipment
+func (h *ResourceHandler) InsertResource(w http.ResponseWriter, r *http.Request) {
+	var resource model.Resource
+	if err := json.NewDecoder(r.Body).Decode(&resource); err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid request payload")
+		return
+	}
+	if errs, ok := utils.IsValid(resource); !ok {
+		writeValidationError(w, r, errs)
+		return
+	}
+
+	resourceID, err := h.resourceService.InsertResource(r.Context(), resource)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"resource_id": resourceID,
+		"message":     "Resource created successfully",
+	})
+}
+
+// UpdateResource replaces the details of a resource
+func (h *ResourceHandler) UpdateResource(w http.ResponseWriter, r *http.Request) {
+	resourceID, err := strconv.ParseInt(mux.Vars(r)["resource_id"], 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid resource_id")
+		return
+	}
+	var resource model.Resource
+	if err := json.NewDecoder(r.Body).Decode(&resource); err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid request payload")
+		return
+	}
+	if errs, ok := utils.IsValid(resource); !ok {
+		writeValidationError(w, r, errs)
+		return
+	}
+
+	resource.ID = resourceID
+	if err := h.resourceService.UpdateResource(r.Context(), resource); err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Resource updated successfully"})
+}
+
+// DeleteResource removes a resource that no event requires or booked
+func (h *ResourceHandler) DeleteResource(w http.ResponseWriter, r *http.Request) {
+	resourceID, err := strconv.ParseInt(mux.Vars(r)["resource_id"], 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid resource_id")
+		return
+	}
+
+	if err := h.resourceService.DeleteResource(r.Context(), resourceID); err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Resource deleted successfully"})
+}
+
+// GetResource returns a resource
+func (h *ResourceHandler) GetResource(w http.ResponseWriter, r *http.Request) {
+	resourceID, err := strconv.ParseInt(mux.Vars(r)["resource_id"], 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid resource_id")
+		return
+	}
+
+	resource, err := h.resourceService.GetResource(r.Context(), resourceID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resource)
+}
+
+// ListResources lists the resources matching ?kind=, ?min_capacity=, ?location= and every ?feature=
+func (h *ResourceHandler) ListResources(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := model.ResourceFilter{
+		Kind:     query.Get("kind"),
+		Location: query.Get("location"),
+		Features: query["feature"],
+	}
+	if filter.Kind != "" && filter.Kind != model.ResourceKindRoom && filter.Kind != model.ResourceKindEquipment {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid kind, expected room or equipment")
+		return
+	}
+	if minCapacityStr := query.Get("min_capacity"); minCapacityStr != "" {
+		minCapacity, err := strconv.Atoi(minCapacityStr)
+		if err != nil || minCapacity < 0 {
+			writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid min_capacity")
+			return
+		}
+		filter.MinCapacity = minCapacity
+	}
+
+	resources, err := h.resourceService.ListResources(r.Context(), filter)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resources)
+}
+
+// SetResourceAvailability replaces the windows a resource can be booked in
+func (h *ResourceHandler) SetResourceAvailability(w http.ResponseWriter, r *http.Request) {
+	resourceID, err := strconv.ParseInt(mux.Vars(r)["resource_id"], 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid resource_id")
+		return
+	}
+	var windows []model.EventSlot
+	if err := json.NewDecoder(r.Body).Decode(&windows); err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid request payload")
+		return
+	}
+
+	if err := h.resourceService.SetResourceAvailability(r.Context(), resourceID, windows); err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Resource availability updated successfully"})
+}
+
+// GetResourceAvailability returns the windows, bookings and free time of a resource between ?from= and ?to=
+func (h *ResourceHandler) GetResourceAvailability(w http.ResponseWriter, r *http.Request) {
+	resourceID, err := strconv.ParseInt(mux.Vars(r)["resource_id"], 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid resource_id")
+		return
+	}
+
+	query := r.URL.Query()
+	var from, to time.Time
+	if fromStr := query.Get("from"); fromStr != "" {
+		if from, err = time.Parse(time.RFC3339, fromStr); err != nil {
+			writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid from, expected RFC3339 time")
+			return
+		}
+	}
+	if toStr := query.Get("to"); toStr != "" {
+		if to, err = time.Parse(time.RFC3339, toStr); err != nil {
+			writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid to, expected RFC3339 time")
+			return
+		}
+	}
+	loc, err := requestedTimeZone(r)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	availability, err := h.resourceService.GetResourceAvailability(r.Context(), resourceID, from, to)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resourceAvailabilityInZone(availability, loc))
+}
+
+// AddEventResource attaches a resource requirement to an event
+func (h *ResourceHandler) AddEventResource(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.ParseInt(mux.Vars(r)["event_id"], 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid event_id")
+		return
+	}
+	var requirement model.ResourceRequirement
+	if err := json.NewDecoder(r.Body).Decode(&requirement); err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid request payload")
+		return
+	}
+	if errs, ok := utils.IsValid(requirement); !ok {
+		writeValidationError(w, r, errs)
+		return
+	}
+
+	requirementID, err := h.resourceService.AddEventResource(r.Context(), eventID, requirement)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"requirement_id": requirementID,
+		"message":        "Resource requirement added successfully",
+	})
+}
+
+// RemoveEventResource detaches a resource requirement from an event
+func (h *ResourceHandler) RemoveEventResource(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID, err := strconv.ParseInt(vars["event_id"], 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid event_id")
+		return
+	}
+	requirementID, err := strconv.ParseInt(vars["requirement_id"], 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid requirement_id")
+		return
+	}
+
+	if err := h.resourceService.RemoveEventResource(r.Context(), eventID, requirementID); err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Resource requirement removed successfully"})
+}
+
+// ListEventResources lists the resource requirements of an event with the resources booked for them
+func (h *ResourceHandler) ListEventResources(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.ParseInt(mux.Vars(r)["event_id"], 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid event_id")
+		return
+	}
+
+	requirements, err := h.resourceService.ListEventResources(r.Context(), eventID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, requirements)
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	mockService "github.com/rahulshewale153/meeting-scheduler-api/mock/service"
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertResource(t *testing.T) {
+	mockResourceService := new(mockService.MockResourceService)
+	resourceHandler := NewResourceHandler(mockResourceService)
+
+	t.Run("invalid payload, should return bad request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/resources", bytes.NewBufferString("{"))
+		w := httptest.NewRecorder()
+
+		resourceHandler.InsertResource(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("unknown kind, should return validation failed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/resources", bytes.NewBufferString(`{"name":"Board room","kind":"desk"}`))
+		w := httptest.NewRecorder()
+
+		resourceHandler.InsertResource(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), ErrCodeValidationFailed)
+	})
+
+	t.Run("caller is not an admin, should return forbidden", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/resources", bytes.NewBufferString(`{"name":"Projector","kind":"equipment"}`))
+		w := httptest.NewRecorder()
+		mockResourceService.On("InsertResource", req.Context(), model.Resource{Name: "Projector", Kind: model.ResourceKindEquipment}).Return(int64(0), &model.ForbiddenError{Message: "only admins can manage resources"}).Once()
+
+		resourceHandler.InsertResource(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("valid request, should return created", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/resources", bytes.NewBufferString(`{"name":"Board room","kind":"room","capacity":12,"features":["projector"]}`))
+		w := httptest.NewRecorder()
+		room := model.Resource{Name: "Board room", Kind: model.ResourceKindRoom, Capacity: 12, Features: []string{"projector"}}
+		mockResourceService.On("InsertResource", req.Context(), room).Return(int64(4), nil).Once()
+
+		resourceHandler.InsertResource(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"resource_id":4`)
+		mockResourceService.AssertExpectations(t)
+	})
+}
+
+func TestListResources(t *testing.T) {
+	mockResourceService := new(mockService.MockResourceService)
+	resourceHandler := NewResourceHandler(mockResourceService)
+
+	t.Run("invalid min_capacity, should return bad request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/resources?min_capacity=many", nil)
+		w := httptest.NewRecorder()
+
+		resourceHandler.ListResources(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("valid request, should filter by every feature", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/resources?kind=room&min_capacity=6&feature=projector&feature=whiteboard", nil)
+		w := httptest.NewRecorder()
+		filter := model.ResourceFilter{Kind: model.ResourceKindRoom, MinCapacity: 6, Features: []string{"projector", "whiteboard"}}
+		mockResourceService.On("ListResources", req.Context(), filter).Return([]model.Resource{{ID: 4, Name: "Board room", Kind: model.ResourceKindRoom, Capacity: 12}}, nil).Once()
+
+		resourceHandler.ListResources(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resources []model.Resource
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resources))
+		assert.Len(t, resources, 1)
+		mockResourceService.AssertExpectations(t)
+	})
+}
+
+func TestDeleteResource(t *testing.T) {
+	mockResourceService := new(mockService.MockResourceService)
+	resourceHandler := NewResourceHandler(mockResourceService)
+
+	t.Run("resource still booked, should return conflict", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/resources/4", nil)
+		req = mux.SetURLVars(req, map[string]string{"resource_id": "4"})
+		w := httptest.NewRecorder()
+		mockResourceService.On("DeleteResource", req.Context(), int64(4)).Return(&model.ConflictError{Message: "resource 4 is required or booked by events"}).Once()
+
+		resourceHandler.DeleteResource(w, req)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestGetResourceAvailability(t *testing.T) {
+	mockResourceService := new(mockService.MockResourceService)
+	resourceHandler := NewResourceHandler(mockResourceService)
+
+	t.Run("invalid to, should return bad request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/resources/4/availability?to=friday", nil)
+		req = mux.SetURLVars(req, map[string]string{"resource_id": "4"})
+		w := httptest.NewRecorder()
+
+		resourceHandler.GetResourceAvailability(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Invalid to")
+	})
+
+	t.Run("valid request, should return the free time in the requested zone", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/resources/4/availability?from=2025-07-14T00:00:00Z&to=2025-07-15T00:00:00Z&tz=Asia/Kolkata", nil)
+		req = mux.SetURLVars(req, map[string]string{"resource_id": "4"})
+		w := httptest.NewRecorder()
+		from := time.Date(2025, 07, 14, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2025, 07, 15, 0, 0, 0, 0, time.UTC)
+		mockResourceService.On("GetResourceAvailability", req.Context(), int64(4), from, to).Return(model.ResourceAvailability{
+			ResourceID: 4,
+			Windows:    []model.EventSlot{},
+			Booked:     []model.Meeting{},
+			Free:       []model.EventSlot{{StartTime: from.Add(9 * time.Hour), EndTime: from.Add(17 * time.Hour)}},
+		}, nil).Once()
+
+		resourceHandler.GetResourceAvailability(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"start_time":"2025-07-14T14:30:00+05:30"`)
+		mockResourceService.AssertExpectations(t)
+	})
+}
+
+func TestAddEventResource(t *testing.T) {
+	mockResourceService := new(mockService.MockResourceService)
+	resourceHandler := NewResourceHandler(mockResourceService)
+
+	t.Run("invalid event_id, should return bad request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/events/abc/resources", bytes.NewBufferString(`{"kind":"room"}`))
+		req = mux.SetURLVars(req, map[string]string{"event_id": "abc"})
+		w := httptest.NewRecorder()
+
+		resourceHandler.AddEventResource(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("valid request, should return created", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/events/1/resources", bytes.NewBufferString(`{"kind":"room","min_capacity":6}`))
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
+		w := httptest.NewRecorder()
+		mockResourceService.On("AddEventResource", req.Context(), int64(1), model.ResourceRequirement{Kind: model.ResourceKindRoom, MinCapacity: 6}).Return(int64(3), nil).Once()
+
+		resourceHandler.AddEventResource(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"requirement_id":3`)
+		mockResourceService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/middleware"
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/rahulshewale153/meeting-scheduler-api/utils"
+)
+
+// stable error codes, clients should switch on these instead of the message
+const (
+	ErrCodeInvalidRequest   = "invalid_request"
+	ErrCodeValidationFailed = "validation_failed"
+	ErrCodeNotFound         = "not_found"
+	ErrCodeConflict         = "conflict"
+	ErrCodeUnauthorized     = "unauthorized"
+	ErrCodeForbidden        = "forbidden"
+	ErrCodeMethodNotAllowed = "method_not_allowed"
+	ErrCodeInternal         = "internal_error"
+)
+
+// ErrorResponse is the envelope returned by every endpoint on failure
+type ErrorResponse struct {
+	Code     string        `json:"code"`
+	Message  string        `json:"message"`
+	Fields   []utils.Field `json:"fields,omitempty"`
+	Resource string        `json:"resource,omitempty"`
+	ID       int64         `json:"id,omitempty"`
+	// Conflicts are the meetings a request clashes with
+	Conflicts []model.MeetingConflict `json:"conflicts,omitempty"`
+	RequestID string                  `json:"request_id,omitempty"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Println("Error encoding response:", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, status int, code string, message string) {
+	writeJSON(w, status, ErrorResponse{
+		Code:      code,
+		Message:   message,
+		RequestID: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+// writeValidationError reports every invalid field returned by utils.IsValid
+func writeValidationError(w http.ResponseWriter, r *http.Request, errs utils.ErrorData) {
+	log.Printf("Validation failed: %v", errs)
+	writeJSON(w, http.StatusBadRequest, ErrorResponse{
+		Code:      ErrCodeValidationFailed,
+		Message:   "Validation failed",
+		Fields:    errs.Field,
+		RequestID: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+// writeServiceError maps an error returned by the service layer to the matching http response
+func writeServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	var validationErr *utils.ValidationError
+	if errors.As(err, &validationErr) {
+		log.Printf("Validation failed: %v", validationErr.Errors)
+	}
+	status, body := serviceErrorResponse(r, err)
+	writeJSON(w, status, body)
+}
+
+// serviceErrorResponse returns the status and the envelope an error returned by the service layer is reported with
+func serviceErrorResponse(r *http.Request, err error) (int, ErrorResponse) {
+	var notFoundErr *model.NotFoundError
+	var validationErr *utils.ValidationError
+	var conflictErr *model.ConflictError
+	body := ErrorResponse{Message: err.Error(), RequestID: middleware.RequestIDFromContext(r.Context())}
+	switch {
+	case errors.As(err, &validationErr):
+		body.Code, body.Message, body.Fields = ErrCodeValidationFailed, "Validation failed", validationErr.Errors.Field
+		return http.StatusBadRequest, body
+	case errors.As(err, &notFoundErr):
+		body.Code, body.Resource, body.ID = ErrCodeNotFound, notFoundErr.Resource, notFoundErr.ID
+		return http.StatusNotFound, body
+	case errors.Is(err, model.ErrNotFound):
+		body.Code = ErrCodeNotFound
+		return http.StatusNotFound, body
+	case errors.Is(err, model.ErrForbidden):
+		body.Code = ErrCodeForbidden
+		return http.StatusForbidden, body
+	case errors.As(err, &conflictErr):
+		body.Code, body.Conflicts = ErrCodeConflict, conflictErr.Conflicts
+		return http.StatusConflict, body
+	case errors.Is(err, model.ErrConflict):
+		body.Code = ErrCodeConflict
+		return http.StatusConflict, body
+	default:
+		// the cause may hold driver or SQL details, it is only logged
+		log.Printf("Internal error (request %s): %v", body.RequestID, err)
+		body.Code, body.Message = ErrCodeInternal, "internal error"
+		return http.StatusInternalServerError, body
+	}
+}
+
+// RouteNotFound answers requests for unknown routes with the error envelope
+func RouteNotFound(w http.ResponseWriter, r *http.Request) {
+	writeError(w, r, http.StatusNotFound, ErrCodeNotFound, "route not found")
+}
+
+// MethodNotAllowed answers requests using an unsupported method with the error envelope
+func MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed, "method not allowed")
+}
+
+// Unauthorized answers requests whose caller could not be authenticated with the error envelope
+func Unauthorized(w http.ResponseWriter, r *http.Request, message string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="meeting-scheduler"`)
+	writeError(w, r, http.StatusUnauthorized, ErrCodeUnauthorized, message)
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/middleware"
+	mockService "github.com/rahulshewale153/meeting-scheduler-api/mock/service"
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/rahulshewale153/meeting-scheduler-api/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteServiceError(t *testing.T) {
+	testCases := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{"not found error", &model.NotFoundError{Resource: "event", ID: 1}, http.StatusNotFound, ErrCodeNotFound},
+		{"wrapped not found error", fmt.Errorf("loading event: %w", &model.NotFoundError{Resource: "event", ID: 1}), http.StatusNotFound, ErrCodeNotFound},
+		{"validation error", &utils.ValidationError{Errors: utils.ErrorData{Field: []utils.Field{{Name: "proposed_slots[0]", ErrorMessage: "proposed_slots[0] overlaps proposed_slots[1]"}}}}, http.StatusBadRequest, ErrCodeValidationFailed},
+		{"conflict error", &model.ConflictError{Message: "slot already taken"}, http.StatusConflict, ErrCodeConflict},
+		{"forbidden error", &model.ForbiddenError{Message: "only the organizer can change event 1"}, http.StatusForbidden, ErrCodeForbidden},
+		{"unknown error", assert.AnError, http.StatusInternalServerError, ErrCodeInternal},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/events/1", nil)
+			req = req.WithContext(middleware.WithRequestID(req.Context(), "req-1"))
+			w := httptest.NewRecorder()
+
+			writeServiceError(w, req, tc.err)
+
+			var body ErrorResponse
+			assert.Equal(t, tc.status, w.Code)
+			assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, tc.code, body.Code)
+			assert.Equal(t, "req-1", body.RequestID)
+		})
+	}
+}
+
+func TestValidationErrorResponse(t *testing.T) {
+	eventHandler := NewEventHandler(new(mockService.MockEventService))
+	req := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(`{"organizer_id": 1, "proposed_slots": []}`))
+	w := httptest.NewRecorder()
+
+	eventHandler.InsertEvent(w, req)
+
+	var body ErrorResponse
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, ErrCodeValidationFailed, body.Code)
+	fieldNames := []string{}
+	for _, field := range body.Fields {
+		fieldNames = append(fieldNames, field.Name)
+	}
+	assert.Contains(t, fieldNames, "title")
+	assert.Contains(t, fieldNames, "duration_minutes")
+}
+
+func TestInternalErrorResponse(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/events/1", nil)
+	req = req.WithContext(middleware.WithRequestID(req.Context(), "req-1"))
+	w := httptest.NewRecorder()
+
+	writeServiceError(w, req, fmt.Errorf("Error 1146 (42S02): Table 'scheduler.event_detail' doesn't exist"))
+
+	var body ErrorResponse
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, ErrorResponse{Code: ErrCodeInternal, Message: "internal error", RequestID: "req-1"}, body)
+}
+
+func TestUnauthorized(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	req = req.WithContext(middleware.WithRequestID(req.Context(), "req-1"))
+	w := httptest.NewRecorder()
+
+	Unauthorized(w, req, "token has expired")
+
+	var body ErrorResponse
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Header().Get("WWW-Authenticate"), "Bearer")
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, ErrorResponse{Code: ErrCodeUnauthorized, Message: "token has expired", RequestID: "req-1"}, body)
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/rahulshewale153/meeting-scheduler-api/utils"
+)
+
+var errInvalidTimeZone = errors.New("Invalid tz, expected an IANA time zone such as Asia/Kolkata")
+
+// requestedTimeZone returns the zone asked for with ?tz=, nil when the caller did not ask for one
+func requestedTimeZone(r *http.Request) (*time.Location, error) {
+	name := r.URL.Query().Get("tz")
+	if name == "" {
+		return nil, nil
+	}
+	loc, err := utils.LoadTimeZone(name)
+	if err != nil {
+		return nil, errInvalidTimeZone
+	}
+	return loc, nil
+}
+
+// eventInZone renders the timestamps of an event in loc, leaving them untouched when loc is nil.
+// The confirmed slot is shown in the zone it was confirmed in unless loc is given
+func eventInZone(event model.Event, loc *time.Location) model.Event {
+	if event.ConfirmedSlot != nil {
+		confirmed := utils.SlotsInZone([]model.EventSlot{*event.ConfirmedSlot}, loc)[0]
+		event.ConfirmedSlot = &confirmed
+	}
+	if loc == nil {
+		return event
+	}
+	event.CreatedAt = event.CreatedAt.In(loc)
+	event.UpdatedAt = event.UpdatedAt.In(loc)
+	return event
+}
+
+// exceptionsInZone renders the times of moved occurrences in loc, leaving them untouched when loc is nil
+func exceptionsInZone(exceptions []model.EventException, loc *time.Location) []model.EventException {
+	if loc == nil {
+		return exceptions
+	}
+	for i := range exceptions {
+		if !exceptions[i].Cancelled {
+			exceptions[i].StartTime = exceptions[i].StartTime.In(loc)
+			exceptions[i].EndTime = exceptions[i].EndTime.In(loc)
+		}
+	}
+	return exceptions
+}
+
+// conflictsInZone renders the meetings of each conflict in loc, or each in the zone of its event when loc is nil
+func conflictsInZone(conflicts []model.MeetingConflict, loc *time.Location) []model.MeetingConflict {
+	for i := range conflicts {
+		slots := utils.SlotsInZone([]model.EventSlot{conflicts[i].First.Slot, conflicts[i].Second.Slot, conflicts[i].Overlap}, loc)
+		conflicts[i].First.Slot, conflicts[i].Second.Slot, conflicts[i].Overlap = slots[0], slots[1], slots[2]
+	}
+	return conflicts
+}
+
+// resourceAvailabilityInZone renders the windows, bookings and free time of a resource in loc, or each booking in
+// the zone of its event when loc is nil
+func resourceAvailabilityInZone(availability model.ResourceAvailability, loc *time.Location) model.ResourceAvailability {
+	availability.Windows = utils.SlotsInZone(availability.Windows, loc)
+	availability.Free = utils.SlotsInZone(availability.Free, loc)
+	for i := range availability.Booked {
+		availability.Booked[i].Slot = utils.SlotsInZone([]model.EventSlot{availability.Booked[i].Slot}, loc)[0]
+	}
+	return availability
+}
+
+// rulesInZone renders the first occurrence of each rule in loc, or in the zone of the rule when loc is nil
+func rulesInZone(rules []model.AvailabilityRule, loc *time.Location) []model.AvailabilityRule {
+	for i := range rules {
+		slot := utils.SlotsInZone([]model.EventSlot{{StartTime: rules[i].StartTime, EndTime: rules[i].EndTime, TimeZone: rules[i].TimeZone}}, loc)[0]
+		rules[i].StartTime, rules[i].EndTime = slot.StartTime, slot.EndTime
+	}
+	return rules
+}
@@ -2,12 +2,15 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"io"
+	"mime"
 	"net/http"
 	"strconv"
 
 	"github.com/gorilla/mux"
+	"github.com/rahulshewale153/meeting-scheduler-api/middleware"
 	"github.com/rahulshewale153/meeting-scheduler-api/model"
 	"github.com/rahulshewale153/meeting-scheduler-api/service"
 	"github.com/rahulshewale153/meeting-scheduler-api/utils"
@@ -28,45 +31,44 @@ func (h *UserAvailabilityHandler) InsertUserAvailability(w http.ResponseWriter,
 	var userAvailability model.UserAvailability
 	err := json.NewDecoder(r.Body).Decode(&userAvailability)
 	if err != nil {
-		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid request payload")
 		return
 	}
 
 	vars := mux.Vars(r)
 	eventIDStr := vars["event_id"]
 	if eventIDStr == "" {
-		http.Error(w, "event_id is required", http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "event_id is required")
 		return
 	}
 
 	eventID, err := strconv.ParseInt(eventIDStr, 10, 64)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Invalid event_id: %v", err), http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, fmt.Sprintf("Invalid event_id: %v", err))
 		return
 	}
 
 	userIDStr := vars["user_id"]
 	if userIDStr == "" {
-		http.Error(w, "user_id is required", http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "user_id is required")
 		return
 	}
 	userID, err := strconv.ParseInt(userIDStr, 10, 64)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Invalid user_id: %v", err), http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, fmt.Sprintf("Invalid user_id: %v", err))
 		return
 	}
 
 	userAvailability.UserID = userID
 	userAvailability.EventID = eventID
 	if errs, ok := utils.IsValid(userAvailability); !ok {
-		log.Printf("Validation failed: %v", errs)
-		http.Error(w, fmt.Sprintf("Validation failed: %v", errs.Error), http.StatusBadRequest)
+		writeValidationError(w, r, errs)
 		return
 	}
 
 	err = h.userAvailabilityService.InsertUserAvailability(r.Context(), userAvailability)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeServiceError(w, r, err)
 		return
 	}
 
@@ -74,50 +76,108 @@ func (h *UserAvailabilityHandler) InsertUserAvailability(w http.ResponseWriter,
 	json.NewEncoder(w).Encode(map[string]string{"message": "User availability inserted successfully"})
 }
 
+// availabilityBatchResponse reports an availability batch item by item. When the batch was rejected it carries the
+// code and message of the first failed item
+type availabilityBatchResponse struct {
+	Code      string                  `json:"code,omitempty"`
+	Message   string                  `json:"message,omitempty"`
+	Stored    int                     `json:"stored"`
+	Failed    int                     `json:"failed"`
+	Items     []availabilityBatchItem `json:"items"`
+	RequestID string                  `json:"request_id,omitempty"`
+}
+
+type availabilityBatchItem struct {
+	model.AvailabilityBatchItem
+	Error *ErrorResponse `json:"error,omitempty"`
+}
+
+// InsertUserAvailabilityBatch stores the availability of many users for an event at once, either every item is
+// stored or none is
+func (h *UserAvailabilityHandler) InsertUserAvailabilityBatch(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.ParseInt(mux.Vars(r)["event_id"], 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid event_id")
+		return
+	}
+	var batch model.UserAvailabilityBatch
+	if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid request payload")
+		return
+	}
+
+	result, err := h.userAvailabilityService.InsertUserAvailabilityBatch(r.Context(), eventID, batch.Items)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	status := http.StatusCreated
+	response := availabilityBatchResponse{
+		Stored:    result.Stored,
+		Failed:    result.Failed,
+		Items:     make([]availabilityBatchItem, 0, len(result.Items)),
+		RequestID: middleware.RequestIDFromContext(r.Context()),
+	}
+	for _, item := range result.Items {
+		reported := availabilityBatchItem{AvailabilityBatchItem: item}
+		if item.Err != nil {
+			itemStatus, body := serviceErrorResponse(r, item.Err)
+			body.RequestID = ""
+			reported.Error = &body
+			// the batch is reported with the status of its first failed item
+			if response.Code == "" {
+				status, response.Code, response.Message = itemStatus, body.Code, fmt.Sprintf("items[%d]: %s", item.Index, body.Message)
+			}
+		}
+		response.Items = append(response.Items, reported)
+	}
+	writeJSON(w, status, response)
+}
+
 // UpdateUserAvailability updates the availability of a user for a specific event
 func (h *UserAvailabilityHandler) UpdateUserAvailability(w http.ResponseWriter, r *http.Request) {
 	var userAvailability model.UserAvailability
 	err := json.NewDecoder(r.Body).Decode(&userAvailability)
 	if err != nil {
-		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid request payload")
 		return
 	}
 
 	vars := mux.Vars(r)
 	eventIDStr := vars["event_id"]
 	if eventIDStr == "" {
-		http.Error(w, "event_id is required", http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "event_id is required")
 		return
 	}
 
 	eventID, err := strconv.ParseInt(eventIDStr, 10, 64)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Invalid event_id: %v", err), http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, fmt.Sprintf("Invalid event_id: %v", err))
 		return
 	}
 
 	userIDStr := vars["user_id"]
 	if userIDStr == "" {
-		http.Error(w, "user_id is required", http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "user_id is required")
 		return
 	}
 	userID, err := strconv.ParseInt(userIDStr, 10, 64)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Invalid user_id: %v", err), http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, fmt.Sprintf("Invalid user_id: %v", err))
 		return
 	}
 
 	userAvailability.UserID = userID
 	userAvailability.EventID = eventID
 	if errs, ok := utils.IsValid(userAvailability); !ok {
-		log.Printf("Validation failed: %v", errs)
-		http.Error(w, fmt.Sprintf("Validation failed: %v", errs.Error), http.StatusBadRequest)
+		writeValidationError(w, r, errs)
 		return
 	}
 
 	err = h.userAvailabilityService.UpdateUserAvailability(r.Context(), userAvailability)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeServiceError(w, r, err)
 		return
 	}
 
@@ -130,33 +190,39 @@ func (h *UserAvailabilityHandler) GetUserAvailability(w http.ResponseWriter, r *
 	vars := mux.Vars(r)
 	eventIDStr := vars["event_id"]
 	if eventIDStr == "" {
-		http.Error(w, "event_id is required", http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "event_id is required")
 		return
 	}
 
 	eventID, err := strconv.ParseInt(eventIDStr, 10, 64)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Invalid event_id: %v", err), http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, fmt.Sprintf("Invalid event_id: %v", err))
 		return
 	}
 
 	userIDStr := vars["user_id"]
 	if userIDStr == "" {
-		http.Error(w, "user_id is required", http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "user_id is required")
 		return
 	}
 	userID, err := strconv.ParseInt(userIDStr, 10, 64)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Invalid user_id: %v", err), http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, fmt.Sprintf("Invalid user_id: %v", err))
+		return
+	}
+
+	loc, err := requestedTimeZone(r)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
 		return
 	}
 
 	slots, err := h.userAvailabilityService.GetUserAvailability(r.Context(), eventID, userID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeServiceError(w, r, err)
 		return
 	}
-	fmt.Println(slots)
+	slots = utils.SlotsInZone(slots, loc)
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(slots)
 }
@@ -166,33 +232,146 @@ func (h *UserAvailabilityHandler) DeleteUserAvailability(w http.ResponseWriter,
 	vars := mux.Vars(r)
 	eventIDStr := vars["event_id"]
 	if eventIDStr == "" {
-		http.Error(w, "event_id is required", http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "event_id is required")
 		return
 	}
 
 	eventID, err := strconv.ParseInt(eventIDStr, 10, 64)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Invalid event_id: %v", err), http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, fmt.Sprintf("Invalid event_id: %v", err))
 		return
 	}
 
 	userIDStr := vars["user_id"]
 	if userIDStr == "" {
-		http.Error(w, "user_id is required", http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "user_id is required")
 		return
 	}
 	userID, err := strconv.ParseInt(userIDStr, 10, 64)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Invalid user_id: %v", err), http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, fmt.Sprintf("Invalid user_id: %v", err))
 		return
 	}
 
 	err = h.userAvailabilityService.DeleteUserAvailability(r.Context(), userID, eventID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeServiceError(w, r, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]string{"message": "User availability deleted successfully"})
 }
+
+// maxCalendarBytes bounds an uploaded iCalendar file
+const maxCalendarBytes = 1 << 20
+
+// ImportUserAvailability stores the free parts of the proposed slots as read from an uploaded iCalendar file,
+// sent either as the raw body or as the "file" field of a multipart form
+func (h *UserAvailabilityHandler) ImportUserAvailability(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID, err := strconv.ParseInt(vars["event_id"], 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, fmt.Sprintf("Invalid event_id: %v", err))
+		return
+	}
+	userID, err := strconv.ParseInt(vars["user_id"], 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, fmt.Sprintf("Invalid user_id: %v", err))
+		return
+	}
+
+	loc, err := requestedTimeZone(r)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	calendar, err := readCalendarUpload(w, r)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	result, err := h.userAvailabilityService.ImportUserAvailability(r.Context(), eventID, userID, calendar)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	result.Busy = utils.SlotsInZone(result.Busy, loc)
+	result.Imported = utils.SlotsInZone(result.Imported, loc)
+	writeJSON(w, http.StatusCreated, result)
+}
+
+// readCalendarUpload returns the uploaded iCalendar file, at most maxCalendarBytes long
+func readCalendarUpload(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxCalendarBytes)
+
+	var body io.Reader = r.Body
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			return nil, errors.New("Invalid upload, expected the calendar in the file field")
+		}
+		defer file.Close()
+		body = file
+	}
+
+	calendar, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid upload, the calendar must be at most %d bytes", maxCalendarBytes)
+	}
+	if len(calendar) == 0 {
+		return nil, errors.New("Invalid upload, the calendar is empty")
+	}
+	return calendar, nil
+}
+
+// ListUserEventAvailability lists the events a user is invited to or responded to with what they offered for each,
+// optionally filtered by ?status=, a page of ?limit= at a time from ?cursor=
+func (h *UserAvailabilityHandler) ListUserEventAvailability(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.ParseInt(mux.Vars(r)["user_id"], 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid user_id")
+		return
+	}
+
+	query := r.URL.Query()
+	var filter model.UserEventFilter
+	if limitStr := query.Get("limit"); limitStr != "" {
+		filter.Limit, err = strconv.Atoi(limitStr)
+		if err != nil || filter.Limit < 0 {
+			writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid limit")
+			return
+		}
+	}
+	filter.AfterID, err = utils.DecodeCursor(query.Get("cursor"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid cursor")
+		return
+	}
+	filter.Status = query.Get("status")
+	switch filter.Status {
+	case "", model.EventStatusDraft, model.EventStatusPolling, model.EventStatusConfirmed, model.EventStatusCancelled:
+	default:
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid status, expected one of draft, polling, confirmed, cancelled")
+		return
+	}
+	loc, err := requestedTimeZone(r)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	result, err := h.userAvailabilityService.ListUserEventAvailability(r.Context(), userID, filter)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	for i := range result.Events {
+		result.Events[i].Event = eventInZone(result.Events[i].Event, loc)
+		result.Events[i].Availability = utils.SlotsInZone(result.Events[i].Availability, loc)
+		result.Events[i].Rules = rulesInZone(result.Events[i].Rules, loc)
+	}
+	writeJSON(w, http.StatusOK, result)
+}
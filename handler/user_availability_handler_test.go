@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"bytes"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -10,6 +12,7 @@ import (
 	"github.com/gorilla/mux"
 	mockService "github.com/rahulshewale153/meeting-scheduler-api/mock/service"
 	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/rahulshewale153/meeting-scheduler-api/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -70,6 +73,29 @@ func TestInsertUserAvailability(t *testing.T) {
 		assert.Contains(t, w.Body.String(), `{"message":"User availability inserted successfully"}`)
 	})
 
+	t.Run("recurring rules only, should pass them to the service", func(t *testing.T) {
+		rulesRequest := `{"time_zone": "Asia/Kolkata", "rules": [{"start_time": "2025-07-07T10:00:00+05:30", "end_time": "2025-07-07T12:00:00+05:30", "rrule": "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"}]}`
+		req := httptest.NewRequest(http.MethodPost, "/events/1/availability/1", strings.NewReader(rulesRequest))
+		req = mux.SetURLVars(req, map[string]string{"user_id": "1", "event_id": "1"})
+		w := httptest.NewRecorder()
+		mockUserAvailService.On("InsertUserAvailability", req.Context(), mock.MatchedBy(func(userAvailability model.UserAvailability) bool {
+			return len(userAvailability.Availability) == 0 && len(userAvailability.Rules) == 1 && userAvailability.Rules[0].RRule == "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"
+		})).Return(nil).Once()
+
+		userAvailabilityHandler.InsertUserAvailability(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockUserAvailService.AssertExpectations(t)
+	})
+
+	t.Run("neither slots nor rules, should return bad request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/events/1/availability/1", strings.NewReader(`{"time_zone": "UTC"}`))
+		req = mux.SetURLVars(req, map[string]string{"user_id": "1", "event_id": "1"})
+		w := httptest.NewRecorder()
+
+		userAvailabilityHandler.InsertUserAvailability(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "availability")
+	})
 }
 
 func TestUpdateUserAvailability(t *testing.T) {
@@ -112,7 +138,7 @@ func TestUpdateUserAvailability(t *testing.T) {
 
 		userAvailabilityHandler.UpdateUserAvailability(w, req)
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
-		assert.Contains(t, w.Body.String(), assert.AnError.Error())
+		assert.NotContains(t, w.Body.String(), assert.AnError.Error())
 		mockUserAvailService.AssertExpectations(t)
 	})
 
@@ -160,7 +186,19 @@ func TestGetUserAvailability(t *testing.T) {
 
 		userAvailabilityHandler.GetUserAvailability(w, req)
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
-		assert.Contains(t, w.Body.String(), assert.AnError.Error())
+		assert.NotContains(t, w.Body.String(), assert.AnError.Error())
+		mockUserAvailService.AssertExpectations(t)
+	})
+
+	t.Run("no availability submitted, should return not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events/1/availability/2", nil)
+		req = mux.SetURLVars(req, map[string]string{"user_id": "2", "event_id": "1"})
+		w := httptest.NewRecorder()
+		mockUserAvailService.On("GetUserAvailability", req.Context(), int64(1), int64(2)).Return(nil, &model.NotFoundError{Resource: "user availability", ID: 2}).Once()
+
+		userAvailabilityHandler.GetUserAvailability(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), `"code":"not_found"`)
 		mockUserAvailService.AssertExpectations(t)
 	})
 
@@ -211,7 +249,7 @@ func TestDeleteUserAvailability(t *testing.T) {
 
 		userAvailabilityHandler.DeleteUserAvailability(w, req)
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
-		assert.Contains(t, w.Body.String(), assert.AnError.Error())
+		assert.NotContains(t, w.Body.String(), assert.AnError.Error())
 		mockUserAvailService.AssertExpectations(t)
 	})
 
@@ -229,3 +267,230 @@ func TestDeleteUserAvailability(t *testing.T) {
 	})
 
 }
+
+func TestImportUserAvailability(t *testing.T) {
+	mockUserAvailService := new(mockService.MockUserAvailabilityService)
+	userAvailabilityHandler := NewUserAvailabilityHandler(mockUserAvailService)
+	calendar := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nEND:VCALENDAR\r\n"
+	vars := map[string]string{"event_id": "2", "user_id": "1"}
+
+	t.Run("invalid event_id, should return bad request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/events/abc/availability/1/import", strings.NewReader(calendar))
+		req = mux.SetURLVars(req, map[string]string{"event_id": "abc", "user_id": "1"})
+		w := httptest.NewRecorder()
+
+		userAvailabilityHandler.ImportUserAvailability(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("empty upload, should return bad request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/events/2/availability/1/import", strings.NewReader(""))
+		req = mux.SetURLVars(req, vars)
+		w := httptest.NewRecorder()
+
+		userAvailabilityHandler.ImportUserAvailability(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockUserAvailService.AssertNotCalled(t, "ImportUserAvailability", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("unreadable calendar, should return validation error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/events/2/availability/1/import", strings.NewReader("not a calendar"))
+		req = mux.SetURLVars(req, vars)
+		w := httptest.NewRecorder()
+		validationErr := &utils.ValidationError{}
+		validationErr.Add("calendar", nil, "calendar is invalid: not an iCalendar object")
+		mockUserAvailService.On("ImportUserAvailability", req.Context(), int64(2), int64(1), []byte("not a calendar")).Return(model.AvailabilityImport{}, validationErr).Once()
+
+		userAvailabilityHandler.ImportUserAvailability(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), ErrCodeValidationFailed)
+	})
+
+	t.Run("raw calendar body, should import it and report the result", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/events/2/availability/1/import?tz=Asia/Kolkata", strings.NewReader(calendar))
+		req.Header.Set("Content-Type", "text/calendar")
+		req = mux.SetURLVars(req, vars)
+		w := httptest.NewRecorder()
+		start := time.Date(2025, 07, 14, 10, 15, 0, 0, time.UTC)
+		mockUserAvailService.On("ImportUserAvailability", req.Context(), int64(2), int64(1), []byte(calendar)).Return(model.AvailabilityImport{
+			UserID:   1,
+			EventID:  2,
+			Busy:     []model.EventSlot{},
+			Imported: []model.EventSlot{{ID: 7, StartTime: start, EndTime: start.Add(45 * time.Minute), TimeZone: "Europe/Berlin"}},
+		}, nil).Once()
+
+		userAvailabilityHandler.ImportUserAvailability(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.JSONEq(t, `{"user_id": 1, "event_id": 2, "busy": [], "imported": [
+			{"id": 7, "start_time": "2025-07-14T15:45:00+05:30", "end_time": "2025-07-14T16:30:00+05:30", "time_zone": "Europe/Berlin"}
+		]}`, w.Body.String())
+	})
+
+	t.Run("multipart upload, should read the file field", func(t *testing.T) {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		file, err := form.CreateFormFile("file", "calendar.ics")
+		assert.NoError(t, err)
+		file.Write([]byte(calendar))
+		form.Close()
+
+		req := httptest.NewRequest(http.MethodPost, "/events/2/availability/1/import", &body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		req = mux.SetURLVars(req, vars)
+		w := httptest.NewRecorder()
+		mockUserAvailService.On("ImportUserAvailability", req.Context(), int64(2), int64(1), []byte(calendar)).Return(model.AvailabilityImport{UserID: 1, EventID: 2}, nil).Once()
+
+		userAvailabilityHandler.ImportUserAvailability(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("multipart upload without a file, should return bad request", func(t *testing.T) {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		form.WriteField("name", "calendar")
+		form.Close()
+
+		req := httptest.NewRequest(http.MethodPost, "/events/2/availability/1/import", &body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		req = mux.SetURLVars(req, vars)
+		w := httptest.NewRecorder()
+
+		userAvailabilityHandler.ImportUserAvailability(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestListUserEventAvailability(t *testing.T) {
+	mockUserAvailService := new(mockService.MockUserAvailabilityService)
+	userAvailabilityHandler := NewUserAvailabilityHandler(mockUserAvailService)
+
+	t.Run("invalid status, should return bad request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users/7/availability?status=open", nil)
+		req = mux.SetURLVars(req, map[string]string{"user_id": "7"})
+		w := httptest.NewRecorder()
+
+		userAvailabilityHandler.ListUserEventAvailability(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Invalid status")
+	})
+
+	t.Run("invalid cursor, should return bad request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users/7/availability?cursor=!", nil)
+		req = mux.SetURLVars(req, map[string]string{"user_id": "7"})
+		w := httptest.NewRecorder()
+
+		userAvailabilityHandler.ListUserEventAvailability(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("another user, should return forbidden", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users/7/availability", nil)
+		req = mux.SetURLVars(req, map[string]string{"user_id": "7"})
+		w := httptest.NewRecorder()
+		mockUserAvailService.On("ListUserEventAvailability", req.Context(), int64(7), model.UserEventFilter{}).
+			Return(model.UserEventAvailabilityList{}, &model.ForbiddenError{Message: "only user 7 can list their availability"}).Once()
+
+		userAvailabilityHandler.ListUserEventAvailability(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("valid request, should return the events in the requested zone", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users/7/availability?status=polling&limit=10&cursor="+utils.EncodeCursor(5)+"&tz=Asia/Kolkata", nil)
+		req = mux.SetURLVars(req, map[string]string{"user_id": "7"})
+		w := httptest.NewRecorder()
+		start := time.Date(2025, 07, 14, 4, 30, 0, 0, time.UTC)
+		mockUserAvailService.On("ListUserEventAvailability", req.Context(), int64(7), model.UserEventFilter{Status: model.EventStatusPolling, AfterID: 5, Limit: 10}).
+			Return(model.UserEventAvailabilityList{Events: []model.UserEventAvailability{{
+				Event:          model.Event{ID: 6, Title: "Design sync", Status: model.EventStatusPolling},
+				Invited:        true,
+				ResponseStatus: model.ResponseStatusResponded,
+				Availability:   []model.EventSlot{{ID: 3, StartTime: start, EndTime: start.Add(time.Hour)}},
+				Rules:          []model.AvailabilityRule{},
+			}}}, nil).Once()
+
+		userAvailabilityHandler.ListUserEventAvailability(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"response_status":"responded"`)
+		assert.Contains(t, w.Body.String(), `"start_time":"2025-07-14T10:00:00+05:30"`)
+		mockUserAvailService.AssertExpectations(t)
+	})
+}
+
+func TestInsertUserAvailabilityBatch(t *testing.T) {
+	mockUserAvailService := new(mockService.MockUserAvailabilityService)
+	userAvailabilityHandler := NewUserAvailabilityHandler(mockUserAvailService)
+	validRequest := `{"items": [
+		{"user_id": 7, "availability": [{"start_time": "2025-07-13T10:00:00Z", "end_time": "2025-07-13T11:00:00Z"}]},
+		{"user_id": 8, "time_zone": "Europe/Berlin", "availability": [{"start_time": "2025-07-13T10:00:00Z", "end_time": "2025-07-13T11:00:00Z"}]}
+	]}`
+
+	t.Run("invalid event_id in URL, should return bad request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/events/abc/availability:batch", strings.NewReader(validRequest))
+		req = mux.SetURLVars(req, map[string]string{"event_id": "abc"})
+		w := httptest.NewRecorder()
+
+		userAvailabilityHandler.InsertUserAvailabilityBatch(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid JSON request, should return bad request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/events/1/availability:batch", strings.NewReader(`\invalid_json`))
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
+		w := httptest.NewRecorder()
+
+		userAvailabilityHandler.InsertUserAvailabilityBatch(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("event not found, should return not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/events/1/availability:batch", strings.NewReader(validRequest))
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
+		w := httptest.NewRecorder()
+		mockUserAvailService.On("InsertUserAvailabilityBatch", req.Context(), int64(1), mock.Anything).
+			Return(model.AvailabilityBatchResult{}, &model.NotFoundError{Resource: "event", ID: 1}).Once()
+
+		userAvailabilityHandler.InsertUserAvailabilityBatch(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockUserAvailService.AssertExpectations(t)
+	})
+
+	t.Run("every item stored, should return created with the report", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/events/1/availability:batch", strings.NewReader(validRequest))
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
+		w := httptest.NewRecorder()
+		mockUserAvailService.On("InsertUserAvailabilityBatch", req.Context(), int64(1), mock.MatchedBy(func(items []model.UserAvailability) bool {
+			return len(items) == 2 && items[0].UserID == 7 && items[1].TimeZone == "Europe/Berlin"
+		})).Return(model.AvailabilityBatchResult{Stored: 2, Items: []model.AvailabilityBatchItem{
+			{Index: 0, UserID: 7, Status: model.BatchItemStored, Slots: 1},
+			{Index: 1, UserID: 8, Status: model.BatchItemStored, Slots: 1},
+		}}, nil).Once()
+
+		userAvailabilityHandler.InsertUserAvailabilityBatch(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockUserAvailService.AssertExpectations(t)
+		assert.Contains(t, w.Body.String(), `"stored":2,"failed":0`)
+		assert.Contains(t, w.Body.String(), `{"index":1,"user_id":8,"status":"stored","slots":1,"rules":0}`)
+	})
+
+	t.Run("failed item, should return the status of the first failure and report every item", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/events/1/availability:batch", strings.NewReader(validRequest))
+		req = mux.SetURLVars(req, map[string]string{"event_id": "1"})
+		w := httptest.NewRecorder()
+		validationErr := &utils.ValidationError{}
+		validationErr.Add("user_id", 8, "user_id 8 is already in items[0]")
+		mockUserAvailService.On("InsertUserAvailabilityBatch", req.Context(), int64(1), mock.Anything).
+			Return(model.AvailabilityBatchResult{Failed: 2, Items: []model.AvailabilityBatchItem{
+				{Index: 0, UserID: 7, Status: model.BatchItemNotStored, Slots: 1},
+				{Index: 1, UserID: 8, Status: model.BatchItemFailed, Slots: 1, Err: validationErr},
+				{Index: 2, UserID: 9, Status: model.BatchItemFailed, Slots: 1, Err: &model.ForbiddenError{Message: "forbidden"}},
+			}}, nil).Once()
+
+		userAvailabilityHandler.InsertUserAvailabilityBatch(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockUserAvailService.AssertExpectations(t)
+		assert.Contains(t, w.Body.String(), `"code":"validation_failed","message":"items[1]: Validation failed"`)
+		assert.Contains(t, w.Body.String(), `{"index":0,"user_id":7,"status":"not_stored","slots":1,"rules":0}`)
+		assert.Contains(t, w.Body.String(), "user_id 8 is already in items[0]")
+		assert.Contains(t, w.Body.String(), `"error":{"code":"forbidden"`)
+	})
+}
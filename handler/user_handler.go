@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/rahulshewale153/meeting-scheduler-api/service"
+	"github.com/rahulshewale153/meeting-scheduler-api/utils"
+)
+
+type UserHandler struct {
+	userService service.UserServiceI
+}
+
+func NewUserHandler(userService service.UserServiceI) *UserHandler {
+	return &UserHandler{
+		userService: userService,
+	}
+}
+
+// GetUserProfile returns the scheduling profile of a user
+func (h *UserHandler) GetUserProfile(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.ParseInt(mux.Vars(r)["user_id"], 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid user_id")
+		return
+	}
+
+	profile, err := h.userService.GetUserProfile(r.Context(), userID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, profile)
+}
+
+// UpdateUserProfile stores the scheduling profile of a user
+func (h *UserHandler) UpdateUserProfile(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.ParseInt(mux.Vars(r)["user_id"], 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid user_id")
+		return
+	}
+
+	var profile model.UserProfile
+	if err := json.NewDecoder(r.Body).Decode(&profile); err != nil {
+		writeError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid request payload")
+		return
+	}
+	profile.UserID = userID
+	if errs, ok := utils.IsValid(profile); !ok {
+		writeValidationError(w, r, errs)
+		return
+	}
+
+	if err := h.userService.UpdateUserProfile(r.Context(), profile); err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, profile)
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	mockService "github.com/rahulshewale153/meeting-scheduler-api/mock/service"
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUserProfile(t *testing.T) {
+	mockUserService := new(mockService.MockUserService)
+	userHandler := NewUserHandler(mockUserService)
+
+	t.Run("invalid user_id, should return bad request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users/abc/profile", nil)
+		req = mux.SetURLVars(req, map[string]string{"user_id": "abc"})
+		w := httptest.NewRecorder()
+
+		userHandler.GetUserProfile(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("service error, should return internal server error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users/1/profile", nil)
+		req = mux.SetURLVars(req, map[string]string{"user_id": "1"})
+		w := httptest.NewRecorder()
+		mockUserService.On("GetUserProfile", req.Context(), int64(1)).Return(model.UserProfile{}, assert.AnError).Once()
+
+		userHandler.GetUserProfile(w, req)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("valid request, should return the profile", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users/1/profile", nil)
+		req = mux.SetURLVars(req, map[string]string{"user_id": "1"})
+		w := httptest.NewRecorder()
+		mockUserService.On("GetUserProfile", req.Context(), int64(1)).Return(model.UserProfile{UserID: 1, TimeZone: "Asia/Kolkata"}, nil).Once()
+
+		userHandler.GetUserProfile(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"user_id": 1, "time_zone": "Asia/Kolkata"}`, w.Body.String())
+	})
+}
+
+func TestUpdateUserProfile(t *testing.T) {
+	mockUserService := new(mockService.MockUserService)
+	userHandler := NewUserHandler(mockUserService)
+
+	t.Run("invalid JSON request, should return bad request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/users/1/profile", strings.NewReader(`{`))
+		req = mux.SetURLVars(req, map[string]string{"user_id": "1"})
+		w := httptest.NewRecorder()
+
+		userHandler.UpdateUserProfile(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("missing time_zone, should return a validation error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/users/1/profile", strings.NewReader(`{}`))
+		req = mux.SetURLVars(req, map[string]string{"user_id": "1"})
+		w := httptest.NewRecorder()
+
+		userHandler.UpdateUserProfile(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "time_zone")
+	})
+
+	t.Run("valid request, should store the profile of the user in the path", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/users/1/profile", strings.NewReader(`{"user_id": 9, "time_zone": "Asia/Kolkata", "working_hours": [{"weekday": 1, "start": "09:00", "end": "17:30"}]}`))
+		req = mux.SetURLVars(req, map[string]string{"user_id": "1"})
+		w := httptest.NewRecorder()
+		profile := model.UserProfile{
+			UserID:       1,
+			TimeZone:     "Asia/Kolkata",
+			WorkingHours: []model.WorkingHours{{Weekday: time.Monday, Start: "09:00", End: "17:30"}},
+		}
+		mockUserService.On("UpdateUserProfile", req.Context(), profile).Return(nil).Once()
+
+		userHandler.UpdateUserProfile(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockUserService.AssertExpectations(t)
+	})
+}
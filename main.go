@@ -5,6 +5,8 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	// embed the IANA zone database so time zones resolve on images without zoneinfo
+	_ "time/tzdata"
 
 	"github.com/rahulshewale153/meeting-scheduler-api/configreader"
 	"github.com/rahulshewale153/meeting-scheduler-api/server"
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/configreader"
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+// errNoCredentials is returned by an Authenticator when the request carries no credentials it understands
+var errNoCredentials = errors.New("no credentials")
+
+// Authenticator identifies the caller of a request. It returns errNoCredentials when the request does not carry
+// its kind of credentials, so that the next one is tried, and any other error when they are invalid
+type Authenticator interface {
+	Authenticate(r *http.Request) (model.Identity, error)
+}
+
+// Authentication requires every request outside PublicPaths to be identified by one of the Authenticators
+type Authentication struct {
+	Authenticators []Authenticator
+	PublicPaths    []string
+	// Unauthorized writes the response for a request that could not be identified
+	Unauthorized func(w http.ResponseWriter, r *http.Request, message string)
+}
+
+// NewAuthentication creates the authenticators described by config, unauthorized answers the rejected requests
+func NewAuthentication(config configreader.AuthConfig, unauthorized func(w http.ResponseWriter, r *http.Request, message string), publicPaths ...string) (*Authentication, error) {
+	authenticators := []Authenticator{}
+	if config.JWTSecret != "" || config.JWTPublicKey != "" {
+		jwtAuthenticator, err := NewJWTAuthenticator(config.JWTSecret, config.JWTPublicKey, config.JWTIssuer, config.JWTAudience)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, jwtAuthenticator)
+	}
+	if len(config.APIKeys) > 0 {
+		authenticators = append(authenticators, NewAPIKeyAuthenticator(config.APIKeys))
+	}
+	if len(authenticators) == 0 {
+		return nil, fmt.Errorf("authentication is enabled without a JWT key or API keys")
+	}
+	return &Authentication{Authenticators: authenticators, PublicPaths: publicPaths, Unauthorized: unauthorized}, nil
+}
+
+// Middleware stores the identity of the caller in the request context, see model.IdentityFromContext
+func (a *Authentication) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if slices.Contains(a.PublicPaths, r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		for _, authenticator := range a.Authenticators {
+			identity, err := authenticator.Authenticate(r)
+			if errors.Is(err, errNoCredentials) {
+				continue
+			}
+			if err != nil {
+				a.Unauthorized(w, r, err.Error())
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(model.WithIdentity(r.Context(), identity)))
+			return
+		}
+		a.Unauthorized(w, r, "authentication required")
+	})
+}
+
+type apiKeyAuthenticator struct {
+	// identities is keyed by the SHA-256 of the API key, so that lookups do not leak the keys through timing
+	identities map[[sha256.Size]byte]model.Identity
+}
+
+// NewAPIKeyAuthenticator authenticates the service accounts of apiKeys by the X-API-Key header
+func NewAPIKeyAuthenticator(apiKeys []configreader.APIKeyConfig) Authenticator {
+	identities := make(map[[sha256.Size]byte]model.Identity, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		identities[sha256.Sum256([]byte(apiKey.Key))] = model.Identity{Subject: apiKey.Name, Roles: apiKey.Roles, ServiceAccount: true}
+	}
+	return &apiKeyAuthenticator{identities: identities}
+}
+
+func (a *apiKeyAuthenticator) Authenticate(r *http.Request) (model.Identity, error) {
+	key := r.Header.Get(APIKeyHeader)
+	if key == "" {
+		return model.Identity{}, errNoCredentials
+	}
+	identity, ok := a.identities[sha256.Sum256([]byte(key))]
+	if !ok {
+		return model.Identity{}, errors.New("invalid API key")
+	}
+	return identity, nil
+}
@@ -0,0 +1,190 @@
+package middleware
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+)
+
+// jwtLeeway is the clock skew allowed when checking the exp and nbf claims
+const jwtLeeway = time.Minute
+
+// jwtHashes maps the supported JWS algorithms to their hash, HS* are checked with the secret and RS* with the RSA key
+var jwtHashes = map[string]crypto.Hash{
+	"HS256": crypto.SHA256,
+	"HS384": crypto.SHA384,
+	"HS512": crypto.SHA512,
+	"RS256": crypto.SHA256,
+	"RS384": crypto.SHA384,
+	"RS512": crypto.SHA512,
+}
+
+type jwtClaims struct {
+	Subject   string          `json:"sub"`
+	Issuer    string          `json:"iss"`
+	Audience  json.RawMessage `json:"aud"`
+	ExpiresAt *float64        `json:"exp"`
+	NotBefore *float64        `json:"nbf"`
+	Roles     []string        `json:"roles"`
+}
+
+type jwtAuthenticator struct {
+	secret    []byte
+	publicKey *rsa.PublicKey
+	issuer    string
+	audience  string
+	now       func() time.Time
+}
+
+// NewJWTAuthenticator authenticates users by a bearer JWT whose sub claim is their user id. secret verifies HMAC
+// signatures and publicKeyPEM, a PKIX or PKCS #1 RSA public key, verifies RSA signatures, either may be empty.
+// issuer and audience are only checked when set
+func NewJWTAuthenticator(secret string, publicKeyPEM string, issuer string, audience string) (Authenticator, error) {
+	authenticator := &jwtAuthenticator{secret: []byte(secret), issuer: issuer, audience: audience, now: time.Now}
+	if publicKeyPEM == "" {
+		return authenticator, nil
+	}
+
+	block, _ := pem.Decode([]byte(publicKeyPEM))
+	if block == nil {
+		return nil, errors.New("JWT public key is not PEM encoded")
+	}
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		authenticator.publicKey = key
+		return authenticator, nil
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JWT public key: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("JWT public key is not an RSA key")
+	}
+	authenticator.publicKey = rsaKey
+	return authenticator, nil
+}
+
+func (a *jwtAuthenticator) Authenticate(r *http.Request) (model.Identity, error) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return model.Identity{}, errNoCredentials
+	}
+
+	claims, err := a.verify(strings.TrimSpace(token))
+	if err != nil {
+		return model.Identity{}, err
+	}
+	userID, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil || userID <= 0 {
+		return model.Identity{}, errors.New("token subject is not a user id")
+	}
+	return model.Identity{UserID: userID, Subject: claims.Subject, Roles: claims.Roles}, nil
+}
+
+// verify checks the signature and the registered claims of a compact JWS and returns its claims
+func (a *jwtAuthenticator) verify(token string) (jwtClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return jwtClaims{}, errors.New("malformed token")
+	}
+	var header struct {
+		Algorithm string `json:"alg"`
+	}
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return jwtClaims{}, errors.New("malformed token header")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return jwtClaims{}, errors.New("malformed token signature")
+	}
+	if err := a.verifySignature(header.Algorithm, parts[0]+"."+parts[1], signature); err != nil {
+		return jwtClaims{}, err
+	}
+
+	var claims jwtClaims
+	if err := decodeJWTSegment(parts[1], &claims); err != nil {
+		return jwtClaims{}, errors.New("malformed token claims")
+	}
+	now := a.now()
+	if claims.ExpiresAt == nil {
+		return jwtClaims{}, errors.New("token has no expiry")
+	}
+	if now.After(unixTime(*claims.ExpiresAt).Add(jwtLeeway)) {
+		return jwtClaims{}, errors.New("token has expired")
+	}
+	if claims.NotBefore != nil && now.Add(jwtLeeway).Before(unixTime(*claims.NotBefore)) {
+		return jwtClaims{}, errors.New("token is not valid yet")
+	}
+	if a.issuer != "" && claims.Issuer != a.issuer {
+		return jwtClaims{}, errors.New("token has an unexpected issuer")
+	}
+	if a.audience != "" && !audienceContains(claims.Audience, a.audience) {
+		return jwtClaims{}, errors.New("token has an unexpected audience")
+	}
+	return claims, nil
+}
+
+func (a *jwtAuthenticator) verifySignature(algorithm string, signingInput string, signature []byte) error {
+	hash, ok := jwtHashes[algorithm]
+	if !ok {
+		return fmt.Errorf("unsupported token algorithm %q", algorithm)
+	}
+	switch {
+	case strings.HasPrefix(algorithm, "HS") && len(a.secret) > 0:
+		mac := hmac.New(hash.New, a.secret)
+		mac.Write([]byte(signingInput))
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return errors.New("invalid token signature")
+		}
+		return nil
+	case strings.HasPrefix(algorithm, "RS") && a.publicKey != nil:
+		digest := hash.New()
+		digest.Write([]byte(signingInput))
+		if err := rsa.VerifyPKCS1v15(a.publicKey, hash, digest.Sum(nil), signature); err != nil {
+			return errors.New("invalid token signature")
+		}
+		return nil
+	}
+	return fmt.Errorf("token algorithm %q is not accepted", algorithm)
+}
+
+func decodeJWTSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// audienceContains reports whether the aud claim, a string or an array of strings, holds audience
+func audienceContains(claim json.RawMessage, audience string) bool {
+	var single string
+	if err := json.Unmarshal(claim, &single); err == nil {
+		return single == audience
+	}
+	var many []string
+	if err := json.Unmarshal(claim, &many); err == nil {
+		return slices.Contains(many, audience)
+	}
+	return false
+}
+
+func unixTime(seconds float64) time.Time {
+	return time.Unix(0, int64(seconds*float64(time.Second)))
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID(t *testing.T) {
+	var seen string
+	handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = RequestIDFromContext(r.Context())
+	}))
+
+	t.Run("request without an id, should generate one", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events", nil)
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+		assert.Len(t, seen, 32)
+		assert.Equal(t, seen, w.Header().Get(RequestIDHeader))
+	})
+
+	t.Run("request with an id, should reuse it", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events", nil)
+		req.Header.Set(RequestIDHeader, "abc-123")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+		assert.Equal(t, "abc-123", seen)
+		assert.Equal(t, "abc-123", w.Header().Get(RequestIDHeader))
+	})
+
+	t.Run("request with an id that could inject log lines, should replace it", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events", nil)
+		req.Header.Set(RequestIDHeader, "abc\nfake log line")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+		assert.Len(t, seen, 32)
+		assert.Equal(t, seen, w.Header().Get(RequestIDHeader))
+	})
+
+	t.Run("request with an id longer than 64 characters, should replace it", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events", nil)
+		req.Header.Set(RequestIDHeader, strings.Repeat("a", 65))
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+		assert.Len(t, seen, 32)
+	})
+}
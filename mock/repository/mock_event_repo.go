@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/rahulshewale153/meeting-scheduler-api/model"
 	"github.com/stretchr/testify/mock"
@@ -37,6 +38,11 @@ func (m *MockEventRepository) DeleteEventSlots(ctx context.Context, tx *sql.Tx,
 	return args.Error(0)
 }
 
+func (m *MockEventRepository) DeleteAllEventSlots(ctx context.Context, tx *sql.Tx, eventID int64) error {
+	args := m.Called(ctx, tx, eventID)
+	return args.Error(0)
+}
+
 func (m *MockEventRepository) GetEventSlots(ctx context.Context, eventID int64) ([]model.EventSlot, error) {
 	args := m.Called(ctx, eventID)
 	return args.Get(0).([]model.EventSlot), args.Error(1)
@@ -46,3 +52,83 @@ func (m *MockEventRepository) GetEvent(ctx context.Context, eventID int64) (mode
 	args := m.Called(ctx, eventID)
 	return args.Get(0).(model.Event), args.Error(1)
 }
+
+func (m *MockEventRepository) ListEvents(ctx context.Context, filter model.EventFilter) ([]model.Event, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]model.Event), args.Error(1)
+}
+
+func (m *MockEventRepository) ListUserEvents(ctx context.Context, userID int64, statuses []string) ([]model.Event, error) {
+	args := m.Called(ctx, userID, statuses)
+	return args.Get(0).([]model.Event), args.Error(1)
+}
+
+func (m *MockEventRepository) ListConfirmedUserEvents(ctx context.Context, userIDs []int64, from time.Time, to time.Time) (map[int64][]model.Event, error) {
+	args := m.Called(ctx, userIDs, from, to)
+	return args.Get(0).(map[int64][]model.Event), args.Error(1)
+}
+
+func (m *MockEventRepository) LockUsers(ctx context.Context, tx *sql.Tx, userIDs []int64) error {
+	args := m.Called(ctx, tx, userIDs)
+	return args.Error(0)
+}
+
+func (m *MockEventRepository) LockUserEvents(ctx context.Context, tx *sql.Tx, userIDs []int64, from time.Time, to time.Time) (map[int64][]model.Event, error) {
+	args := m.Called(ctx, tx, userIDs, from, to)
+	return args.Get(0).(map[int64][]model.Event), args.Error(1)
+}
+
+func (m *MockEventRepository) InsertEventAttendee(ctx context.Context, tx *sql.Tx, eventID int64, attendee model.Attendee) error {
+	args := m.Called(ctx, tx, eventID, attendee)
+	return args.Error(0)
+}
+
+func (m *MockEventRepository) DeleteEventAttendees(ctx context.Context, tx *sql.Tx, eventID int64) error {
+	args := m.Called(ctx, tx, eventID)
+	return args.Error(0)
+}
+
+func (m *MockEventRepository) GetEventAttendees(ctx context.Context, eventID int64) ([]model.Attendee, error) {
+	args := m.Called(ctx, eventID)
+	return args.Get(0).([]model.Attendee), args.Error(1)
+}
+
+func (m *MockEventRepository) DeleteEventAttendee(ctx context.Context, tx *sql.Tx, eventID int64, userID int64) error {
+	args := m.Called(ctx, tx, eventID, userID)
+	return args.Error(0)
+}
+
+func (m *MockEventRepository) UpsertEventException(ctx context.Context, tx *sql.Tx, eventID int64, exception model.EventException) error {
+	args := m.Called(ctx, tx, eventID, exception)
+	return args.Error(0)
+}
+
+func (m *MockEventRepository) DeleteEventException(ctx context.Context, tx *sql.Tx, eventID int64, occurrenceDate string) error {
+	args := m.Called(ctx, tx, eventID, occurrenceDate)
+	return args.Error(0)
+}
+
+func (m *MockEventRepository) GetEventExceptions(ctx context.Context, eventID int64) ([]model.EventException, error) {
+	args := m.Called(ctx, eventID)
+	return args.Get(0).([]model.EventException), args.Error(1)
+}
+
+func (m *MockEventRepository) LockEvent(ctx context.Context, tx *sql.Tx, eventID int64) (model.Event, error) {
+	args := m.Called(ctx, tx, eventID)
+	return args.Get(0).(model.Event), args.Error(1)
+}
+
+func (m *MockEventRepository) StartPolling(ctx context.Context, tx *sql.Tx, eventID int64) error {
+	args := m.Called(ctx, tx, eventID)
+	return args.Error(0)
+}
+
+func (m *MockEventRepository) CancelEvent(ctx context.Context, tx *sql.Tx, eventID int64) error {
+	args := m.Called(ctx, tx, eventID)
+	return args.Error(0)
+}
+
+func (m *MockEventRepository) ConfirmEvent(ctx context.Context, tx *sql.Tx, eventID int64, slot model.EventSlot) error {
+	args := m.Called(ctx, tx, eventID, slot)
+	return args.Error(0)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFreeBusyRepository struct {
+	mock.Mock
+}
+
+func (m *MockFreeBusyRepository) GetFreeBusy(ctx context.Context, userID int64, from time.Time, to time.Time) ([]model.EventSlot, error) {
+	args := m.Called(ctx, userID, from, to)
+	return args.Get(0).([]model.EventSlot), args.Error(1)
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockResourceRepository struct {
+	mock.Mock
+}
+
+func (m *MockResourceRepository) InsertResource(ctx context.Context, tx *sql.Tx, resource model.Resource) (int64, error) {
+	args := m.Called(ctx, tx, resource)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockResourceRepository) UpdateResource(ctx context.Context, tx *sql.Tx, resource model.Resource) error {
+	args := m.Called(ctx, tx, resource)
+	return args.Error(0)
+}
+
+func (m *MockResourceRepository) DeleteResource(ctx context.Context, tx *sql.Tx, resourceID int64) error {
+	args := m.Called(ctx, tx, resourceID)
+	return args.Error(0)
+}
+
+func (m *MockResourceRepository) GetResource(ctx context.Context, resourceID int64) (model.Resource, error) {
+	args := m.Called(ctx, resourceID)
+	return args.Get(0).(model.Resource), args.Error(1)
+}
+
+func (m *MockResourceRepository) ListResources(ctx context.Context, filter model.ResourceFilter) ([]model.Resource, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]model.Resource), args.Error(1)
+}
+
+func (m *MockResourceRepository) LockResources(ctx context.Context, tx *sql.Tx, resourceIDs []int64) error {
+	args := m.Called(ctx, tx, resourceIDs)
+	return args.Error(0)
+}
+
+func (m *MockResourceRepository) ReplaceResourceAvailability(ctx context.Context, tx *sql.Tx, resourceID int64, windows []model.EventSlot) error {
+	args := m.Called(ctx, tx, resourceID, windows)
+	return args.Error(0)
+}
+
+func (m *MockResourceRepository) GetResourceAvailability(ctx context.Context, resourceIDs []int64) (map[int64][]model.EventSlot, error) {
+	args := m.Called(ctx, resourceIDs)
+	return args.Get(0).(map[int64][]model.EventSlot), args.Error(1)
+}
+
+func (m *MockResourceRepository) InsertEventResource(ctx context.Context, tx *sql.Tx, eventID int64, requirement model.ResourceRequirement) (int64, error) {
+	args := m.Called(ctx, tx, eventID, requirement)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockResourceRepository) DeleteEventResource(ctx context.Context, tx *sql.Tx, eventID int64, requirementID int64) error {
+	args := m.Called(ctx, tx, eventID, requirementID)
+	return args.Error(0)
+}
+
+func (m *MockResourceRepository) GetEventResources(ctx context.Context, eventID int64) ([]model.ResourceRequirement, error) {
+	args := m.Called(ctx, eventID)
+	return args.Get(0).([]model.ResourceRequirement), args.Error(1)
+}
+
+func (m *MockResourceRepository) BookEventResource(ctx context.Context, tx *sql.Tx, requirementID int64, resourceID int64) error {
+	args := m.Called(ctx, tx, requirementID, resourceID)
+	return args.Error(0)
+}
+
+func (m *MockResourceRepository) ReleaseEventResources(ctx context.Context, tx *sql.Tx, eventID int64) error {
+	args := m.Called(ctx, tx, eventID)
+	return args.Error(0)
+}
+
+func (m *MockResourceRepository) ListBookedEvents(ctx context.Context, resourceIDs []int64, from time.Time, to time.Time) (map[int64][]model.Event, error) {
+	args := m.Called(ctx, resourceIDs, from, to)
+	return args.Get(0).(map[int64][]model.Event), args.Error(1)
+}
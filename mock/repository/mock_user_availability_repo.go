@@ -13,8 +13,8 @@ type MockUserAvailabilityRepository struct {
 	mock.Mock
 }
 
-func (m *MockUserAvailabilityRepository) InsertUserAvailability(ctx context.Context, tx *sql.Tx, userID int64, eventID int64, startTime time.Time, endTime time.Time) (int64, error) {
-	args := m.Called(ctx, tx, userID, eventID, startTime, endTime)
+func (m *MockUserAvailabilityRepository) InsertUserAvailability(ctx context.Context, tx *sql.Tx, userID int64, eventID int64, startTime time.Time, endTime time.Time, timeZone string) (int64, error) {
+	args := m.Called(ctx, tx, userID, eventID, startTime, endTime, timeZone)
 	return args.Get(0).(int64), args.Error(1)
 }
 
@@ -23,9 +23,14 @@ func (m *MockUserAvailabilityRepository) GetAllEventUsers(ctx context.Context, e
 	return args.Get(0).(map[int64][]model.EventSlot), args.Error(1)
 }
 
-func (m *MockUserAvailabilityRepository) DeleteUserAvailability(ctx context.Context, tx *sql.Tx, userID int64, availabilityID int64) error {
+func (m *MockUserAvailabilityRepository) DeleteUserAvailability(ctx context.Context, tx *sql.Tx, userID int64, eventID int64) error {
 
-	args := m.Called(ctx, tx, userID, availabilityID)
+	args := m.Called(ctx, tx, userID, eventID)
+	return args.Error(0)
+}
+
+func (m *MockUserAvailabilityRepository) DeleteUserAvailabilitySlot(ctx context.Context, tx *sql.Tx, userID int64, eventID int64, slotID int64) error {
+	args := m.Called(ctx, tx, userID, eventID, slotID)
 	return args.Error(0)
 }
 
@@ -37,6 +42,14 @@ func (m *MockUserAvailabilityRepository) GetUserAvailability(ctx context.Context
 	return args.Get(0).([]model.EventSlot), args.Error(1)
 }
 
+func (m *MockUserAvailabilityRepository) GetSubmittedAvailability(ctx context.Context, eventID int64, userID int64) ([]model.EventSlot, error) {
+	args := m.Called(ctx, eventID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.EventSlot), args.Error(1)
+}
+
 func (m *MockUserAvailabilityRepository) GetEventUsers(ctx context.Context, eventID int64) (map[int64][]model.EventSlot, error) {
 	args := m.Called(ctx, eventID)
 	if args.Get(0) == nil {
@@ -45,3 +58,38 @@ func (m *MockUserAvailabilityRepository) GetEventUsers(ctx context.Context, even
 
 	return args.Get(0).(map[int64][]model.EventSlot), args.Error(1)
 }
+
+func (m *MockUserAvailabilityRepository) InsertAvailabilityRule(ctx context.Context, tx *sql.Tx, userID int64, eventID int64, rule model.AvailabilityRule) (int64, error) {
+	args := m.Called(ctx, tx, userID, eventID, rule)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserAvailabilityRepository) DeleteAvailabilityRules(ctx context.Context, tx *sql.Tx, userID int64, eventID int64) error {
+	args := m.Called(ctx, tx, userID, eventID)
+	return args.Error(0)
+}
+
+func (m *MockUserAvailabilityRepository) GetAvailabilityRules(ctx context.Context, eventID int64, userID int64) ([]model.AvailabilityRule, error) {
+	args := m.Called(ctx, eventID, userID)
+	return args.Get(0).([]model.AvailabilityRule), args.Error(1)
+}
+
+func (m *MockUserAvailabilityRepository) GetAllEventRules(ctx context.Context, eventID int64) (map[int64][]model.AvailabilityRule, error) {
+	args := m.Called(ctx, eventID)
+	return args.Get(0).(map[int64][]model.AvailabilityRule), args.Error(1)
+}
+
+func (m *MockUserAvailabilityRepository) ReplaceSyncedAvailability(ctx context.Context, tx *sql.Tx, userID int64, eventID int64, slots []model.EventSlot) error {
+	args := m.Called(ctx, tx, userID, eventID, slots)
+	return args.Error(0)
+}
+
+func (m *MockUserAvailabilityRepository) ListUserEventAvailability(ctx context.Context, userID int64, filter model.UserEventFilter) ([]model.UserEventAvailability, error) {
+	args := m.Called(ctx, userID, filter)
+	return args.Get(0).([]model.UserEventAvailability), args.Error(1)
+}
+
+func (m *MockUserAvailabilityRepository) InsertUserAvailabilityBatch(ctx context.Context, tx *sql.Tx, eventID int64, items []model.UserAvailability) error {
+	args := m.Called(ctx, tx, eventID, items)
+	return args.Error(0)
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) UpsertUserProfile(ctx context.Context, tx *sql.Tx, profile model.UserProfile) error {
+	args := m.Called(ctx, tx, profile)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetUserProfile(ctx context.Context, userID int64) (model.UserProfile, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(model.UserProfile), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserProfiles(ctx context.Context, userIDs []int64) (map[int64]model.UserProfile, error) {
+	args := m.Called(ctx, userIDs)
+	return args.Get(0).(map[int64]model.UserProfile), args.Error(1)
+}
+
+func (m *MockUserRepository) ReplaceWorkingHours(ctx context.Context, tx *sql.Tx, userID int64, workingHours []model.WorkingHours) error {
+	args := m.Called(ctx, tx, userID, workingHours)
+	return args.Error(0)
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAvailabilitySyncService struct {
+	mock.Mock
+}
+
+func (m *MockAvailabilitySyncService) SyncOpenEvents(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
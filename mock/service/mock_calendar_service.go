@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCalendarService struct {
+	mock.Mock
+}
+
+func (m *MockCalendarService) ExportEvent(ctx context.Context, eventID int64) ([]byte, error) {
+	args := m.Called(ctx, eventID)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockCalendarService) ExportUserCalendar(ctx context.Context, userID int64) ([]byte, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]byte), args.Error(1)
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockConflictService struct {
+	mock.Mock
+}
+
+func (m *MockConflictService) GetUserConflicts(ctx context.Context, userID int64, from time.Time, to time.Time) ([]model.MeetingConflict, error) {
+	args := m.Called(ctx, userID, from, to)
+	return args.Get(0).([]model.MeetingConflict), args.Error(1)
+}
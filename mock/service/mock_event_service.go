@@ -25,3 +25,48 @@ func (m *MockEventService) DeleteEvent(ctx context.Context, eventID int64) error
 	args := m.Called(ctx, eventID)
 	return args.Error(0)
 }
+
+func (m *MockEventService) GetEvent(ctx context.Context, eventID int64) (model.EventDetail, error) {
+	args := m.Called(ctx, eventID)
+	return args.Get(0).(model.EventDetail), args.Error(1)
+}
+
+func (m *MockEventService) ListEvents(ctx context.Context, filter model.EventFilter) (model.EventList, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(model.EventList), args.Error(1)
+}
+
+func (m *MockEventService) AddAttendee(ctx context.Context, eventID int64, attendee model.Attendee) error {
+	args := m.Called(ctx, eventID, attendee)
+	return args.Error(0)
+}
+
+func (m *MockEventService) RemoveAttendee(ctx context.Context, eventID int64, userID int64) error {
+	args := m.Called(ctx, eventID, userID)
+	return args.Error(0)
+}
+
+func (m *MockEventService) ListAttendees(ctx context.Context, eventID int64) ([]model.Attendee, error) {
+	args := m.Called(ctx, eventID)
+	return args.Get(0).([]model.Attendee), args.Error(1)
+}
+
+func (m *MockEventService) SetEventException(ctx context.Context, eventID int64, exception model.EventException) error {
+	args := m.Called(ctx, eventID, exception)
+	return args.Error(0)
+}
+
+func (m *MockEventService) RemoveEventException(ctx context.Context, eventID int64, occurrenceDate string) error {
+	args := m.Called(ctx, eventID, occurrenceDate)
+	return args.Error(0)
+}
+
+func (m *MockEventService) ConfirmEvent(ctx context.Context, eventID int64, slot *model.EventSlot) (model.EventSlot, error) {
+	args := m.Called(ctx, eventID, slot)
+	return args.Get(0).(model.EventSlot), args.Error(1)
+}
+
+func (m *MockEventService) CancelEvent(ctx context.Context, eventID int64) error {
+	args := m.Called(ctx, eventID)
+	return args.Error(0)
+}
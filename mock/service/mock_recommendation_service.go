@@ -11,7 +11,7 @@ type MockRecommendationService struct {
 	mock.Mock
 }
 
-func (m *MockRecommendationService) GetRecommendedSlots(ctx context.Context, eventID int64) ([]model.SlotRecommendation, error) {
-	args := m.Called(ctx, eventID)
+func (m *MockRecommendationService) GetRecommendedSlots(ctx context.Context, eventID int64, options model.RecommendationOptions) ([]model.SlotRecommendation, error) {
+	args := m.Called(ctx, eventID, options)
 	return args.Get(0).([]model.SlotRecommendation), args.Error(1)
 }
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockResourceService struct {
+	mock.Mock
+}
+
+func (m *MockResourceService) InsertResource(ctx context.Context, resource model.Resource) (int64, error) {
+	args := m.Called(ctx, resource)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockResourceService) UpdateResource(ctx context.Context, resource model.Resource) error {
+	args := m.Called(ctx, resource)
+	return args.Error(0)
+}
+
+func (m *MockResourceService) DeleteResource(ctx context.Context, resourceID int64) error {
+	args := m.Called(ctx, resourceID)
+	return args.Error(0)
+}
+
+func (m *MockResourceService) GetResource(ctx context.Context, resourceID int64) (model.Resource, error) {
+	args := m.Called(ctx, resourceID)
+	return args.Get(0).(model.Resource), args.Error(1)
+}
+
+func (m *MockResourceService) ListResources(ctx context.Context, filter model.ResourceFilter) ([]model.Resource, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]model.Resource), args.Error(1)
+}
+
+func (m *MockResourceService) SetResourceAvailability(ctx context.Context, resourceID int64, windows []model.EventSlot) error {
+	args := m.Called(ctx, resourceID, windows)
+	return args.Error(0)
+}
+
+func (m *MockResourceService) GetResourceAvailability(ctx context.Context, resourceID int64, from time.Time, to time.Time) (model.ResourceAvailability, error) {
+	args := m.Called(ctx, resourceID, from, to)
+	return args.Get(0).(model.ResourceAvailability), args.Error(1)
+}
+
+func (m *MockResourceService) AddEventResource(ctx context.Context, eventID int64, requirement model.ResourceRequirement) (int64, error) {
+	args := m.Called(ctx, eventID, requirement)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockResourceService) RemoveEventResource(ctx context.Context, eventID int64, requirementID int64) error {
+	args := m.Called(ctx, eventID, requirementID)
+	return args.Error(0)
+}
+
+func (m *MockResourceService) ListEventResources(ctx context.Context, eventID int64) ([]model.ResourceRequirement, error) {
+	args := m.Called(ctx, eventID)
+	return args.Get(0).([]model.ResourceRequirement), args.Error(1)
+}
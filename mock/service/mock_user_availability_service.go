@@ -41,3 +41,18 @@ func (m *MockUserAvailabilityService) GetEventUsers(ctx context.Context, eventID
 	}
 	return args.Get(0).(map[int64][]model.EventSlot), args.Error(1)
 }
+
+func (m *MockUserAvailabilityService) ImportUserAvailability(ctx context.Context, eventID int64, userID int64, calendar []byte) (model.AvailabilityImport, error) {
+	args := m.Called(ctx, eventID, userID, calendar)
+	return args.Get(0).(model.AvailabilityImport), args.Error(1)
+}
+
+func (m *MockUserAvailabilityService) ListUserEventAvailability(ctx context.Context, userID int64, filter model.UserEventFilter) (model.UserEventAvailabilityList, error) {
+	args := m.Called(ctx, userID, filter)
+	return args.Get(0).(model.UserEventAvailabilityList), args.Error(1)
+}
+
+func (m *MockUserAvailabilityService) InsertUserAvailabilityBatch(ctx context.Context, eventID int64, items []model.UserAvailability) (model.AvailabilityBatchResult, error) {
+	args := m.Called(ctx, eventID, items)
+	return args.Get(0).(model.AvailabilityBatchResult), args.Error(1)
+}
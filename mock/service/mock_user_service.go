@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserService struct {
+	mock.Mock
+}
+
+func (m *MockUserService) GetUserProfile(ctx context.Context, userID int64) (model.UserProfile, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(model.UserProfile), args.Error(1)
+}
+
+func (m *MockUserService) UpdateUserProfile(ctx context.Context, profile model.UserProfile) error {
+	args := m.Called(ctx, profile)
+	return args.Error(0)
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound is matched by every NotFoundError, so callers can use errors.Is without knowing the resource
+var ErrNotFound = errors.New("not found")
+
+// NotFoundError reports that the requested resource does not exist
+type NotFoundError struct {
+	Resource string
+	ID       int64
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s %d not found", e.Resource, e.ID)
+}
+
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// ErrConflict is matched by every ConflictError
+var ErrConflict = errors.New("conflict")
+
+// ConflictError reports that the request clashes with the current state of a resource
+type ConflictError struct {
+	Message string
+	// Conflicts are the meetings that clash with the request, if any
+	Conflicts []MeetingConflict
+}
+
+func (e *ConflictError) Error() string {
+	return e.Message
+}
+
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// ErrForbidden is matched by every ForbiddenError
+var ErrForbidden = errors.New("forbidden")
+
+// ForbiddenError reports that the caller is not allowed to perform the request
+type ForbiddenError struct {
+	Message string
+}
+
+func (e *ForbiddenError) Error() string {
+	return e.Message
+}
+
+func (e *ForbiddenError) Is(target error) bool {
+	return target == ErrForbidden
+}
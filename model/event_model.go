@@ -5,33 +5,235 @@ import "time"
 type EventRequest struct {
 	Event
 	ProposedSlots []EventSlot `json:"proposed_slots" validate:"required,dive,required"`
+	Attendees     []Attendee  `json:"attendees,omitempty" validate:"omitempty,dive"`
 }
 
 type Event struct {
-	ID              int64     `json:"id"`
-	Title           string    `json:"title" validate:"required"`
-	OrganizerID     int64     `json:"organizer_id" validate:"required"`
-	DurationMinutes int       `json:"duration_minutes" validate:"required"`
-	CreatedAt       time.Time `json:"created_at,omitempty"`
-	UpdatedAt       time.Time `json:"updated_at,omitempty"`
+	ID              int64  `json:"id"`
+	Title           string `json:"title" validate:"required"`
+	OrganizerID     int64  `json:"organizer_id" validate:"required"`
+	DurationMinutes int    `json:"duration_minutes" validate:"required"`
+	TimeZone        string `json:"time_zone,omitempty"`
+	RRule           string `json:"rrule,omitempty"`
+	// Status and ConfirmedSlot are managed by the confirm and cancel endpoints, they are ignored in requests
+	Status        string     `json:"status,omitempty"`
+	ConfirmedSlot *EventSlot `json:"confirmed_slot,omitempty"`
+	CreatedAt     time.Time  `json:"created_at,omitempty"`
+	UpdatedAt     time.Time  `json:"updated_at,omitempty"`
 }
 
+// Event statuses, a new event is a draft, it polls once availability arrives until a slot is confirmed,
+// and can be cancelled at any point. Confirmed and cancelled events no longer accept edits or availability
+const (
+	EventStatusDraft     = "draft"
+	EventStatusPolling   = "polling"
+	EventStatusConfirmed = "confirmed"
+	EventStatusCancelled = "cancelled"
+)
+
+type EventDetail struct {
+	Event
+	ProposedSlots []EventSlot      `json:"proposed_slots"`
+	Attendees     []Attendee       `json:"attendees"`
+	Exceptions    []EventException `json:"exceptions,omitempty"`
+}
+
+// EventException overrides one occurrence of a recurring event, named by its date in the zone of the event.
+// The occurrence is either cancelled or moved to StartTime-EndTime, in both cases the series recommendation skips it
+type EventException struct {
+	OccurrenceDate string    `json:"occurrence_date"`
+	Cancelled      bool      `json:"cancelled"`
+	StartTime      time.Time `json:"start_time,omitempty"`
+	EndTime        time.Time `json:"end_time,omitempty"`
+}
+
+// Attendee marks a user as required or optional for an event, optional attendees count towards the score by their weight
+type Attendee struct {
+	UserID   int64   `json:"user_id" validate:"required"`
+	Required bool    `json:"required"`
+	Weight   float64 `json:"weight,omitempty" validate:"gte=0"`
+}
+
+type EventFilter struct {
+	OrganizerID int64
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	Title       string
+	Status      string
+	AfterID     int64
+	Limit       int
+}
+
+type EventList struct {
+	Events     []Event `json:"events"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
+// EventSlot is a span of time, TimeZone is the IANA zone it was submitted in and is rendered in
 type EventSlot struct {
 	ID        int64     `json:"id,omitempty"`
 	StartTime time.Time `json:"start_time" validate:"required"`
 	EndTime   time.Time `json:"end_time" validate:"required"`
+	TimeZone  string    `json:"time_zone,omitempty"`
 }
 
+// UserAvailability is what a user submitted for an event, concrete slots and recurring rules, at least one of them
 type UserAvailability struct {
-	UserID       int64       `json:"user_id" validate:"required"`
-	EventID      int64       `json:"event_id" validate:"required"`
-	Availability []EventSlot `json:"availability" validate:"required,dive,required"`
-	CreatedAt    time.Time   `json:"created_at,omitempty"`
-	UpdatedAt    time.Time   `json:"updated_at,omitempty"`
+	UserID       int64              `json:"user_id" validate:"required"`
+	EventID      int64              `json:"event_id" validate:"required"`
+	Availability []EventSlot        `json:"availability" validate:"required_without=Rules,dive,required"`
+	Rules        []AvailabilityRule `json:"rules,omitempty" validate:"omitempty,dive"`
+	TimeZone     string             `json:"time_zone,omitempty"`
+	CreatedAt    time.Time          `json:"created_at,omitempty"`
+	UpdatedAt    time.Time          `json:"updated_at,omitempty"`
+}
+
+// Responses of a user to an event, a user has responded once they submitted slots or rules
+const (
+	ResponseStatusPending   = "pending"
+	ResponseStatusResponded = "responded"
+)
+
+// UserEventAvailability is what a user offered for an event they are invited to or responded to. The confirmed
+// slot, if any, is the one of Event
+type UserEventAvailability struct {
+	Event          Event              `json:"event"`
+	Invited        bool               `json:"invited"`
+	Required       bool               `json:"required"`
+	ResponseStatus string             `json:"response_status"`
+	Availability   []EventSlot        `json:"availability"`
+	Rules          []AvailabilityRule `json:"rules"`
+}
+
+// UserEventFilter selects the events of a user, AfterID and Limit page through them by event id
+type UserEventFilter struct {
+	Status  string
+	AfterID int64
+	Limit   int
+}
+
+type UserEventAvailabilityList struct {
+	Events     []UserEventAvailability `json:"events"`
+	NextCursor string                  `json:"next_cursor,omitempty"`
+}
+
+// UserAvailabilityBatch is the availability of many users for one event, stored together or not at all
+type UserAvailabilityBatch struct {
+	Items []UserAvailability `json:"items"`
+}
+
+// Outcomes of an item of an availability batch
+const (
+	BatchItemStored = "stored"
+	BatchItemFailed = "failed"
+	// BatchItemNotStored marks a valid item that was left out because another item of the batch failed
+	BatchItemNotStored = "not_stored"
+)
+
+// AvailabilityBatchItem reports one item of an availability batch, Err is why a failed item was rejected
+type AvailabilityBatchItem struct {
+	Index  int    `json:"index"`
+	UserID int64  `json:"user_id"`
+	Status string `json:"status"`
+	Slots  int    `json:"slots"`
+	Rules  int    `json:"rules"`
+	Err    error  `json:"-"`
+}
+
+// AvailabilityBatchResult reports an availability batch item by item, in the order of the items
+type AvailabilityBatchResult struct {
+	Stored int                     `json:"stored"`
+	Failed int                     `json:"failed"`
+	Items  []AvailabilityBatchItem `json:"items"`
+}
+
+// AvailabilityImport reports an iCalendar import: the busy time read from the calendar within the proposed slots
+// of the event and the free parts of the proposed slots that were stored as availability
+type AvailabilityImport struct {
+	UserID   int64       `json:"user_id"`
+	EventID  int64       `json:"event_id"`
+	Busy     []EventSlot `json:"busy"`
+	Imported []EventSlot `json:"imported"`
+}
+
+// Sources of availability slots, synced slots are replaced on every sync while submitted ones are left alone
+const (
+	AvailabilitySourceManual = "manual"
+	AvailabilitySourceCalDAV = "caldav"
+)
+
+// AvailabilityRule is availability that repeats by an RFC 5545 RRULE such as FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR.
+// StartTime and EndTime bound the first occurrence, later ones keep its wall clock time in TimeZone
+type AvailabilityRule struct {
+	ID        int64     `json:"id,omitempty"`
+	StartTime time.Time `json:"start_time" validate:"required"`
+	EndTime   time.Time `json:"end_time" validate:"required"`
+	TimeZone  string    `json:"time_zone,omitempty"`
+	RRule     string    `json:"rrule" validate:"required"`
+}
+
+// Tie-break orders for recommendations that are equally feasible and score the same
+const (
+	TieBreakEarliest      = "earliest"
+	TieBreakLatest        = "latest"
+	TieBreakMostAvailable = "most_available"
+)
+
+// Working-hours modes for recommendations, attendees without working hours are never outside them
+const (
+	// WorkingHoursMark only reports attendees that would be outside their working hours
+	WorkingHoursMark = "mark"
+	// WorkingHoursExclude also counts those attendees as unavailable for the slot
+	WorkingHoursExclude = "exclude"
+)
+
+// RecommendationOptions tunes how recommended slots are filtered and ordered, zero values disable a filter
+type RecommendationOptions struct {
+	TieBreak        string
+	Limit           int
+	MinAvailable    int
+	RequireUsers    []int64
+	From            time.Time
+	To              time.Time
+	ExcludeWeekends bool
+	WorkingHours    string
+	// Occurrences is how many upcoming occurrences of a recurring event a slot is judged on
+	Occurrences int
 }
 
 type SlotRecommendation struct {
-	Slot        EventSlot
-	Available   []int64 `json:"available_users_id"`
-	Unavailable []int64 `json:"unavailable_users_id"`
+	Slot            EventSlot
+	Available       []int64 `json:"available_users_id"`
+	Unavailable     []int64 `json:"unavailable_users_id"`
+	NoResponse      []int64 `json:"no_response_users_id"`
+	MissingRequired []int64 `json:"missing_required_users_id"`
+	OutsideHours    []int64 `json:"outside_working_hours_users_id"`
+	// Occurrences lists who is free in each occurrence of a recurring event that the slot was judged on
+	Occurrences []OccurrenceAvailability `json:"occurrences,omitempty"`
+	// Resources is the resource that would be reserved for each resource requirement of the event, in their order
+	Resources []int64 `json:"resources_id,omitempty"`
+	Feasible  bool    `json:"feasible"`
+	Score     float64 `json:"score"`
+}
+
+// OccurrenceAvailability is one occurrence of a recurring event at a recommended slot and the users free in it
+type OccurrenceAvailability struct {
+	Slot      EventSlot `json:"slot"`
+	Available []int64   `json:"available_users_id"`
+}
+
+// Meeting is one occurrence of a confirmed event
+type Meeting struct {
+	EventID int64     `json:"event_id"`
+	Title   string    `json:"title"`
+	Slot    EventSlot `json:"slot"`
+}
+
+// MeetingConflict is a pair of confirmed meetings of one user that overlap, Overlap is the time they share
+type MeetingConflict struct {
+	// UserID is the user attending both meetings, left out when listing the conflicts of a single user
+	UserID  int64     `json:"user_id,omitempty"`
+	First   Meeting   `json:"first"`
+	Second  Meeting   `json:"second"`
+	Overlap EventSlot `json:"overlap"`
 }
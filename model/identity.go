@@ -0,0 +1,50 @@
+package model
+
+import "context"
+
+// roles of a caller, each one includes the permissions of the roles ranked below it
+const (
+	RoleAttendee  = "attendee"
+	RoleOrganizer = "organizer"
+	RoleAdmin     = "admin"
+)
+
+var roleRanks = map[string]int{RoleAttendee: 1, RoleOrganizer: 2, RoleAdmin: 3}
+
+// Identity is the authenticated caller of a request
+type Identity struct {
+	// UserID is the user the caller acts as, zero for a service account
+	UserID int64 `json:"user_id,omitempty"`
+	// Subject is the sub claim of a token or the name of an API key
+	Subject        string   `json:"subject"`
+	Roles          []string `json:"roles,omitempty"`
+	ServiceAccount bool     `json:"service_account"`
+}
+
+// HasRole reports whether the identity holds role or a role ranked above it. A user without roles is an attendee,
+// a service account without roles holds none
+func (i Identity) HasRole(role string) bool {
+	roles := i.Roles
+	if len(roles) == 0 && !i.ServiceAccount {
+		roles = []string{RoleAttendee}
+	}
+	for _, held := range roles {
+		if roleRanks[held] >= roleRanks[role] && roleRanks[role] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+type identityKey struct{}
+
+// WithIdentity returns a copy of ctx carrying the given identity
+func WithIdentity(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext returns the identity stored in ctx, ok is false for an anonymous request
+func IdentityFromContext(ctx context.Context) (identity Identity, ok bool) {
+	identity, ok = ctx.Value(identityKey{}).(Identity)
+	return identity, ok
+}
@@ -0,0 +1,50 @@
+package model
+
+import "time"
+
+// Resource kinds, only rooms hold people
+const (
+	ResourceKindRoom      = "room"
+	ResourceKindEquipment = "equipment"
+)
+
+// Resource is a room or a piece of equipment that events book. Features are free-form tags such as projector,
+// a resource can be booked at any time unless it has availability windows
+type Resource struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name" validate:"required"`
+	Kind      string    `json:"kind" validate:"required,oneof=room equipment"`
+	Capacity  int       `json:"capacity,omitempty" validate:"gte=0"`
+	Location  string    `json:"location,omitempty"`
+	Features  []string  `json:"features,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// ResourceFilter selects resources, zero values match every resource
+type ResourceFilter struct {
+	Kind        string
+	MinCapacity int
+	Features    []string
+	Location    string
+}
+
+// ResourceRequirement is a resource an event needs: the resource ResourceID, or any resource of Kind that holds
+// MinCapacity people and has every one of Features. BookedResourceID is the resource reserved on confirmation
+type ResourceRequirement struct {
+	ID               int64    `json:"id"`
+	ResourceID       int64    `json:"resource_id,omitempty"`
+	Kind             string   `json:"kind,omitempty" validate:"omitempty,oneof=room equipment"`
+	MinCapacity      int      `json:"min_capacity,omitempty" validate:"gte=0"`
+	Features         []string `json:"features,omitempty"`
+	BookedResourceID int64    `json:"booked_resource_id,omitempty"`
+}
+
+// ResourceAvailability is when a resource can be booked within a time range: its bookable windows, none meaning
+// at any time, the meetings of the confirmed events that booked it and the free time left
+type ResourceAvailability struct {
+	ResourceID int64       `json:"resource_id"`
+	Windows    []EventSlot `json:"windows"`
+	Booked     []Meeting   `json:"booked"`
+	Free       []EventSlot `json:"free"`
+}
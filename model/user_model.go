@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// UserProfile holds the per-user settings used when scheduling, TimeZone is an IANA zone name.
+// Users without working hours are treated as available around the clock
+type UserProfile struct {
+	UserID       int64          `json:"user_id"`
+	TimeZone     string         `json:"time_zone" validate:"required"`
+	WorkingHours []WorkingHours `json:"working_hours,omitempty"`
+}
+
+// WorkingHours is a span of one weekday on the wall clock of the user, Start and End are HH:MM and End may be 24:00
+type WorkingHours struct {
+	Weekday time.Weekday `json:"weekday"`
+	Start   string       `json:"start"`
+	End     string       `json:"end"`
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/configreader"
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/rahulshewale153/meeting-scheduler-api/utils"
+)
+
+const (
+	defaultCalDAVCalendarPath = "/calendars/{user_id}/calendar/"
+	defaultCalDAVTimeout      = 10 * time.Second
+	// maxFreeBusyBytes bounds the free/busy response read from the server
+	maxFreeBusyBytes = 4 << 20
+)
+
+// freeBusyQuery is the body of an RFC 4791 free-busy-query REPORT, filled with the UTC start and end of the range
+const freeBusyQuery = `<?xml version="1.0" encoding="utf-8" ?>
+<C:free-busy-query xmlns:C="urn:ietf:params:xml:ns:caldav">
+  <C:time-range start="%s" end="%s"/>
+</C:free-busy-query>`
+
+type calDAVRepository struct {
+	httpClient   *http.Client
+	baseURL      string
+	calendarPath string
+	username     string
+	password     string
+}
+
+// NewCalDAVRepository creates a free/busy repository that runs free-busy-query REPORTs against a CalDAV server
+func NewCalDAVRepository(config configreader.CalDAVConfig) FreeBusyRepositoryI {
+	timeout := time.Duration(config.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = defaultCalDAVTimeout
+	}
+	calendarPath := config.CalendarPath
+	if calendarPath == "" {
+		calendarPath = defaultCalDAVCalendarPath
+	}
+	return &calDAVRepository{
+		httpClient:   &http.Client{Timeout: timeout},
+		baseURL:      strings.TrimRight(config.BaseURL, "/"),
+		calendarPath: calendarPath,
+		username:     config.Username,
+		password:     config.Password,
+	}
+}
+
+// GetFreeBusy returns the busy time of the user between from and to, as reported by the VFREEBUSY of their calendar
+func (r *calDAVRepository) GetFreeBusy(ctx context.Context, userID int64, from time.Time, to time.Time) ([]model.EventSlot, error) {
+	path := strings.ReplaceAll(r.calendarPath, "{user_id}", strconv.FormatInt(userID, 10))
+	body := fmt.Sprintf(freeBusyQuery, from.UTC().Format("20060102T150405Z"), to.UTC().Format("20060102T150405Z"))
+	req, err := http.NewRequestWithContext(ctx, "REPORT", r.baseURL+path, bytes.NewBufferString(body))
+	if err != nil {
+		log.Println("Error creating free/busy request:", err)
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+	req.Header.Set("Depth", "1")
+	if r.username != "" {
+		req.SetBasicAuth(r.username, r.password)
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		log.Println("Error querying free/busy:", err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, &model.NotFoundError{Resource: "calendar", ID: userID}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("free/busy query for user %d failed with status %d", userID, resp.StatusCode)
+	}
+
+	calendar, err := io.ReadAll(io.LimitReader(resp.Body, maxFreeBusyBytes))
+	if err != nil {
+		log.Println("Error reading free/busy response:", err)
+		return nil, err
+	}
+	busy, err := utils.ParseICalendarBusy(calendar, time.UTC, from, to)
+	if err != nil {
+		log.Println("Error parsing free/busy response:", err)
+		return nil, fmt.Errorf("free/busy response for user %d is invalid: %w", userID, err)
+	}
+	return busy, nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/configreader"
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// calDAVStandIn is an in-process CalDAV server that answers free-busy-query REPORTs for the calendars it holds
+type calDAVStandIn struct {
+	username  string
+	password  string
+	calendars map[string]string
+	// queries records the time ranges asked for, by calendar path
+	queries map[string][2]string
+}
+
+func (s *calDAVStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if user, password, ok := r.BasicAuth(); !ok || user != s.username || password != s.password {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if r.Method != "REPORT" || r.Header.Get("Depth") != "1" {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	calendar, ok := s.calendars[r.URL.Path]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var query struct {
+		XMLName   xml.Name `xml:"urn:ietf:params:xml:ns:caldav free-busy-query"`
+		TimeRange struct {
+			Start string `xml:"start,attr"`
+			End   string `xml:"end,attr"`
+		} `xml:"urn:ietf:params:xml:ns:caldav time-range"`
+	}
+	body, _ := io.ReadAll(r.Body)
+	if err := xml.Unmarshal(body, &query); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.queries[r.URL.Path] = [2]string{query.TimeRange.Start, query.TimeRange.End}
+
+	w.Header().Set("Content-Type", "text/calendar")
+	w.Write([]byte(calendar))
+}
+
+func TestGetFreeBusy(t *testing.T) {
+	standIn := &calDAVStandIn{
+		username: "scheduler",
+		password: "secret",
+		calendars: map[string]string{
+			"/dav/5/calendar/": strings.Join([]string{
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"BEGIN:VFREEBUSY",
+				"DTSTART:20250714T000000Z",
+				"DTEND:20250715T000000Z",
+				"FREEBUSY:20250714T090000Z/20250714T100000Z,20250714T130000Z/PT30M",
+				"FREEBUSY;FBTYPE=FREE:20250714T100000Z/20250714T130000Z",
+				"END:VFREEBUSY",
+				"END:VCALENDAR",
+			}, "\r\n"),
+			"/dav/6/calendar/": "not a calendar",
+		},
+		queries: map[string][2]string{},
+	}
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	repository := NewCalDAVRepository(configreader.CalDAVConfig{
+		BaseURL:      server.URL + "/",
+		CalendarPath: "/dav/{user_id}/calendar/",
+		Username:     "scheduler",
+		Password:     "secret",
+	})
+	ctx := context.Background()
+	from := time.Date(2025, 07, 14, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 07, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Function must return the busy periods of the calendar of the user", func(t *testing.T) {
+		busy, err := repository.GetFreeBusy(ctx, 5, from, to)
+		assert.NoError(t, err)
+		assert.Equal(t, []model.EventSlot{
+			{StartTime: time.Date(2025, 07, 14, 9, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 14, 10, 0, 0, 0, time.UTC)},
+			{StartTime: time.Date(2025, 07, 14, 13, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 14, 13, 30, 0, 0, time.UTC)},
+		}, busy)
+		assert.Equal(t, [2]string{"20250714T000000Z", "20250715T000000Z"}, standIn.queries["/dav/5/calendar/"])
+	})
+
+	t.Run("Function must return a not found error for a user without a calendar", func(t *testing.T) {
+		_, err := repository.GetFreeBusy(ctx, 7, from, to)
+		assert.ErrorIs(t, err, model.ErrNotFound)
+	})
+
+	t.Run("Function must return an error for a response that is not a calendar", func(t *testing.T) {
+		_, err := repository.GetFreeBusy(ctx, 6, from, to)
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must return an error when the server rejects the credentials", func(t *testing.T) {
+		repository := NewCalDAVRepository(configreader.CalDAVConfig{BaseURL: server.URL, CalendarPath: "/dav/{user_id}/calendar/", Username: "scheduler", Password: "wrong"})
+
+		_, err := repository.GetFreeBusy(ctx, 5, from, to)
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, model.ErrNotFound)
+	})
+}
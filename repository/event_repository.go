@@ -3,11 +3,18 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"log"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/rahulshewale153/meeting-scheduler-api/model"
 )
 
+// eventColumns are the columns of event_detail read by scanEvent, in order
+const eventColumns = `id, title, organizer_id, duration_minutes, time_zone, rrule, status, confirmed_start_time, confirmed_end_time, created_at, updated_at`
+
 type eventRepository struct {
 	dbConn *sql.DB
 }
@@ -19,8 +26,8 @@ func NewEventRepository(dbConn *sql.DB) EventRepositoryI {
 // Insert the event
 func (eventRepo *eventRepository) InsertEvent(ctx context.Context, tx *sql.Tx, createEventReq model.Event) (int64, error) {
 	result, err := tx.ExecContext(ctx, `
-		INSERT INTO event_detail (title, organizer_id, duration_minutes) 
-		VALUES (?, ?, ?)`, createEventReq.Title, createEventReq.OrganizerID, createEventReq.DurationMinutes)
+		INSERT INTO event_detail (title, organizer_id, duration_minutes, time_zone, rrule) 
+		VALUES (?, ?, ?, ?, ?)`, createEventReq.Title, createEventReq.OrganizerID, createEventReq.DurationMinutes, createEventReq.TimeZone, createEventReq.RRule)
 	if err != nil {
 		log.Println("Error inserting event:", err)
 		return 0, err
@@ -37,7 +44,7 @@ func (eventRepo *eventRepository) InsertEvent(ctx context.Context, tx *sql.Tx, c
 
 // Update the event
 func (eventRepo *eventRepository) UpdateEvent(ctx context.Context, tx *sql.Tx, updateEventReq model.Event) error {
-	_, err := tx.ExecContext(ctx, `Update event_detail SET title = ?, organizer_id = ?, duration_minutes = ? WHERE id = ?`, updateEventReq.Title, updateEventReq.OrganizerID, updateEventReq.DurationMinutes, updateEventReq.ID)
+	_, err := tx.ExecContext(ctx, `Update event_detail SET title = ?, organizer_id = ?, duration_minutes = ?, time_zone = ?, rrule = ? WHERE id = ?`, updateEventReq.Title, updateEventReq.OrganizerID, updateEventReq.DurationMinutes, updateEventReq.TimeZone, updateEventReq.RRule, updateEventReq.ID)
 	if err != nil {
 		log.Println("Error updating event:", err)
 		return err
@@ -58,8 +65,8 @@ func (eventRepo *eventRepository) DeleteEvent(ctx context.Context, tx *sql.Tx, e
 // Insert the event slots
 func (eventRepo *eventRepository) InsertEventSlots(ctx context.Context, tx *sql.Tx, eventID int64, slot model.EventSlot) error {
 	_, err := tx.ExecContext(ctx, `
-			INSERT INTO event_slot (event_id, start_time, end_time) 
-			VALUES (?, ?, ?)`, eventID, slot.StartTime, slot.EndTime)
+			INSERT INTO event_slot (event_id, start_time, end_time, time_zone) 
+			VALUES (?, ?, ?, ?)`, eventID, slot.StartTime, slot.EndTime, slot.TimeZone)
 	if err != nil {
 		log.Println("Error inserting event slot:", err)
 		return err
@@ -78,9 +85,19 @@ func (eventRepo *eventRepository) DeleteEventSlots(ctx context.Context, tx *sql.
 	return nil
 }
 
+// Delete all the slots of the event
+func (eventRepo *eventRepository) DeleteAllEventSlots(ctx context.Context, tx *sql.Tx, eventID int64) error {
+	_, err := tx.ExecContext(ctx, `DELETE FROM event_slot WHERE event_id = ?`, eventID)
+	if err != nil {
+		log.Println("Error deleting event slots:", err)
+		return err
+	}
+	return nil
+}
+
 // Get the event slots
 func (eventRepo *eventRepository) GetEventSlots(ctx context.Context, eventID int64) ([]model.EventSlot, error) {
-	rows, err := eventRepo.dbConn.QueryContext(ctx, `SELECT id, start_time, end_time FROM event_slot WHERE event_id = ?`, eventID)
+	rows, err := eventRepo.dbConn.QueryContext(ctx, `SELECT id, start_time, end_time, time_zone FROM event_slot WHERE event_id = ?`, eventID)
 	if err != nil {
 		log.Println("Error getting event slots:", err)
 		return nil, err
@@ -90,7 +107,7 @@ func (eventRepo *eventRepository) GetEventSlots(ctx context.Context, eventID int
 	var slots []model.EventSlot
 	for rows.Next() {
 		var slot model.EventSlot
-		if err := rows.Scan(&slot.ID, &slot.StartTime, &slot.EndTime); err != nil {
+		if err := rows.Scan(&slot.ID, &slot.StartTime, &slot.EndTime, &slot.TimeZone); err != nil {
 			log.Println("Error scanning event slot:", err)
 			return nil, err
 		}
@@ -102,12 +119,12 @@ func (eventRepo *eventRepository) GetEventSlots(ctx context.Context, eventID int
 
 // Get Event by ID
 func (eventRepo *eventRepository) GetEvent(ctx context.Context, eventID int64) (model.Event, error) {
-	row := eventRepo.dbConn.QueryRowContext(ctx, `SELECT id, title, organizer_id, duration_minutes, created_at, updated_at FROM event_detail WHERE id = ?`, eventID)
+	row := eventRepo.dbConn.QueryRowContext(ctx, `SELECT `+eventColumns+` FROM event_detail WHERE id = ?`, eventID)
 
-	var event model.Event
-	if err := row.Scan(&event.ID, &event.Title, &event.OrganizerID, &event.DurationMinutes, &event.CreatedAt, &event.UpdatedAt); err != nil {
+	event, err := scanEvent(row)
+	if err != nil {
 		if err == sql.ErrNoRows {
-			return model.Event{}, nil // Event not found
+			return model.Event{}, &model.NotFoundError{Resource: "event", ID: eventID}
 		}
 		log.Println("Error getting event by ID:", err)
 		return model.Event{}, err
@@ -115,3 +132,413 @@ func (eventRepo *eventRepository) GetEvent(ctx context.Context, eventID int64) (
 
 	return event, nil
 }
+
+// scanEvent reads a row of eventColumns, the confirmed slot is set only once a slot has been confirmed
+func scanEvent(row interface{ Scan(dest ...any) error }) (model.Event, error) {
+	var event model.Event
+	var confirmedStart, confirmedEnd sql.NullTime
+	if err := row.Scan(&event.ID, &event.Title, &event.OrganizerID, &event.DurationMinutes, &event.TimeZone, &event.RRule, &event.Status, &confirmedStart, &confirmedEnd, &event.CreatedAt, &event.UpdatedAt); err != nil {
+		return model.Event{}, err
+	}
+	if confirmedStart.Valid && confirmedEnd.Valid {
+		event.ConfirmedSlot = &model.EventSlot{StartTime: confirmedStart.Time, EndTime: confirmedEnd.Time, TimeZone: event.TimeZone}
+	}
+	return event, nil
+}
+
+// Lock the row of the event until the end of the transaction and return it as it is stored, so that the status can
+// be checked again after it was read outside the transaction
+func (eventRepo *eventRepository) LockEvent(ctx context.Context, tx *sql.Tx, eventID int64) (model.Event, error) {
+	row := tx.QueryRowContext(ctx, `SELECT `+eventColumns+` FROM event_detail WHERE id = ? FOR UPDATE`, eventID)
+
+	event, err := scanEvent(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.Event{}, &model.NotFoundError{Resource: "event", ID: eventID}
+		}
+		log.Println("Error locking event:", err)
+		if isLockError(err) {
+			return model.Event{}, &model.ConflictError{Message: fmt.Sprintf("event %d is being changed, try again", eventID)}
+		}
+		return model.Event{}, err
+	}
+	return event, nil
+}
+
+// Move a draft event to polling, an event in any other status is left as it is
+func (eventRepo *eventRepository) StartPolling(ctx context.Context, tx *sql.Tx, eventID int64) error {
+	_, err := tx.ExecContext(ctx, `UPDATE event_detail SET status = ? WHERE id = ? AND status = ?`,
+		model.EventStatusPolling, eventID, model.EventStatusDraft)
+	if err != nil {
+		log.Println("Error starting event polling:", err)
+		return err
+	}
+	return nil
+}
+
+// Cancel the event, an event that is already cancelled is a conflict
+func (eventRepo *eventRepository) CancelEvent(ctx context.Context, tx *sql.Tx, eventID int64) error {
+	result, err := tx.ExecContext(ctx, `UPDATE event_detail SET status = ? WHERE id = ? AND status <> ?`,
+		model.EventStatusCancelled, eventID, model.EventStatusCancelled)
+	if err != nil {
+		log.Println("Error cancelling event:", err)
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Println("Error getting rows affected:", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		return &model.ConflictError{Message: fmt.Sprintf("event %d is already cancelled", eventID)}
+	}
+	return nil
+}
+
+// Store the confirmed slot of the event and mark it confirmed, an event that is no longer open is a conflict
+func (eventRepo *eventRepository) ConfirmEvent(ctx context.Context, tx *sql.Tx, eventID int64, slot model.EventSlot) error {
+	result, err := tx.ExecContext(ctx, `UPDATE event_detail SET status = ?, confirmed_start_time = ?, confirmed_end_time = ? WHERE id = ? AND status IN (?, ?)`,
+		model.EventStatusConfirmed, slot.StartTime, slot.EndTime, eventID, model.EventStatusDraft, model.EventStatusPolling)
+	if err != nil {
+		log.Println("Error confirming event:", err)
+		if isLockError(err) {
+			return &model.ConflictError{Message: fmt.Sprintf("event %d is being changed, try again", eventID)}
+		}
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Println("Error getting rows affected:", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		return &model.ConflictError{Message: fmt.Sprintf("event %d is no longer open and cannot be confirmed", eventID)}
+	}
+	return nil
+}
+
+// likeEscaper escapes the wildcards of a LIKE pattern so that user input only matches itself
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// List events matching the filter, ordered by id so that the last id can be used as the next cursor
+func (eventRepo *eventRepository) ListEvents(ctx context.Context, filter model.EventFilter) ([]model.Event, error) {
+	conditions := []string{"id > ?"}
+	args := []any{filter.AfterID}
+	if filter.OrganizerID != 0 {
+		conditions = append(conditions, "organizer_id = ?")
+		args = append(args, filter.OrganizerID)
+	}
+	if !filter.CreatedFrom.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, filter.CreatedFrom)
+	}
+	if !filter.CreatedTo.IsZero() {
+		conditions = append(conditions, "created_at <= ?")
+		args = append(args, filter.CreatedTo)
+	}
+	if filter.Title != "" {
+		conditions = append(conditions, `title LIKE ? ESCAPE '\\'`)
+		args = append(args, "%"+likeEscaper.Replace(filter.Title)+"%")
+	}
+
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, filter.Status)
+	}
+	args = append(args, filter.Limit)
+
+	query := `SELECT ` + eventColumns + ` FROM event_detail WHERE ` +
+		strings.Join(conditions, " AND ") + ` ORDER BY id ASC LIMIT ?`
+	rows, err := eventRepo.dbConn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Println("Error listing events:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []model.Event{}
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			log.Println("Error scanning event:", err)
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// List the events in one of the given statuses that the user organizes, attends or responded to, ordered by id
+func (eventRepo *eventRepository) ListUserEvents(ctx context.Context, userID int64, statuses []string) ([]model.Event, error) {
+	if len(statuses) == 0 {
+		return []model.Event{}, nil
+	}
+	placeholders, args := inClause(statuses)
+	args = append(args, userID, userID, userID, userID)
+	rows, err := eventRepo.dbConn.QueryContext(ctx, `SELECT `+eventColumns+` FROM event_detail WHERE status IN (`+placeholders+`) AND (
+		organizer_id = ?
+		OR id IN (SELECT event_id FROM event_attendee WHERE user_id = ?)
+		OR id IN (SELECT event_id FROM user_availability WHERE user_id = ?)
+		OR id IN (SELECT event_id FROM user_availability_rule WHERE user_id = ?)
+	) ORDER BY id ASC`, args...)
+	if err != nil {
+		log.Println("Error listing user events:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []model.Event{}
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			log.Println("Error scanning event:", err)
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// List the confirmed events each user organizes or attends that may overlap [from, to), keyed by user. A recurring
+// event is returned whenever its series starts before to, the caller expands its occurrences
+func (eventRepo *eventRepository) ListConfirmedUserEvents(ctx context.Context, userIDs []int64, from time.Time, to time.Time) (map[int64][]model.Event, error) {
+	userEvents := make(map[int64][]model.Event)
+	if len(userIDs) == 0 {
+		return userEvents, nil
+	}
+	query, args := participantEventsQuery(userIDs, "", `status = ? AND confirmed_start_time < ? AND (rrule <> '' OR confirmed_end_time > ?)`,
+		model.EventStatusConfirmed, to, from)
+	rows, err := eventRepo.dbConn.QueryContext(ctx, query+` ORDER BY user_id, id`, args...)
+	if err != nil {
+		log.Println("Error listing confirmed user events:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID int64
+		event, err := scanEvent(scanFunc(func(dest ...any) error {
+			return rows.Scan(append([]any{&userID}, dest...)...)
+		}))
+		if err != nil {
+			log.Println("Error scanning event:", err)
+			return nil, err
+		}
+		userEvents[userID] = append(userEvents[userID], event)
+	}
+
+	return userEvents, nil
+}
+
+// participantEventsQuery selects the user id and the columns of the events matching condition that each user organizes
+// or attends, locking appends a locking clause to both branches. Both branches look the users up first, through the
+// organizer and the attendee user index
+func participantEventsQuery(userIDs []int64, locking string, condition string, conditionArgs ...any) (string, []any) {
+	placeholders, ids := inClause(userIDs)
+	query := `(SELECT organizer_id AS user_id, ` + eventColumns + ` FROM event_detail
+	WHERE organizer_id IN (` + placeholders + `) AND ` + condition + locking + `)
+	UNION (SELECT attendee.user_id, ` + eventColumns + ` FROM event_detail JOIN (
+		SELECT event_id, user_id FROM event_attendee WHERE user_id IN (` + placeholders + `)
+	) AS attendee ON attendee.event_id = event_detail.id
+	WHERE ` + condition + locking + `)`
+	args := append(append(append(append([]any{}, ids...), conditionArgs...), ids...), conditionArgs...)
+	return query, args
+}
+
+// Lock the users until the end of the transaction, so that two confirmations involving the same user wait for each
+// other and the second one sees the slot of the first. The rows are created on first use and locked in ascending
+// order of user id, the upsert locks existing rows exclusively rather than shared and then upgraded, which would
+// deadlock two transactions locking the same user
+func (eventRepo *eventRepository) LockUsers(ctx context.Context, tx *sql.Tx, userIDs []int64) error {
+	ids := slices.Clone(userIDs)
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+	if len(ids) == 0 {
+		return nil
+	}
+	_, args := inClause(ids)
+	values := strings.TrimSuffix(strings.Repeat("(?), ", len(ids)), ", ")
+	_, err := tx.ExecContext(ctx, `INSERT INTO user_lock (user_id) VALUES `+values+` ON DUPLICATE KEY UPDATE user_id = user_id`, args...)
+	if err != nil {
+		log.Println("Error locking users:", err)
+		if isLockError(err) {
+			return &model.ConflictError{Message: "another event of the same users is being confirmed, try again"}
+		}
+		return err
+	}
+	return nil
+}
+
+// Lock the confirmed events each user organizes or attends that may overlap [from, to), keyed by user. The users are
+// locked by the caller beforehand with LockUsers
+func (eventRepo *eventRepository) LockUserEvents(ctx context.Context, tx *sql.Tx, userIDs []int64, from time.Time, to time.Time) (map[int64][]model.Event, error) {
+	userEvents := make(map[int64][]model.Event)
+	if len(userIDs) == 0 {
+		return userEvents, nil
+	}
+	query, args := participantEventsQuery(userIDs, " FOR UPDATE", `status = ? AND confirmed_start_time < ? AND (rrule <> '' OR confirmed_end_time > ?)`,
+		model.EventStatusConfirmed, to, from)
+	rows, err := tx.QueryContext(ctx, query+` ORDER BY user_id, id`, args...)
+	if err != nil {
+		log.Println("Error locking user events:", err)
+		if isLockError(err) {
+			return nil, &model.ConflictError{Message: "another event of the same users is being confirmed, try again"}
+		}
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID int64
+		event, err := scanEvent(scanFunc(func(dest ...any) error {
+			return rows.Scan(append([]any{&userID}, dest...)...)
+		}))
+		if err != nil {
+			log.Println("Error scanning event:", err)
+			return nil, err
+		}
+		userEvents[userID] = append(userEvents[userID], event)
+	}
+
+	return userEvents, nil
+}
+
+// scanFunc lets scanEvent read rows that carry columns in front of eventColumns
+type scanFunc func(dest ...any) error
+
+func (f scanFunc) Scan(dest ...any) error {
+	return f(dest...)
+}
+
+// Insert an attendee of the event
+func (eventRepo *eventRepository) InsertEventAttendee(ctx context.Context, tx *sql.Tx, eventID int64, attendee model.Attendee) error {
+	_, err := tx.ExecContext(ctx, `
+			INSERT INTO event_attendee (event_id, user_id, is_required, weight) 
+			VALUES (?, ?, ?, ?)`, eventID, attendee.UserID, attendee.Required, attendee.Weight)
+	if err != nil {
+		log.Println("Error inserting event attendee:", err)
+		if isMySQLError(err, mysqlErrDuplicateEntry) {
+			return &model.ConflictError{Message: fmt.Sprintf("user %d is already an attendee of event %d", attendee.UserID, eventID)}
+		}
+		return err
+	}
+	return nil
+}
+
+// Delete all the attendees of the event
+func (eventRepo *eventRepository) DeleteEventAttendees(ctx context.Context, tx *sql.Tx, eventID int64) error {
+	_, err := tx.ExecContext(ctx, `DELETE FROM event_attendee WHERE event_id = ?`, eventID)
+	if err != nil {
+		log.Println("Error deleting event attendees:", err)
+		return err
+	}
+	return nil
+}
+
+// Delete a single attendee of the event
+func (eventRepo *eventRepository) DeleteEventAttendee(ctx context.Context, tx *sql.Tx, eventID int64, userID int64) error {
+	result, err := tx.ExecContext(ctx, `DELETE FROM event_attendee WHERE event_id = ? AND user_id = ?`, eventID, userID)
+	if err != nil {
+		log.Println("Error deleting event attendee:", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Println("Error getting rows affected:", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		return &model.NotFoundError{Resource: "attendee", ID: userID}
+	}
+	return nil
+}
+
+// Get the event attendees
+func (eventRepo *eventRepository) GetEventAttendees(ctx context.Context, eventID int64) ([]model.Attendee, error) {
+	rows, err := eventRepo.dbConn.QueryContext(ctx, `SELECT user_id, is_required, weight FROM event_attendee WHERE event_id = ? ORDER BY user_id ASC`, eventID)
+	if err != nil {
+		log.Println("Error getting event attendees:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	attendees := []model.Attendee{}
+	for rows.Next() {
+		var attendee model.Attendee
+		if err := rows.Scan(&attendee.UserID, &attendee.Required, &attendee.Weight); err != nil {
+			log.Println("Error scanning event attendee:", err)
+			return nil, err
+		}
+		attendees = append(attendees, attendee)
+	}
+
+	return attendees, nil
+}
+
+// Insert or replace the exception of one occurrence of a recurring event
+func (eventRepo *eventRepository) UpsertEventException(ctx context.Context, tx *sql.Tx, eventID int64, exception model.EventException) error {
+	var startTime, endTime sql.NullTime
+	if !exception.Cancelled {
+		startTime = sql.NullTime{Time: exception.StartTime, Valid: true}
+		endTime = sql.NullTime{Time: exception.EndTime, Valid: true}
+	}
+	_, err := tx.ExecContext(ctx, `
+			INSERT INTO event_exception (event_id, occurrence_date, cancelled, start_time, end_time) 
+			VALUES (?, ?, ?, ?, ?) 
+			ON DUPLICATE KEY UPDATE cancelled = VALUES(cancelled), start_time = VALUES(start_time), end_time = VALUES(end_time)`,
+		eventID, exception.OccurrenceDate, exception.Cancelled, startTime, endTime)
+	if err != nil {
+		log.Println("Error upserting event exception:", err)
+		return err
+	}
+	return nil
+}
+
+// Delete the exception of one occurrence of a recurring event
+func (eventRepo *eventRepository) DeleteEventException(ctx context.Context, tx *sql.Tx, eventID int64, occurrenceDate string) error {
+	result, err := tx.ExecContext(ctx, `DELETE FROM event_exception WHERE event_id = ? AND occurrence_date = ?`, eventID, occurrenceDate)
+	if err != nil {
+		log.Println("Error deleting event exception:", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Println("Error getting rows affected:", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		return &model.NotFoundError{Resource: "exception for " + occurrenceDate + " of event", ID: eventID}
+	}
+	return nil
+}
+
+// Get the exceptions of a recurring event ordered by occurrence date
+func (eventRepo *eventRepository) GetEventExceptions(ctx context.Context, eventID int64) ([]model.EventException, error) {
+	rows, err := eventRepo.dbConn.QueryContext(ctx, `SELECT occurrence_date, cancelled, start_time, end_time FROM event_exception WHERE event_id = ? ORDER BY occurrence_date ASC`, eventID)
+	if err != nil {
+		log.Println("Error getting event exceptions:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	exceptions := []model.EventException{}
+	for rows.Next() {
+		var exception model.EventException
+		var occurrenceDate time.Time
+		var startTime, endTime sql.NullTime
+		if err := rows.Scan(&occurrenceDate, &exception.Cancelled, &startTime, &endTime); err != nil {
+			log.Println("Error scanning event exception:", err)
+			return nil, err
+		}
+		exception.OccurrenceDate = occurrenceDate.Format(time.DateOnly)
+		exception.StartTime = startTime.Time
+		exception.EndTime = endTime.Time
+		exceptions = append(exceptions, exception)
+	}
+
+	return exceptions, nil
+}
@@ -2,11 +2,13 @@ package repository
 
 import (
 	"context"
+	"database/sql/driver"
 	"regexp"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
 	"github.com/rahulshewale153/meeting-scheduler-api/model"
 	"github.com/stretchr/testify/assert"
 )
@@ -27,11 +29,13 @@ func TestInsertEvent(t *testing.T) {
 		Title:           "Test Event",
 		OrganizerID:     1,
 		DurationMinutes: 60,
+		TimeZone:        "Asia/Kolkata",
+		RRule:           "FREQ=WEEKLY",
 	}
-	query := `INSERT INTO event_detail (title, organizer_id, duration_minutes) VALUES (?, ?, ?)`
+	query := `INSERT INTO event_detail (title, organizer_id, duration_minutes, time_zone, rrule) VALUES (?, ?, ?, ?, ?)`
 	t.Run("Function must return an error when the write operation fails", func(t *testing.T) {
 		mock.ExpectExec(regexp.QuoteMeta(query)).
-			WithArgs(createEventReq.Title, createEventReq.OrganizerID, createEventReq.DurationMinutes).
+			WithArgs(createEventReq.Title, createEventReq.OrganizerID, createEventReq.DurationMinutes, createEventReq.TimeZone, createEventReq.RRule).
 			WillReturnError(assert.AnError)
 
 		_, err := repository.InsertEvent(ctx, tx, createEventReq)
@@ -40,7 +44,7 @@ func TestInsertEvent(t *testing.T) {
 
 	t.Run("Function must return the event_id when the insert operation is successful", func(t *testing.T) {
 		mock.ExpectExec(regexp.QuoteMeta(query)).
-			WithArgs(createEventReq.Title, createEventReq.OrganizerID, createEventReq.DurationMinutes).
+			WithArgs(createEventReq.Title, createEventReq.OrganizerID, createEventReq.DurationMinutes, createEventReq.TimeZone, createEventReq.RRule).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
 		eventID, err := repository.InsertEvent(ctx, tx, createEventReq)
@@ -69,10 +73,10 @@ func TestUpdateEvent(t *testing.T) {
 		DurationMinutes: 90,
 	}
 
-	query := `Update event_detail SET title = ?, organizer_id = ?, duration_minutes = ? WHERE id = ?`
+	query := `Update event_detail SET title = ?, organizer_id = ?, duration_minutes = ?, time_zone = ?, rrule = ? WHERE id = ?`
 	t.Run("Function must return an error when the write operation fails", func(t *testing.T) {
 		mock.ExpectExec(regexp.QuoteMeta(query)).
-			WithArgs(updateEventReq.Title, updateEventReq.OrganizerID, updateEventReq.DurationMinutes, updateEventReq.ID).
+			WithArgs(updateEventReq.Title, updateEventReq.OrganizerID, updateEventReq.DurationMinutes, updateEventReq.TimeZone, updateEventReq.RRule, updateEventReq.ID).
 			WillReturnError(assert.AnError)
 
 		err := repository.UpdateEvent(ctx, tx, updateEventReq)
@@ -81,7 +85,7 @@ func TestUpdateEvent(t *testing.T) {
 
 	t.Run("Function must return nil when the update operation is successful", func(t *testing.T) {
 		mock.ExpectExec(regexp.QuoteMeta(query)).
-			WithArgs(updateEventReq.Title, updateEventReq.OrganizerID, updateEventReq.DurationMinutes, updateEventReq.ID).
+			WithArgs(updateEventReq.Title, updateEventReq.OrganizerID, updateEventReq.DurationMinutes, updateEventReq.TimeZone, updateEventReq.RRule, updateEventReq.ID).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
 		err := repository.UpdateEvent(ctx, tx, updateEventReq)
@@ -143,10 +147,10 @@ func TestInsertEventSlots(t *testing.T) {
 		EndTime:   time.Date(2025, 07, 13, 11, 0, 0, 0, time.UTC),
 	}
 
-	query := `INSERT INTO event_slot (event_id, start_time, end_time) VALUES (?, ?, ?)`
+	query := `INSERT INTO event_slot (event_id, start_time, end_time, time_zone) VALUES (?, ?, ?, ?)`
 	t.Run("Function must return an error when the write operation fails", func(t *testing.T) {
 		mock.ExpectExec(regexp.QuoteMeta(query)).
-			WithArgs(eventID, slot.StartTime, slot.EndTime).
+			WithArgs(eventID, slot.StartTime, slot.EndTime, slot.TimeZone).
 			WillReturnError(assert.AnError)
 
 		err := repository.InsertEventSlots(ctx, tx, eventID, slot)
@@ -155,7 +159,7 @@ func TestInsertEventSlots(t *testing.T) {
 
 	t.Run("Function must return nil when the insert operation is successful", func(t *testing.T) {
 		mock.ExpectExec(regexp.QuoteMeta(query)).
-			WithArgs(eventID, slot.StartTime, slot.EndTime).
+			WithArgs(eventID, slot.StartTime, slot.EndTime, slot.TimeZone).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
 		err := repository.InsertEventSlots(ctx, tx, eventID, slot)
@@ -198,6 +202,40 @@ func TestDeleteEventSlots(t *testing.T) {
 
 }
 
+func TestDeleteAllEventSlots(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	repository := NewEventRepository(db)
+	ctx := context.Background()
+	eventID := int64(1)
+
+	query := `DELETE FROM event_slot WHERE event_id = ?`
+	t.Run("Function must return an error when the delete operation fails", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(eventID).
+			WillReturnError(assert.AnError)
+
+		err := repository.DeleteAllEventSlots(ctx, tx, eventID)
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must return nil when the slots of the event are deleted", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(eventID).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		err := repository.DeleteAllEventSlots(ctx, tx, eventID)
+		assert.NoError(t, err)
+	})
+}
+
 func TestGetEventSlots(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.Nil(t, err)
@@ -209,7 +247,7 @@ func TestGetEventSlots(t *testing.T) {
 	startTime := time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC)
 	endTime := time.Date(2025, 07, 13, 11, 0, 0, 0, time.UTC)
 
-	query := `SELECT id, start_time, end_time FROM event_slot WHERE event_id = ?`
+	query := `SELECT id, start_time, end_time, time_zone FROM event_slot WHERE event_id = ?`
 	t.Run("Function must return an error when the read operation fails", func(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(query)).
 			WithArgs(eventID).
@@ -222,8 +260,8 @@ func TestGetEventSlots(t *testing.T) {
 	t.Run("Function must return an error when scanning the rows fails", func(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(query)).
 			WithArgs(eventID).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "start_time", "end_time"}).
-				AddRow(nil, startTime, endTime))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "start_time", "end_time", "time_zone"}).
+				AddRow(nil, startTime, endTime, "UTC"))
 		_, err := repository.GetEventSlots(ctx, eventID)
 		assert.Error(t, err)
 	})
@@ -231,16 +269,16 @@ func TestGetEventSlots(t *testing.T) {
 	t.Run("Function must return an empty slice when no slots are found", func(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(query)).
 			WithArgs(eventID).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "start_time", "end_time"}))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "start_time", "end_time", "time_zone"}))
 		slots, err := repository.GetEventSlots(ctx, eventID)
 		assert.NoError(t, err)
 		assert.Empty(t, slots)
 	})
 
 	t.Run("Function must return the event slots when the read operation is successful", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "start_time", "end_time"}).
-			AddRow(1, startTime, endTime).
-			AddRow(2, startTime, endTime)
+		rows := sqlmock.NewRows([]string{"id", "start_time", "end_time", "time_zone"}).
+			AddRow(1, startTime, endTime, "UTC").
+			AddRow(2, startTime, endTime, "America/New_York")
 
 		mock.ExpectQuery(regexp.QuoteMeta(query)).
 			WithArgs(eventID).
@@ -251,6 +289,7 @@ func TestGetEventSlots(t *testing.T) {
 		assert.Len(t, slots, 2)
 		assert.Equal(t, int64(1), slots[0].ID)
 		assert.Equal(t, int64(2), slots[1].ID)
+		assert.Equal(t, "America/New_York", slots[1].TimeZone)
 	})
 
 }
@@ -266,7 +305,7 @@ func TestGetEvent(t *testing.T) {
 	createdAT := time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC)
 	updatedAT := time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC)
 
-	query := `SELECT id, title, organizer_id, duration_minutes, created_at, updated_at FROM event_detail WHERE id = ?`
+	query := `SELECT id, title, organizer_id, duration_minutes, time_zone, rrule, status, confirmed_start_time, confirmed_end_time, created_at, updated_at FROM event_detail WHERE id = ?`
 	t.Run("Function must return an error when the read operation fails", func(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(query)).
 			WithArgs(eventID).
@@ -279,24 +318,26 @@ func TestGetEvent(t *testing.T) {
 	t.Run("Function must return an error when scanning the row fails", func(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(query)).
 			WithArgs(eventID).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "organizer_id", "duration_minutes", "created_at", "updated_at"}).
-				AddRow(nil, "Test Event", 1, 60, createdAT, updatedAT))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "organizer_id", "duration_minutes", "time_zone", "rrule", "status", "confirmed_start_time", "confirmed_end_time", "created_at", "updated_at"}).
+				AddRow(nil, "Test Event", 1, 60, "UTC", "", "draft", nil, nil, createdAT, updatedAT))
 		_, err := repository.GetEvent(ctx, eventID)
 		assert.Error(t, err)
 	})
 
-	t.Run("Function must return an empty event when no event is found", func(t *testing.T) {
+	t.Run("Function must return a not found error when no event is found", func(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(query)).
 			WithArgs(eventID).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "organizer_id", "duration_minutes", "created_at", "updated_at"}))
-		event, err := repository.GetEvent(ctx, eventID)
-		assert.NoError(t, err)
-		assert.Equal(t, model.Event{}, event)
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "organizer_id", "duration_minutes", "time_zone", "rrule", "status", "confirmed_start_time", "confirmed_end_time", "created_at", "updated_at"}))
+		_, err := repository.GetEvent(ctx, eventID)
+		assert.ErrorIs(t, err, model.ErrNotFound)
+		var notFoundErr *model.NotFoundError
+		assert.ErrorAs(t, err, &notFoundErr)
+		assert.Equal(t, "event", notFoundErr.Resource)
 	})
 
 	t.Run("Function must return the event when the read operation is successful", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "title", "organizer_id", "duration_minutes", "created_at", "updated_at"}).
-			AddRow(1, "Test Event", 1, 60, createdAT, updatedAT)
+		rows := sqlmock.NewRows([]string{"id", "title", "organizer_id", "duration_minutes", "time_zone", "rrule", "status", "confirmed_start_time", "confirmed_end_time", "created_at", "updated_at"}).
+			AddRow(1, "Test Event", 1, 60, "Asia/Kolkata", "FREQ=WEEKLY;BYDAY=MO", "confirmed", createdAT.Add(24*time.Hour), createdAT.Add(25*time.Hour), createdAT, updatedAT)
 
 		mock.ExpectQuery(regexp.QuoteMeta(query)).
 			WithArgs(eventID).
@@ -305,6 +346,700 @@ func TestGetEvent(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, int64(1), event.ID)
 		assert.Equal(t, "Test Event", event.Title)
+		assert.Equal(t, "Asia/Kolkata", event.TimeZone)
+		assert.Equal(t, "FREQ=WEEKLY;BYDAY=MO", event.RRule)
+		assert.Equal(t, model.EventStatusConfirmed, event.Status)
+		assert.Equal(t, &model.EventSlot{StartTime: createdAT.Add(24 * time.Hour), EndTime: createdAT.Add(25 * time.Hour), TimeZone: "Asia/Kolkata"}, event.ConfirmedSlot)
+	})
+
+}
+
+func TestListEvents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	repository := NewEventRepository(db)
+	ctx := context.Background()
+	createdAT := time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC)
+	columns := []string{"id", "title", "organizer_id", "duration_minutes", "time_zone", "rrule", "status", "confirmed_start_time", "confirmed_end_time", "created_at", "updated_at"}
+
+	t.Run("Function must return an error when the read operation fails", func(t *testing.T) {
+		query := `SELECT id, title, organizer_id, duration_minutes, time_zone, rrule, status, confirmed_start_time, confirmed_end_time, created_at, updated_at FROM event_detail WHERE id > ? ORDER BY id ASC LIMIT ?`
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(int64(0), 10).
+			WillReturnError(assert.AnError)
+
+		_, err := repository.ListEvents(ctx, model.EventFilter{Limit: 10})
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must apply every filter when they are provided", func(t *testing.T) {
+		filter := model.EventFilter{
+			OrganizerID: 2,
+			CreatedFrom: createdAT,
+			CreatedTo:   createdAT.Add(time.Hour),
+			Title:       "sync",
+			Status:      model.EventStatusPolling,
+			AfterID:     5,
+			Limit:       10,
+		}
+		query := `SELECT id, title, organizer_id, duration_minutes, time_zone, rrule, status, confirmed_start_time, confirmed_end_time, created_at, updated_at FROM event_detail WHERE id > ? AND organizer_id = ? AND created_at >= ? AND created_at <= ? AND title LIKE ? ESCAPE '\\' AND status = ? ORDER BY id ASC LIMIT ?`
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(filter.AfterID, filter.OrganizerID, filter.CreatedFrom, filter.CreatedTo, "%sync%", filter.Status, filter.Limit).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(6, "Weekly sync", 2, 30, "UTC", "FREQ=WEEKLY", "polling", nil, nil, createdAT, createdAT).
+				AddRow(9, "Design sync", 2, 60, "Asia/Kolkata", "", "polling", nil, nil, createdAT, createdAT))
+
+		events, err := repository.ListEvents(ctx, filter)
+		assert.NoError(t, err)
+		assert.Len(t, events, 2)
+		assert.Equal(t, int64(6), events[0].ID)
+		assert.Equal(t, "Design sync", events[1].Title)
+		assert.Nil(t, events[1].ConfirmedSlot)
+	})
+
+	t.Run("Function must match the wildcards of the title literally", func(t *testing.T) {
+		query := `SELECT id, title, organizer_id, duration_minutes, time_zone, rrule, status, confirmed_start_time, confirmed_end_time, created_at, updated_at FROM event_detail WHERE id > ? AND title LIKE ? ESCAPE '\\' ORDER BY id ASC LIMIT ?`
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(int64(0), `%100\%\_done\\%`, 10).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		events, err := repository.ListEvents(ctx, model.EventFilter{Title: `100%_done\`, Limit: 10})
+		assert.NoError(t, err)
+		assert.Empty(t, events)
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
+}
+
+func TestListUserEvents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	repository := NewEventRepository(db)
+	ctx := context.Background()
+	createdAT := time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC)
+	confirmedAt := time.Date(2025, 07, 20, 9, 0, 0, 0, time.UTC)
+	columns := []string{"id", "title", "organizer_id", "duration_minutes", "time_zone", "rrule", "status", "confirmed_start_time", "confirmed_end_time", "created_at", "updated_at"}
+	query := `FROM event_detail WHERE status IN (?, ?) AND (`
+	statuses := []string{model.EventStatusConfirmed, model.EventStatusCancelled}
+
+	t.Run("Function must return an error when the read operation fails", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(model.EventStatusConfirmed, model.EventStatusCancelled, int64(3), int64(3), int64(3), int64(3)).
+			WillReturnError(assert.AnError)
+
+		_, err := repository.ListUserEvents(ctx, 3, statuses)
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must return the events of the user with their confirmed slot", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(model.EventStatusConfirmed, model.EventStatusCancelled, int64(3), int64(3), int64(3), int64(3)).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(4, "Planning", 3, 60, "Europe/Berlin", "", "confirmed", confirmedAt, confirmedAt.Add(time.Hour), createdAT, createdAT))
+
+		events, err := repository.ListUserEvents(ctx, 3, statuses)
+		assert.NoError(t, err)
+		assert.Len(t, events, 1)
+		assert.Equal(t, confirmedAt, events[0].ConfirmedSlot.StartTime)
+		assert.Equal(t, "Europe/Berlin", events[0].ConfirmedSlot.TimeZone)
+	})
+
+	t.Run("Function must return no events without a status to list", func(t *testing.T) {
+		events, err := repository.ListUserEvents(ctx, 3, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, events)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestLockEvent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	repository := NewEventRepository(db)
+	ctx := context.Background()
+	createdAT := time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC)
+	columns := []string{"id", "title", "organizer_id", "duration_minutes", "time_zone", "rrule", "status", "confirmed_start_time", "confirmed_end_time", "created_at", "updated_at"}
+
+	query := `SELECT id, title, organizer_id, duration_minutes, time_zone, rrule, status, confirmed_start_time, confirmed_end_time, created_at, updated_at FROM event_detail WHERE id = ? FOR UPDATE`
+	t.Run("Function must return a not found error when no event is found", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		_, err := repository.LockEvent(ctx, tx, 1)
+		assert.ErrorIs(t, err, model.ErrNotFound)
+	})
+
+	t.Run("Function must return a conflict error when the lock cannot be taken", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(1).
+			WillReturnError(&mysql.MySQLError{Number: 1205, Message: "Lock wait timeout exceeded; try restarting transaction"})
+
+		_, err := repository.LockEvent(ctx, tx, 1)
+		assert.ErrorIs(t, err, model.ErrConflict)
+	})
+
+	t.Run("Function must return the locked event as it is stored", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, "Test Event", 1, 60, "UTC", "", "confirmed", createdAT.Add(24*time.Hour), createdAT.Add(25*time.Hour), createdAT, createdAT))
+
+		event, err := repository.LockEvent(ctx, tx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, model.EventStatusConfirmed, event.Status)
+		assert.NotNil(t, event.ConfirmedSlot)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestStartPolling(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
 
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	repository := NewEventRepository(db)
+	ctx := context.Background()
+
+	query := `UPDATE event_detail SET status = ? WHERE id = ? AND status = ?`
+	t.Run("Function must return an error when the write operation fails", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(model.EventStatusPolling, 1, model.EventStatusDraft).
+			WillReturnError(assert.AnError)
+
+		err := repository.StartPolling(ctx, tx, 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must leave an event that is no longer a draft as it is", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(model.EventStatusPolling, 1, model.EventStatusDraft).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repository.StartPolling(ctx, tx, 1)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCancelEvent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	repository := NewEventRepository(db)
+	ctx := context.Background()
+
+	query := `UPDATE event_detail SET status = ? WHERE id = ? AND status <> ?`
+	t.Run("Function must return an error when the write operation fails", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(model.EventStatusCancelled, 1, model.EventStatusCancelled).
+			WillReturnError(assert.AnError)
+
+		err := repository.CancelEvent(ctx, tx, 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must return a conflict error when the event is already cancelled", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(model.EventStatusCancelled, 1, model.EventStatusCancelled).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repository.CancelEvent(ctx, tx, 1)
+		assert.ErrorIs(t, err, model.ErrConflict)
+	})
+
+	t.Run("Function must return nil when the update operation is successful", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(model.EventStatusCancelled, 1, model.EventStatusCancelled).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repository.CancelEvent(ctx, tx, 1)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestConfirmEvent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	repository := NewEventRepository(db)
+	ctx := context.Background()
+	slot := model.EventSlot{
+		StartTime: time.Date(2025, 07, 14, 10, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2025, 07, 14, 11, 0, 0, 0, time.UTC),
+	}
+
+	query := `UPDATE event_detail SET status = ?, confirmed_start_time = ?, confirmed_end_time = ? WHERE id = ? AND status IN (?, ?)`
+	t.Run("Function must return an error when the write operation fails", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(model.EventStatusConfirmed, slot.StartTime, slot.EndTime, 1, model.EventStatusDraft, model.EventStatusPolling).
+			WillReturnError(assert.AnError)
+
+		err := repository.ConfirmEvent(ctx, tx, 1, slot)
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must return a conflict error when the event is no longer open", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(model.EventStatusConfirmed, slot.StartTime, slot.EndTime, 1, model.EventStatusDraft, model.EventStatusPolling).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repository.ConfirmEvent(ctx, tx, 1, slot)
+		assert.ErrorIs(t, err, model.ErrConflict)
+	})
+
+	t.Run("Function must return a conflict error when the update waited too long for a lock", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(model.EventStatusConfirmed, slot.StartTime, slot.EndTime, 1, model.EventStatusDraft, model.EventStatusPolling).
+			WillReturnError(&mysql.MySQLError{Number: 1205, Message: "Lock wait timeout exceeded; try restarting transaction"})
+
+		err := repository.ConfirmEvent(ctx, tx, 1, slot)
+		assert.ErrorIs(t, err, model.ErrConflict)
+	})
+
+	t.Run("Function must store the slot when the update operation is successful", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(model.EventStatusConfirmed, slot.StartTime, slot.EndTime, 1, model.EventStatusDraft, model.EventStatusPolling).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repository.ConfirmEvent(ctx, tx, 1, slot)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestInsertEventAttendee(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	repository := NewEventRepository(db)
+	ctx := context.Background()
+	eventID := int64(1)
+	attendee := model.Attendee{UserID: 2, Required: true, Weight: 1}
+
+	query := `INSERT INTO event_attendee (event_id, user_id, is_required, weight) VALUES (?, ?, ?, ?)`
+	t.Run("Function must return an error when the write operation fails", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(eventID, attendee.UserID, attendee.Required, attendee.Weight).
+			WillReturnError(assert.AnError)
+
+		err := repository.InsertEventAttendee(ctx, tx, eventID, attendee)
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must return a conflict error when the user is already an attendee", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(eventID, attendee.UserID, attendee.Required, attendee.Weight).
+			WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry '1-2' for key 'uq_event_attendee'"})
+
+		err := repository.InsertEventAttendee(ctx, tx, eventID, attendee)
+		assert.ErrorIs(t, err, model.ErrConflict)
+	})
+
+	t.Run("Function must return nil when the insert operation is successful", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(eventID, attendee.UserID, attendee.Required, attendee.Weight).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := repository.InsertEventAttendee(ctx, tx, eventID, attendee)
+		assert.NoError(t, err)
+	})
+}
+
+func TestGetEventAttendees(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	repository := NewEventRepository(db)
+	ctx := context.Background()
+	eventID := int64(1)
+
+	query := `SELECT user_id, is_required, weight FROM event_attendee WHERE event_id = ? ORDER BY user_id ASC`
+	t.Run("Function must return an error when the read operation fails", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(eventID).
+			WillReturnError(assert.AnError)
+
+		_, err := repository.GetEventAttendees(ctx, eventID)
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must return the attendees when the read operation is successful", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(eventID).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "is_required", "weight"}).
+				AddRow(2, true, 1.0).
+				AddRow(3, false, 2.5))
+
+		attendees, err := repository.GetEventAttendees(ctx, eventID)
+		assert.NoError(t, err)
+		assert.Equal(t, []model.Attendee{{UserID: 2, Required: true, Weight: 1}, {UserID: 3, Weight: 2.5}}, attendees)
+	})
+}
+
+func TestDeleteEventAttendee(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	repository := NewEventRepository(db)
+	ctx := context.Background()
+	eventID := int64(1)
+	userID := int64(2)
+
+	query := `DELETE FROM event_attendee WHERE event_id = ? AND user_id = ?`
+	t.Run("Function must return an error when the delete operation fails", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(eventID, userID).
+			WillReturnError(assert.AnError)
+
+		err := repository.DeleteEventAttendee(ctx, tx, eventID, userID)
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must return a not found error when the user is not an attendee", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(eventID, userID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repository.DeleteEventAttendee(ctx, tx, eventID, userID)
+		assert.ErrorIs(t, err, model.ErrNotFound)
+	})
+
+	t.Run("Function must return nil when the delete operation is successful", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(eventID, userID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repository.DeleteEventAttendee(ctx, tx, eventID, userID)
+		assert.NoError(t, err)
+	})
+}
+
+func TestUpsertEventException(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	repository := NewEventRepository(db)
+	ctx := context.Background()
+	startTime := time.Date(2025, 07, 22, 10, 0, 0, 0, time.UTC)
+	endTime := time.Date(2025, 07, 22, 11, 0, 0, 0, time.UTC)
+
+	query := `INSERT INTO event_exception (event_id, occurrence_date, cancelled, start_time, end_time) VALUES (?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE cancelled = VALUES(cancelled), start_time = VALUES(start_time), end_time = VALUES(end_time)`
+	t.Run("Function must return an error when the write operation fails", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(1, "2025-07-21", true, nil, nil).
+			WillReturnError(assert.AnError)
+
+		err := repository.UpsertEventException(ctx, tx, 1, model.EventException{OccurrenceDate: "2025-07-21", Cancelled: true})
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must store no times for a cancelled occurrence", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(1, "2025-07-21", true, nil, nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := repository.UpsertEventException(ctx, tx, 1, model.EventException{OccurrenceDate: "2025-07-21", Cancelled: true, StartTime: startTime, EndTime: endTime})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Function must store the new times of a moved occurrence", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(1, "2025-07-21", false, startTime, endTime).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := repository.UpsertEventException(ctx, tx, 1, model.EventException{OccurrenceDate: "2025-07-21", StartTime: startTime, EndTime: endTime})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeleteEventException(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	repository := NewEventRepository(db)
+	ctx := context.Background()
+
+	query := `DELETE FROM event_exception WHERE event_id = ? AND occurrence_date = ?`
+	t.Run("Function must return an error when the delete operation fails", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(1, "2025-07-21").
+			WillReturnError(assert.AnError)
+
+		err := repository.DeleteEventException(ctx, tx, 1, "2025-07-21")
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must return a not found error when the occurrence has no exception", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(1, "2025-07-21").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repository.DeleteEventException(ctx, tx, 1, "2025-07-21")
+		assert.ErrorIs(t, err, model.ErrNotFound)
+	})
+
+	t.Run("Function must return nil when the delete operation is successful", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(1, "2025-07-21").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repository.DeleteEventException(ctx, tx, 1, "2025-07-21")
+		assert.NoError(t, err)
+	})
+}
+
+func TestGetEventExceptions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	repository := NewEventRepository(db)
+	ctx := context.Background()
+	startTime := time.Date(2025, 07, 29, 10, 0, 0, 0, time.UTC)
+	endTime := time.Date(2025, 07, 29, 11, 0, 0, 0, time.UTC)
+
+	query := `SELECT occurrence_date, cancelled, start_time, end_time FROM event_exception WHERE event_id = ? ORDER BY occurrence_date ASC`
+	t.Run("Function must return an error when the read operation fails", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(1).
+			WillReturnError(assert.AnError)
+
+		_, err := repository.GetEventExceptions(ctx, 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must return the exceptions with their dates", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"occurrence_date", "cancelled", "start_time", "end_time"}).
+				AddRow(time.Date(2025, 07, 21, 0, 0, 0, 0, time.UTC), true, nil, nil).
+				AddRow(time.Date(2025, 07, 28, 0, 0, 0, 0, time.UTC), false, startTime, endTime))
+
+		exceptions, err := repository.GetEventExceptions(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []model.EventException{
+			{OccurrenceDate: "2025-07-21", Cancelled: true},
+			{OccurrenceDate: "2025-07-28", StartTime: startTime, EndTime: endTime},
+		}, exceptions)
+	})
+}
+
+func TestListConfirmedUserEvents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	repository := NewEventRepository(db)
+	ctx := context.Background()
+	createdAT := time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC)
+	confirmedAt := time.Date(2025, 07, 20, 9, 0, 0, 0, time.UTC)
+	from := time.Date(2025, 07, 14, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 07, 28, 0, 0, 0, 0, time.UTC)
+	columns := []string{"user_id", "id", "title", "organizer_id", "duration_minutes", "time_zone", "rrule", "status", "confirmed_start_time", "confirmed_end_time", "created_at", "updated_at"}
+	query := `WHERE organizer_id IN (?, ?) AND status = ? AND confirmed_start_time < ? AND (rrule <> '' OR confirmed_end_time > ?))
+	UNION (SELECT attendee.user_id, ` + eventColumns + ` FROM event_detail JOIN (
+		SELECT event_id, user_id FROM event_attendee WHERE user_id IN (?, ?)
+	) AS attendee ON attendee.event_id = event_detail.id
+	WHERE status = ? AND confirmed_start_time < ? AND (rrule <> '' OR confirmed_end_time > ?)) ORDER BY user_id, id`
+
+	t.Run("Function must not query the database when no user is given", func(t *testing.T) {
+		userEvents, err := repository.ListConfirmedUserEvents(ctx, nil, from, to)
+		assert.NoError(t, err)
+		assert.Empty(t, userEvents)
+	})
+
+	t.Run("Function must return an error when the read operation fails", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(int64(3), int64(4), model.EventStatusConfirmed, to, from, int64(3), int64(4), model.EventStatusConfirmed, to, from).
+			WillReturnError(assert.AnError)
+
+		_, err := repository.ListConfirmedUserEvents(ctx, []int64{3, 4}, from, to)
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must return the confirmed events of every user", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(int64(3), int64(4), model.EventStatusConfirmed, to, from, int64(3), int64(4), model.EventStatusConfirmed, to, from).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(3, 4, "Planning", 3, 60, "Europe/Berlin", "", "confirmed", confirmedAt, confirmedAt.Add(time.Hour), createdAT, createdAT).
+				AddRow(3, 6, "Standup", 5, 15, "UTC", "FREQ=DAILY", "confirmed", confirmedAt, confirmedAt.Add(15*time.Minute), createdAT, createdAT).
+				AddRow(4, 4, "Planning", 3, 60, "Europe/Berlin", "", "confirmed", confirmedAt, confirmedAt.Add(time.Hour), createdAT, createdAT))
+
+		userEvents, err := repository.ListConfirmedUserEvents(ctx, []int64{3, 4}, from, to)
+		assert.NoError(t, err)
+		assert.Len(t, userEvents[3], 2)
+		assert.Equal(t, "FREQ=DAILY", userEvents[3][1].RRule)
+		assert.Len(t, userEvents[4], 1)
+		assert.Equal(t, confirmedAt, userEvents[4][0].ConfirmedSlot.StartTime)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestLockUsers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	repository := NewEventRepository(db)
+	ctx := context.Background()
+
+	query := `INSERT INTO user_lock (user_id) VALUES (?), (?), (?) ON DUPLICATE KEY UPDATE user_id = user_id`
+	t.Run("Function must lock nothing without users", func(t *testing.T) {
+		err := repository.LockUsers(ctx, tx, nil)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Function must return a conflict error when a user stays locked", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(3, 5, 7).
+			WillReturnError(&mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock; try restarting transaction"})
+
+		err := repository.LockUsers(ctx, tx, []int64{7, 3, 5})
+		assert.ErrorIs(t, err, model.ErrConflict)
+	})
+
+	t.Run("Function must return an error when the write operation fails", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(3, 5, 7).
+			WillReturnError(assert.AnError)
+
+		err := repository.LockUsers(ctx, tx, []int64{7, 3, 5})
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("Function must lock each user once in ascending order of id", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(3, 5, 7).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := repository.LockUsers(ctx, tx, []int64{7, 3, 5, 3})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestLockUserEvents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	repository := NewEventRepository(db)
+	ctx := context.Background()
+	createdAT := time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC)
+	confirmedAt := time.Date(2025, 07, 20, 9, 0, 0, 0, time.UTC)
+	from := time.Date(2025, 07, 20, 9, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 07, 20, 10, 0, 0, 0, time.UTC)
+	columns := []string{"user_id", "id", "title", "organizer_id", "duration_minutes", "time_zone", "rrule", "status", "confirmed_start_time", "confirmed_end_time", "created_at", "updated_at"}
+	query := `WHERE organizer_id IN (?, ?) AND status = ? AND confirmed_start_time < ? AND (rrule <> '' OR confirmed_end_time > ?) FOR UPDATE)
+	UNION (SELECT attendee.user_id, ` + eventColumns + ` FROM event_detail JOIN (
+		SELECT event_id, user_id FROM event_attendee WHERE user_id IN (?, ?)
+	) AS attendee ON attendee.event_id = event_detail.id
+	WHERE status = ? AND confirmed_start_time < ? AND (rrule <> '' OR confirmed_end_time > ?) FOR UPDATE) ORDER BY user_id, id`
+	args := []driver.Value{int64(3), int64(4), model.EventStatusConfirmed, to, from, int64(3), int64(4), model.EventStatusConfirmed, to, from}
+
+	t.Run("Function must not query the database when no user is given", func(t *testing.T) {
+		userEvents, err := repository.LockUserEvents(ctx, tx, nil, from, to)
+		assert.NoError(t, err)
+		assert.Empty(t, userEvents)
+	})
+
+	t.Run("Function must return a conflict error when the lock cannot be acquired", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(args...).
+			WillReturnError(&mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock; try restarting transaction"})
+
+		_, err := repository.LockUserEvents(ctx, tx, []int64{3, 4}, from, to)
+		assert.ErrorIs(t, err, model.ErrConflict)
+	})
+
+	t.Run("Function must return an error when the read operation fails", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(args...).
+			WillReturnError(assert.AnError)
+
+		_, err := repository.LockUserEvents(ctx, tx, []int64{3, 4}, from, to)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("Function must return the locked confirmed events of every user", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(args...).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(3, 2, "Standup", 5, 15, "UTC", "FREQ=DAILY", "confirmed", confirmedAt, confirmedAt.Add(15*time.Minute), createdAT, createdAT).
+				AddRow(3, 4, "Planning", 3, 60, "Europe/Berlin", "", "confirmed", confirmedAt, confirmedAt.Add(time.Hour), createdAT, createdAT).
+				AddRow(4, 4, "Planning", 3, 60, "Europe/Berlin", "", "confirmed", confirmedAt, confirmedAt.Add(time.Hour), createdAT, createdAT))
+
+		userEvents, err := repository.LockUserEvents(ctx, tx, []int64{3, 4}, from, to)
+		assert.NoError(t, err)
+		assert.Len(t, userEvents[3], 2)
+		assert.Equal(t, "FREQ=DAILY", userEvents[3][0].RRule)
+		assert.Equal(t, model.EventStatusConfirmed, userEvents[4][0].Status)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
 }
@@ -18,13 +18,69 @@ type EventRepositoryI interface {
 	DeleteEvent(ctx context.Context, tx *sql.Tx, eventID int64) error
 	InsertEventSlots(ctx context.Context, tx *sql.Tx, eventID int64, slot model.EventSlot) error
 	DeleteEventSlots(ctx context.Context, tx *sql.Tx, slotID int64) error
+	DeleteAllEventSlots(ctx context.Context, tx *sql.Tx, eventID int64) error
 	GetEventSlots(ctx context.Context, eventID int64) ([]model.EventSlot, error)
 	GetEvent(ctx context.Context, eventID int64) (model.Event, error)
+	ListEvents(ctx context.Context, filter model.EventFilter) ([]model.Event, error)
+	ListUserEvents(ctx context.Context, userID int64, statuses []string) ([]model.Event, error)
+	ListConfirmedUserEvents(ctx context.Context, userIDs []int64, from time.Time, to time.Time) (map[int64][]model.Event, error)
+	LockUsers(ctx context.Context, tx *sql.Tx, userIDs []int64) error
+	LockUserEvents(ctx context.Context, tx *sql.Tx, userIDs []int64, from time.Time, to time.Time) (map[int64][]model.Event, error)
+	LockEvent(ctx context.Context, tx *sql.Tx, eventID int64) (model.Event, error)
+	StartPolling(ctx context.Context, tx *sql.Tx, eventID int64) error
+	CancelEvent(ctx context.Context, tx *sql.Tx, eventID int64) error
+	ConfirmEvent(ctx context.Context, tx *sql.Tx, eventID int64, slot model.EventSlot) error
+	InsertEventAttendee(ctx context.Context, tx *sql.Tx, eventID int64, attendee model.Attendee) error
+	DeleteEventAttendees(ctx context.Context, tx *sql.Tx, eventID int64) error
+	DeleteEventAttendee(ctx context.Context, tx *sql.Tx, eventID int64, userID int64) error
+	GetEventAttendees(ctx context.Context, eventID int64) ([]model.Attendee, error)
+	UpsertEventException(ctx context.Context, tx *sql.Tx, eventID int64, exception model.EventException) error
+	DeleteEventException(ctx context.Context, tx *sql.Tx, eventID int64, occurrenceDate string) error
+	GetEventExceptions(ctx context.Context, eventID int64) ([]model.EventException, error)
 }
 
 type UserAvailabilityRepositoryI interface {
-	InsertUserAvailability(ctx context.Context, tx *sql.Tx, userID int64, eventID int64, startTime time.Time, endTime time.Time) (int64, error)
+	InsertUserAvailability(ctx context.Context, tx *sql.Tx, userID int64, eventID int64, startTime time.Time, endTime time.Time, timeZone string) (int64, error)
+	InsertUserAvailabilityBatch(ctx context.Context, tx *sql.Tx, eventID int64, items []model.UserAvailability) error
 	GetAllEventUsers(ctx context.Context, eventID int64) (map[int64][]model.EventSlot, error)
 	DeleteUserAvailability(ctx context.Context, tx *sql.Tx, userID int64, eventID int64) error
+	DeleteUserAvailabilitySlot(ctx context.Context, tx *sql.Tx, userID int64, eventID int64, slotID int64) error
 	GetUserAvailability(ctx context.Context, eventID int64, userID int64) ([]model.EventSlot, error)
+	GetSubmittedAvailability(ctx context.Context, eventID int64, userID int64) ([]model.EventSlot, error)
+	InsertAvailabilityRule(ctx context.Context, tx *sql.Tx, userID int64, eventID int64, rule model.AvailabilityRule) (int64, error)
+	DeleteAvailabilityRules(ctx context.Context, tx *sql.Tx, userID int64, eventID int64) error
+	GetAvailabilityRules(ctx context.Context, eventID int64, userID int64) ([]model.AvailabilityRule, error)
+	GetAllEventRules(ctx context.Context, eventID int64) (map[int64][]model.AvailabilityRule, error)
+	ReplaceSyncedAvailability(ctx context.Context, tx *sql.Tx, userID int64, eventID int64, slots []model.EventSlot) error
+	ListUserEventAvailability(ctx context.Context, userID int64, filter model.UserEventFilter) ([]model.UserEventAvailability, error)
+}
+
+type UserRepositoryI interface {
+	UpsertUserProfile(ctx context.Context, tx *sql.Tx, profile model.UserProfile) error
+	GetUserProfile(ctx context.Context, userID int64) (model.UserProfile, error)
+	GetUserProfiles(ctx context.Context, userIDs []int64) (map[int64]model.UserProfile, error)
+	ReplaceWorkingHours(ctx context.Context, tx *sql.Tx, userID int64, workingHours []model.WorkingHours) error
+}
+
+// ResourceRepositoryI stores rooms and equipment, when they can be booked and the resources events require
+type ResourceRepositoryI interface {
+	InsertResource(ctx context.Context, tx *sql.Tx, resource model.Resource) (int64, error)
+	UpdateResource(ctx context.Context, tx *sql.Tx, resource model.Resource) error
+	DeleteResource(ctx context.Context, tx *sql.Tx, resourceID int64) error
+	GetResource(ctx context.Context, resourceID int64) (model.Resource, error)
+	ListResources(ctx context.Context, filter model.ResourceFilter) ([]model.Resource, error)
+	LockResources(ctx context.Context, tx *sql.Tx, resourceIDs []int64) error
+	ReplaceResourceAvailability(ctx context.Context, tx *sql.Tx, resourceID int64, windows []model.EventSlot) error
+	GetResourceAvailability(ctx context.Context, resourceIDs []int64) (map[int64][]model.EventSlot, error)
+	InsertEventResource(ctx context.Context, tx *sql.Tx, eventID int64, requirement model.ResourceRequirement) (int64, error)
+	DeleteEventResource(ctx context.Context, tx *sql.Tx, eventID int64, requirementID int64) error
+	GetEventResources(ctx context.Context, eventID int64) ([]model.ResourceRequirement, error)
+	BookEventResource(ctx context.Context, tx *sql.Tx, requirementID int64, resourceID int64) error
+	ReleaseEventResources(ctx context.Context, tx *sql.Tx, eventID int64) error
+	ListBookedEvents(ctx context.Context, resourceIDs []int64, from time.Time, to time.Time) (map[int64][]model.Event, error)
+}
+
+// FreeBusyRepositoryI reads the busy time of a user from their calendar, a user without a calendar is not found
+type FreeBusyRepositoryI interface {
+	GetFreeBusy(ctx context.Context, userID int64, from time.Time, to time.Time) ([]model.EventSlot, error)
 }
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// mysql server error numbers the repositories translate into model errors
+const (
+	mysqlErrDuplicateEntry  = 1062 // Duplicate entry for key
+	mysqlErrRowIsReferenced = 1451 // Cannot delete or update a parent row: a foreign key constraint fails
+	mysqlErrNoReferencedRow = 1452 // Cannot add or update a child row: a foreign key constraint fails
+	mysqlErrLockWaitTimeout = 1205 // Lock wait timeout exceeded
+	mysqlErrDeadlock        = 1213 // Deadlock found when trying to get lock
+)
+
+func isMySQLError(err error, number uint16) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == number
+}
+
+// isLockError reports whether a statement gave up waiting for a row lock, the transaction can be retried
+func isLockError(err error) bool {
+	return isMySQLError(err, mysqlErrDeadlock) || isMySQLError(err, mysqlErrLockWaitTimeout)
+}
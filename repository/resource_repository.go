@@ -0,0 +1,375 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+)
+
+// resourceColumns are the columns of resource read by scanResource, in order
+const resourceColumns = `id, name, kind, capacity, location, features, created_at, updated_at`
+
+type resourceRepository struct {
+	dbConn *sql.DB
+}
+
+func NewResourceRepository(dbConn *sql.DB) ResourceRepositoryI {
+	return &resourceRepository{dbConn: dbConn}
+}
+
+// Insert the resource
+func (resourceRepo *resourceRepository) InsertResource(ctx context.Context, tx *sql.Tx, resource model.Resource) (int64, error) {
+	features, err := encodeFeatures(resource.Features)
+	if err != nil {
+		return 0, err
+	}
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO resource (name, kind, capacity, location, features)
+		VALUES (?, ?, ?, ?, ?)`, resource.Name, resource.Kind, resource.Capacity, resource.Location, features)
+	if err != nil {
+		log.Println("Error inserting resource:", err)
+		return 0, err
+	}
+
+	resourceID, err := result.LastInsertId()
+	if err != nil {
+		log.Println("Error getting last insert ID:", err)
+		return 0, err
+	}
+	return resourceID, nil
+}
+
+// Update the resource
+func (resourceRepo *resourceRepository) UpdateResource(ctx context.Context, tx *sql.Tx, resource model.Resource) error {
+	features, err := encodeFeatures(resource.Features)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE resource SET name = ?, kind = ?, capacity = ?, location = ?, features = ? WHERE id = ?`,
+		resource.Name, resource.Kind, resource.Capacity, resource.Location, features, resource.ID)
+	if err != nil {
+		log.Println("Error updating resource:", err)
+		return err
+	}
+	return nil
+}
+
+// Delete the resource and release the bookings of it, a resource that a requirement of an event names cannot be deleted
+func (resourceRepo *resourceRepository) DeleteResource(ctx context.Context, tx *sql.Tx, resourceID int64) error {
+	result, err := tx.ExecContext(ctx, `DELETE FROM resource WHERE id = ?`, resourceID)
+	if err != nil {
+		log.Println("Error deleting resource:", err)
+		// foreign key violation, an event still requires the resource
+		if isMySQLError(err, mysqlErrRowIsReferenced) {
+			return &model.ConflictError{Message: fmt.Sprintf("resource %d is required by an event, remove the requirement first", resourceID)}
+		}
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Println("Error getting rows affected:", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		return &model.NotFoundError{Resource: "resource", ID: resourceID}
+	}
+	return nil
+}
+
+// Get the resource by ID
+func (resourceRepo *resourceRepository) GetResource(ctx context.Context, resourceID int64) (model.Resource, error) {
+	row := resourceRepo.dbConn.QueryRowContext(ctx, `SELECT `+resourceColumns+` FROM resource WHERE id = ?`, resourceID)
+	resource, err := scanResource(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.Resource{}, &model.NotFoundError{Resource: "resource", ID: resourceID}
+		}
+		log.Println("Error getting resource by ID:", err)
+		return model.Resource{}, err
+	}
+	return resource, nil
+}
+
+// List the resources matching the filter, smallest capacity first so that the first free one is the best fit
+func (resourceRepo *resourceRepository) ListResources(ctx context.Context, filter model.ResourceFilter) ([]model.Resource, error) {
+	conditions := []string{"capacity >= ?"}
+	args := []any{filter.MinCapacity}
+	if filter.Kind != "" {
+		conditions = append(conditions, "kind = ?")
+		args = append(args, filter.Kind)
+	}
+	if filter.Location != "" {
+		conditions = append(conditions, "location = ?")
+		args = append(args, filter.Location)
+	}
+	if len(filter.Features) > 0 {
+		features, err := encodeFeatures(filter.Features)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, "JSON_CONTAINS(features, ?)")
+		args = append(args, features)
+	}
+
+	query := `SELECT ` + resourceColumns + ` FROM resource WHERE ` + strings.Join(conditions, " AND ") + ` ORDER BY capacity ASC, id ASC`
+	rows, err := resourceRepo.dbConn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Println("Error listing resources:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	resources := []model.Resource{}
+	for rows.Next() {
+		resource, err := scanResource(rows)
+		if err != nil {
+			log.Println("Error scanning resource:", err)
+			return nil, err
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// Lock the resources until the transaction ends, so that two confirmations booking one of them wait for each other
+func (resourceRepo *resourceRepository) LockResources(ctx context.Context, tx *sql.Tx, resourceIDs []int64) error {
+	if len(resourceIDs) == 0 {
+		return nil
+	}
+	placeholders, args := inClause(resourceIDs)
+	rows, err := tx.QueryContext(ctx, `SELECT id FROM resource WHERE id IN (`+placeholders+`) ORDER BY id FOR UPDATE`, args...)
+	if err != nil {
+		log.Println("Error locking resources:", err)
+		if isLockError(err) {
+			return &model.ConflictError{Message: "another event booking the same resources is being confirmed, try again"}
+		}
+		return err
+	}
+	return rows.Close()
+}
+
+// Replace the bookable windows of the resource
+func (resourceRepo *resourceRepository) ReplaceResourceAvailability(ctx context.Context, tx *sql.Tx, resourceID int64, windows []model.EventSlot) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM resource_availability WHERE resource_id = ?`, resourceID); err != nil {
+		log.Println("Error deleting resource availability:", err)
+		return err
+	}
+
+	query := `INSERT INTO resource_availability (resource_id, start_time, end_time, time_zone) VALUES (?, ?, ?, ?)`
+	for _, window := range windows {
+		if _, err := tx.ExecContext(ctx, query, resourceID, window.StartTime, window.EndTime, window.TimeZone); err != nil {
+			log.Println("Error inserting resource availability:", err)
+			if isMySQLError(err, mysqlErrNoReferencedRow) {
+				return &model.NotFoundError{Resource: "resource", ID: resourceID}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Get the bookable windows of the resources keyed by resource, ordered by start. Resources without any are left out
+func (resourceRepo *resourceRepository) GetResourceAvailability(ctx context.Context, resourceIDs []int64) (map[int64][]model.EventSlot, error) {
+	windows := make(map[int64][]model.EventSlot)
+	if len(resourceIDs) == 0 {
+		return windows, nil
+	}
+	placeholders, args := inClause(resourceIDs)
+	query := `SELECT id, resource_id, start_time, end_time, time_zone FROM resource_availability WHERE resource_id IN (` + placeholders + `) ORDER BY resource_id, start_time`
+	rows, err := resourceRepo.dbConn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Println("Error getting resource availability:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var resourceID int64
+		var window model.EventSlot
+		if err := rows.Scan(&window.ID, &resourceID, &window.StartTime, &window.EndTime, &window.TimeZone); err != nil {
+			log.Println("Error scanning resource availability:", err)
+			return nil, err
+		}
+		windows[resourceID] = append(windows[resourceID], window)
+	}
+	return windows, nil
+}
+
+// Insert a resource requirement of the event
+func (resourceRepo *resourceRepository) InsertEventResource(ctx context.Context, tx *sql.Tx, eventID int64, requirement model.ResourceRequirement) (int64, error) {
+	features, err := encodeFeatures(requirement.Features)
+	if err != nil {
+		return 0, err
+	}
+	var resourceID sql.NullInt64
+	if requirement.ResourceID != 0 {
+		resourceID = sql.NullInt64{Int64: requirement.ResourceID, Valid: true}
+	}
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO event_resource (event_id, resource_id, kind, min_capacity, features)
+		VALUES (?, ?, ?, ?, ?)`, eventID, resourceID, requirement.Kind, requirement.MinCapacity, features)
+	if err != nil {
+		log.Println("Error inserting event resource:", err)
+		// foreign key violation, the event is checked by the caller so the resource does not exist
+		if isMySQLError(err, mysqlErrNoReferencedRow) {
+			return 0, &model.NotFoundError{Resource: "resource", ID: requirement.ResourceID}
+		}
+		return 0, err
+	}
+
+	requirementID, err := result.LastInsertId()
+	if err != nil {
+		log.Println("Error getting last insert ID:", err)
+		return 0, err
+	}
+	return requirementID, nil
+}
+
+// Delete a resource requirement of the event
+func (resourceRepo *resourceRepository) DeleteEventResource(ctx context.Context, tx *sql.Tx, eventID int64, requirementID int64) error {
+	result, err := tx.ExecContext(ctx, `DELETE FROM event_resource WHERE event_id = ? AND id = ?`, eventID, requirementID)
+	if err != nil {
+		log.Println("Error deleting event resource:", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Println("Error getting rows affected:", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		return &model.NotFoundError{Resource: "resource requirement", ID: requirementID}
+	}
+	return nil
+}
+
+// Get the resource requirements of the event, ordered by id
+func (resourceRepo *resourceRepository) GetEventResources(ctx context.Context, eventID int64) ([]model.ResourceRequirement, error) {
+	rows, err := resourceRepo.dbConn.QueryContext(ctx, `SELECT id, resource_id, kind, min_capacity, features, booked_resource_id FROM event_resource WHERE event_id = ? ORDER BY id ASC`, eventID)
+	if err != nil {
+		log.Println("Error getting event resources:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	requirements := []model.ResourceRequirement{}
+	for rows.Next() {
+		var requirement model.ResourceRequirement
+		var resourceID, bookedResourceID sql.NullInt64
+		var features []byte
+		if err := rows.Scan(&requirement.ID, &resourceID, &requirement.Kind, &requirement.MinCapacity, &features, &bookedResourceID); err != nil {
+			log.Println("Error scanning event resource:", err)
+			return nil, err
+		}
+		if requirement.Features, err = decodeFeatures(features); err != nil {
+			return nil, err
+		}
+		requirement.ResourceID = resourceID.Int64
+		requirement.BookedResourceID = bookedResourceID.Int64
+		requirements = append(requirements, requirement)
+	}
+	return requirements, nil
+}
+
+// Reserve the resource for the requirement
+func (resourceRepo *resourceRepository) BookEventResource(ctx context.Context, tx *sql.Tx, requirementID int64, resourceID int64) error {
+	_, err := tx.ExecContext(ctx, `UPDATE event_resource SET booked_resource_id = ? WHERE id = ?`, resourceID, requirementID)
+	if err != nil {
+		log.Println("Error booking event resource:", err)
+		return err
+	}
+	return nil
+}
+
+// Release the resources booked for the requirements of the event
+func (resourceRepo *resourceRepository) ReleaseEventResources(ctx context.Context, tx *sql.Tx, eventID int64) error {
+	_, err := tx.ExecContext(ctx, `UPDATE event_resource SET booked_resource_id = NULL WHERE event_id = ?`, eventID)
+	if err != nil {
+		log.Println("Error releasing event resources:", err)
+		return err
+	}
+	return nil
+}
+
+// List the confirmed events that booked each resource and may overlap [from, to), keyed by resource. A recurring
+// event is returned whenever its series starts before to, the caller expands its occurrences
+func (resourceRepo *resourceRepository) ListBookedEvents(ctx context.Context, resourceIDs []int64, from time.Time, to time.Time) (map[int64][]model.Event, error) {
+	resourceEvents := make(map[int64][]model.Event)
+	if len(resourceIDs) == 0 {
+		return resourceEvents, nil
+	}
+	placeholders, args := inClause(resourceIDs)
+	query := `SELECT booking.resource_id, ` + eventColumns + ` FROM event_detail JOIN (
+		SELECT event_id, booked_resource_id AS resource_id FROM event_resource WHERE booked_resource_id IS NOT NULL
+	) AS booking ON booking.event_id = event_detail.id
+	WHERE status = ? AND booking.resource_id IN (` + placeholders + `) AND confirmed_start_time < ? AND (rrule <> '' OR confirmed_end_time > ?)
+	ORDER BY booking.resource_id, id`
+	args = append(append([]any{model.EventStatusConfirmed}, args...), to, from)
+	rows, err := resourceRepo.dbConn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Println("Error listing booked events:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var resourceID int64
+		event, err := scanEvent(scanFunc(func(dest ...any) error {
+			return rows.Scan(append([]any{&resourceID}, dest...)...)
+		}))
+		if err != nil {
+			log.Println("Error scanning event:", err)
+			return nil, err
+		}
+		resourceEvents[resourceID] = append(resourceEvents[resourceID], event)
+	}
+	return resourceEvents, nil
+}
+
+// scanResource reads a row of resourceColumns
+func scanResource(row interface{ Scan(dest ...any) error }) (model.Resource, error) {
+	var resource model.Resource
+	var features []byte
+	if err := row.Scan(&resource.ID, &resource.Name, &resource.Kind, &resource.Capacity, &resource.Location, &features, &resource.CreatedAt, &resource.UpdatedAt); err != nil {
+		return model.Resource{}, err
+	}
+	var err error
+	if resource.Features, err = decodeFeatures(features); err != nil {
+		return model.Resource{}, err
+	}
+	return resource, nil
+}
+
+// encodeFeatures returns the JSON array stored in the features columns
+func encodeFeatures(features []string) (string, error) {
+	if features == nil {
+		features = []string{}
+	}
+	encoded, err := json.Marshal(features)
+	if err != nil {
+		log.Println("Error encoding features:", err)
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// decodeFeatures reads a features column, nil when the array is empty
+func decodeFeatures(encoded []byte) ([]string, error) {
+	var features []string
+	if err := json.Unmarshal(encoded, &features); err != nil {
+		log.Println("Error decoding features:", err)
+		return nil, err
+	}
+	if len(features) == 0 {
+		return nil, nil
+	}
+	return features, nil
+}
@@ -0,0 +1,371 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/stretchr/testify/assert"
+)
+
+var resourceRowColumns = []string{"id", "name", "kind", "capacity", "location", "features", "created_at", "updated_at"}
+
+func TestInsertResource(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	repository := NewResourceRepository(db)
+	ctx := context.Background()
+	resource := model.Resource{Name: "Room 4.12", Kind: model.ResourceKindRoom, Capacity: 8, Location: "Building 4", Features: []string{"projector"}}
+	query := `INSERT INTO resource (name, kind, capacity, location, features) VALUES (?, ?, ?, ?, ?)`
+
+	t.Run("Function must return an error when the write operation fails", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs("Room 4.12", model.ResourceKindRoom, 8, "Building 4", `["projector"]`).
+			WillReturnError(assert.AnError)
+
+		_, err := repository.InsertResource(ctx, tx, resource)
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must store a resource without features as an empty array", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs("Beamer", model.ResourceKindEquipment, 0, "", `[]`).
+			WillReturnResult(sqlmock.NewResult(3, 1))
+
+		resourceID, err := repository.InsertResource(ctx, tx, model.Resource{Name: "Beamer", Kind: model.ResourceKindEquipment})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), resourceID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeleteResource(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	repository := NewResourceRepository(db)
+	ctx := context.Background()
+	query := `DELETE FROM resource WHERE id = ?`
+
+	t.Run("Function must return a not found error when the resource does not exist", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(3).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repository.DeleteResource(ctx, tx, 3)
+		assert.ErrorIs(t, err, model.ErrNotFound)
+	})
+
+	t.Run("Function must return a conflict error when a requirement names the resource", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(3).
+			WillReturnError(&mysql.MySQLError{Number: 1451, Message: "Cannot delete or update a parent row: a foreign key constraint fails"})
+
+		err := repository.DeleteResource(ctx, tx, 3)
+		assert.ErrorIs(t, err, model.ErrConflict)
+	})
+
+	t.Run("Function must return nil when the delete operation is successful", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(3).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repository.DeleteResource(ctx, tx, 3)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetResource(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	repository := NewResourceRepository(db)
+	ctx := context.Background()
+	createdAt := time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC)
+	query := `SELECT id, name, kind, capacity, location, features, created_at, updated_at FROM resource WHERE id = ?`
+
+	t.Run("Function must return a not found error when the resource does not exist", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(3).
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := repository.GetResource(ctx, 3)
+		assert.ErrorIs(t, err, model.ErrNotFound)
+	})
+
+	t.Run("Function must return the resource with its features", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows(resourceRowColumns).
+				AddRow(3, "Room 4.12", "room", 8, "Building 4", []byte(`["projector","whiteboard"]`), createdAt, createdAt))
+
+		resource, err := repository.GetResource(ctx, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, model.Resource{ID: 3, Name: "Room 4.12", Kind: "room", Capacity: 8, Location: "Building 4", Features: []string{"projector", "whiteboard"}, CreatedAt: createdAt, UpdatedAt: createdAt}, resource)
+	})
+}
+
+func TestListResources(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	repository := NewResourceRepository(db)
+	ctx := context.Background()
+	createdAt := time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC)
+
+	t.Run("Function must return an error when the read operation fails", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`FROM resource WHERE capacity >= ? ORDER BY capacity ASC, id ASC`)).
+			WithArgs(0).
+			WillReturnError(assert.AnError)
+
+		_, err := repository.ListResources(ctx, model.ResourceFilter{})
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must filter by every field that is set", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`FROM resource WHERE capacity >= ? AND kind = ? AND location = ? AND JSON_CONTAINS(features, ?) ORDER BY capacity ASC, id ASC`)).
+			WithArgs(6, "room", "Building 4", `["projector"]`).
+			WillReturnRows(sqlmock.NewRows(resourceRowColumns).
+				AddRow(3, "Room 4.12", "room", 8, "Building 4", []byte(`["projector"]`), createdAt, createdAt).
+				AddRow(5, "Room 4.20", "room", 12, "Building 4", []byte(`["projector","whiteboard"]`), createdAt, createdAt))
+
+		resources, err := repository.ListResources(ctx, model.ResourceFilter{Kind: "room", MinCapacity: 6, Location: "Building 4", Features: []string{"projector"}})
+		assert.NoError(t, err)
+		assert.Len(t, resources, 2)
+		assert.Equal(t, int64(3), resources[0].ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestLockResources(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	repository := NewResourceRepository(db)
+	ctx := context.Background()
+	query := `SELECT id FROM resource WHERE id IN (?, ?) ORDER BY id FOR UPDATE`
+
+	t.Run("Function must not query the database when no resource is given", func(t *testing.T) {
+		assert.NoError(t, repository.LockResources(ctx, tx, nil))
+	})
+
+	t.Run("Function must return a conflict error when the lock cannot be acquired", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(int64(3), int64(5)).
+			WillReturnError(&mysql.MySQLError{Number: 1205, Message: "Lock wait timeout exceeded; try restarting transaction"})
+
+		err := repository.LockResources(ctx, tx, []int64{3, 5})
+		assert.ErrorIs(t, err, model.ErrConflict)
+	})
+
+	t.Run("Function must lock the resources", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(int64(3), int64(5)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(5))
+
+		err := repository.LockResources(ctx, tx, []int64{3, 5})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReplaceResourceAvailability(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	repository := NewResourceRepository(db)
+	ctx := context.Background()
+	window := model.EventSlot{StartTime: time.Date(2025, 07, 14, 8, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 14, 18, 0, 0, 0, time.UTC), TimeZone: "UTC"}
+	deleteQuery := `DELETE FROM resource_availability WHERE resource_id = ?`
+	insertQuery := `INSERT INTO resource_availability (resource_id, start_time, end_time, time_zone) VALUES (?, ?, ?, ?)`
+
+	t.Run("Function must return a not found error when the resource does not exist", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(deleteQuery)).WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(insertQuery)).
+			WithArgs(3, window.StartTime, window.EndTime, "UTC").
+			WillReturnError(&mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row"})
+
+		err := repository.ReplaceResourceAvailability(ctx, tx, 3, []model.EventSlot{window})
+		assert.ErrorIs(t, err, model.ErrNotFound)
+	})
+
+	t.Run("Function must replace the windows of the resource", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(deleteQuery)).WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(regexp.QuoteMeta(insertQuery)).
+			WithArgs(3, window.StartTime, window.EndTime, "UTC").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := repository.ReplaceResourceAvailability(ctx, tx, 3, []model.EventSlot{window})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestInsertEventResource(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	repository := NewResourceRepository(db)
+	ctx := context.Background()
+	query := `INSERT INTO event_resource (event_id, resource_id, kind, min_capacity, features) VALUES (?, ?, ?, ?, ?)`
+
+	t.Run("Function must return a not found error when the resource does not exist", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(1, int64(9), "", 0, `[]`).
+			WillReturnError(&mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row"})
+
+		_, err := repository.InsertEventResource(ctx, tx, 1, model.ResourceRequirement{ResourceID: 9})
+		assert.ErrorIs(t, err, model.ErrNotFound)
+	})
+
+	t.Run("Function must store a requirement without a resource as NULL", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(1, nil, "room", 6, `["projector"]`).
+			WillReturnResult(sqlmock.NewResult(4, 1))
+
+		requirementID, err := repository.InsertEventResource(ctx, tx, 1, model.ResourceRequirement{Kind: "room", MinCapacity: 6, Features: []string{"projector"}})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), requirementID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetEventResources(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	repository := NewResourceRepository(db)
+	ctx := context.Background()
+	query := `SELECT id, resource_id, kind, min_capacity, features, booked_resource_id FROM event_resource WHERE event_id = ? ORDER BY id ASC`
+
+	t.Run("Function must return an error when the read operation fails", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1).WillReturnError(assert.AnError)
+
+		_, err := repository.GetEventResources(ctx, 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must return the requirements with the booked resources", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "resource_id", "kind", "min_capacity", "features", "booked_resource_id"}).
+				AddRow(4, nil, "room", 6, []byte(`["projector"]`), 3).
+				AddRow(5, 9, "", 0, []byte(`[]`), nil))
+
+		requirements, err := repository.GetEventResources(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []model.ResourceRequirement{
+			{ID: 4, Kind: "room", MinCapacity: 6, Features: []string{"projector"}, BookedResourceID: 3},
+			{ID: 5, ResourceID: 9},
+		}, requirements)
+	})
+}
+
+func TestReleaseEventResources(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	repository := NewResourceRepository(db)
+	ctx := context.Background()
+	query := `UPDATE event_resource SET booked_resource_id = NULL WHERE event_id = ?`
+
+	t.Run("Function must return an error when the update operation fails", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(1).
+			WillReturnError(assert.AnError)
+
+		err := repository.ReleaseEventResources(ctx, tx, 1)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("Function must release every booking of the event", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := repository.ReleaseEventResources(ctx, tx, 1)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestListBookedEvents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	repository := NewResourceRepository(db)
+	ctx := context.Background()
+	createdAt := time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC)
+	confirmedAt := time.Date(2025, 07, 20, 9, 0, 0, 0, time.UTC)
+	from := time.Date(2025, 07, 14, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 07, 28, 0, 0, 0, 0, time.UTC)
+	columns := []string{"resource_id", "id", "title", "organizer_id", "duration_minutes", "time_zone", "rrule", "status", "confirmed_start_time", "confirmed_end_time", "created_at", "updated_at"}
+	query := `AS booking ON booking.event_id = event_detail.id
+	WHERE status = ? AND booking.resource_id IN (?) AND confirmed_start_time < ? AND (rrule <> '' OR confirmed_end_time > ?)`
+
+	t.Run("Function must not query the database when no resource is given", func(t *testing.T) {
+		resourceEvents, err := repository.ListBookedEvents(ctx, nil, from, to)
+		assert.NoError(t, err)
+		assert.Empty(t, resourceEvents)
+	})
+
+	t.Run("Function must return the confirmed events that booked each resource", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(model.EventStatusConfirmed, int64(3), to, from).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(3, 4, "Planning", 3, 60, "UTC", "", "confirmed", confirmedAt, confirmedAt.Add(time.Hour), createdAt, createdAt))
+
+		resourceEvents, err := repository.ListBookedEvents(ctx, []int64{3}, from, to)
+		assert.NoError(t, err)
+		assert.Len(t, resourceEvents[3], 1)
+		assert.Equal(t, confirmedAt, resourceEvents[3][0].ConfirmedSlot.StartTime)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -4,11 +4,15 @@ import (
 	"context"
 	"database/sql"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/rahulshewale153/meeting-scheduler-api/model"
 )
 
+// insertBatchSize is how many rows a batched insert writes per statement
+const insertBatchSize = 500
+
 type userAvailabilityRepository struct {
 	dbConn *sql.DB
 }
@@ -18,11 +22,15 @@ func NewUserAvailabilityRepository(dbConn *sql.DB) UserAvailabilityRepositoryI {
 }
 
 // InsertUserAvailability: inserts a new user availability record into the database.
-func (userRepo *userAvailabilityRepository) InsertUserAvailability(ctx context.Context, tx *sql.Tx, userID int64, eventID int64, startTime time.Time, endTime time.Time) (int64, error) {
-	query := `INSERT INTO user_availability (event_id, user_id, start_time, end_time) VALUES (?, ?, ?, ?)`
-	result, err := tx.ExecContext(ctx, query, eventID, userID, startTime, endTime)
+func (userRepo *userAvailabilityRepository) InsertUserAvailability(ctx context.Context, tx *sql.Tx, userID int64, eventID int64, startTime time.Time, endTime time.Time, timeZone string) (int64, error) {
+	query := `INSERT INTO user_availability (event_id, user_id, start_time, end_time, time_zone) VALUES (?, ?, ?, ?, ?)`
+	result, err := tx.ExecContext(ctx, query, eventID, userID, startTime, endTime, timeZone)
 	if err != nil {
 		log.Printf("Error inserting user availability: %v", err)
+		// foreign key violation, the referenced event does not exist
+		if isMySQLError(err, mysqlErrNoReferencedRow) {
+			return 0, &model.NotFoundError{Resource: "event", ID: eventID}
+		}
 		return 0, err
 	}
 
@@ -35,10 +43,79 @@ func (userRepo *userAvailabilityRepository) InsertUserAvailability(ctx context.C
 	return lastInsertID, nil
 }
 
+// InsertUserAvailabilityBatch: inserts the slots and rules of many users for an event with multi-row statements.
+// Every slot and rule must carry its time zone.
+func (userRepo *userAvailabilityRepository) InsertUserAvailabilityBatch(ctx context.Context, tx *sql.Tx, eventID int64, items []model.UserAvailability) error {
+	slotRows := [][]any{}
+	ruleRows := [][]any{}
+	for _, item := range items {
+		for _, slot := range item.Availability {
+			slotRows = append(slotRows, []any{eventID, item.UserID, slot.StartTime, slot.EndTime, slot.TimeZone})
+		}
+		for _, rule := range item.Rules {
+			ruleRows = append(ruleRows, []any{eventID, item.UserID, rule.StartTime, rule.EndTime, rule.TimeZone, rule.RRule})
+		}
+	}
+
+	if err := insertRows(ctx, tx, `INSERT INTO user_availability (event_id, user_id, start_time, end_time, time_zone) VALUES `, slotRows); err != nil {
+		log.Printf("Error inserting user availability batch: %v", err)
+		if isMySQLError(err, mysqlErrNoReferencedRow) {
+			return &model.NotFoundError{Resource: "event", ID: eventID}
+		}
+		return err
+	}
+	if err := insertRows(ctx, tx, `INSERT INTO user_availability_rule (event_id, user_id, start_time, end_time, time_zone, rrule) VALUES `, ruleRows); err != nil {
+		log.Printf("Error inserting availability rule batch: %v", err)
+		if isMySQLError(err, mysqlErrNoReferencedRow) {
+			return &model.NotFoundError{Resource: "event", ID: eventID}
+		}
+		return err
+	}
+	return nil
+}
+
+// insertRows runs the INSERT ... VALUES statement for the rows, insertBatchSize rows at a time
+func insertRows(ctx context.Context, tx *sql.Tx, statement string, rows [][]any) error {
+	for start := 0; start < len(rows); start += insertBatchSize {
+		end := min(start+insertBatchSize, len(rows))
+		values := make([]string, 0, end-start)
+		args := []any{}
+		for _, row := range rows[start:end] {
+			values = append(values, "("+strings.TrimSuffix(strings.Repeat("?, ", len(row)), ", ")+")")
+			args = append(args, row...)
+		}
+		if _, err := tx.ExecContext(ctx, statement+strings.Join(values, ", "), args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReplaceSyncedAvailability: replaces the availability synced from the calendar of the user, submitted availability is kept.
+func (userRepo *userAvailabilityRepository) ReplaceSyncedAvailability(ctx context.Context, tx *sql.Tx, userID int64, eventID int64, slots []model.EventSlot) error {
+	_, err := tx.ExecContext(ctx, `DELETE FROM user_availability WHERE event_id = ? AND user_id = ? AND source = ?`, eventID, userID, model.AvailabilitySourceCalDAV)
+	if err != nil {
+		log.Printf("Error deleting synced availability: %v", err)
+		return err
+	}
+
+	query := `INSERT INTO user_availability (event_id, user_id, start_time, end_time, time_zone, source) VALUES (?, ?, ?, ?, ?, ?)`
+	for _, slot := range slots {
+		if _, err := tx.ExecContext(ctx, query, eventID, userID, slot.StartTime, slot.EndTime, slot.TimeZone, model.AvailabilitySourceCalDAV); err != nil {
+			log.Printf("Error inserting synced availability: %v", err)
+			if isMySQLError(err, mysqlErrNoReferencedRow) {
+				return &model.NotFoundError{Resource: "event", ID: eventID}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
 // GetEventUsers: retrieves the availability of users for a specific event.
 func (userRepo *userAvailabilityRepository) GetAllEventUsers(ctx context.Context, eventID int64) (map[int64][]model.EventSlot, error) {
 	eventUsers := make(map[int64][]model.EventSlot)
-	query := `SELECT id, user_id, start_time, end_time FROM user_availability WHERE event_id = ? order by user_id ASC`
+	query := `SELECT id, user_id, start_time, end_time, time_zone FROM user_availability WHERE event_id = ? order by user_id ASC`
 	rows, err := userRepo.dbConn.QueryContext(ctx, query, eventID)
 	if err != nil {
 		log.Printf("Error retrieving user availability: %v", err)
@@ -49,7 +126,7 @@ func (userRepo *userAvailabilityRepository) GetAllEventUsers(ctx context.Context
 	for rows.Next() {
 		var userID int64
 		var selectedSlot model.EventSlot
-		if err := rows.Scan(&selectedSlot.ID, &userID, &selectedSlot.StartTime, &selectedSlot.EndTime); err != nil {
+		if err := rows.Scan(&selectedSlot.ID, &userID, &selectedSlot.StartTime, &selectedSlot.EndTime, &selectedSlot.TimeZone); err != nil {
 			log.Printf("Error scanning user availability: %v", err)
 			return eventUsers, err
 		}
@@ -70,11 +147,33 @@ func (userRepo *userAvailabilityRepository) DeleteUserAvailability(ctx context.C
 	return nil
 }
 
+// DeleteUserAvailabilitySlot: deletes one availability slot of a user for an event.
+func (userRepo *userAvailabilityRepository) DeleteUserAvailabilitySlot(ctx context.Context, tx *sql.Tx, userID int64, eventID int64, slotID int64) error {
+	query := `DELETE FROM user_availability WHERE id = ? AND user_id = ? AND event_id = ?`
+	_, err := tx.ExecContext(ctx, query, slotID, userID, eventID)
+	if err != nil {
+		log.Printf("Error deleting user availability slot: %v", err)
+		return err
+	}
+	return nil
+}
+
 // GetUserAvailability: retrieves the availability of specific user for a specific event.
 func (userRepo *userAvailabilityRepository) GetUserAvailability(ctx context.Context, eventID int64, userID int64) ([]model.EventSlot, error) {
+	query := `SELECT id, start_time, end_time, time_zone FROM user_availability WHERE event_id = ? AND user_id = ?`
+	return userRepo.queryUserSlots(ctx, query, eventID, userID)
+}
+
+// GetSubmittedAvailability: retrieves the availability a user submitted for an event, leaving out what was synced.
+func (userRepo *userAvailabilityRepository) GetSubmittedAvailability(ctx context.Context, eventID int64, userID int64) ([]model.EventSlot, error) {
+	query := `SELECT id, start_time, end_time, time_zone FROM user_availability WHERE event_id = ? AND user_id = ? AND source = ?`
+	return userRepo.queryUserSlots(ctx, query, eventID, userID, model.AvailabilitySourceManual)
+}
+
+// queryUserSlots reads the id, start_time, end_time and time_zone rows of the query as slots
+func (userRepo *userAvailabilityRepository) queryUserSlots(ctx context.Context, query string, args ...any) ([]model.EventSlot, error) {
 	slots := []model.EventSlot{}
-	query := `SELECT id, start_time, end_time FROM user_availability WHERE event_id = ? AND user_id = ?`
-	rows, err := userRepo.dbConn.QueryContext(ctx, query, eventID, userID)
+	rows, err := userRepo.dbConn.QueryContext(ctx, query, args...)
 	if err != nil {
 		log.Printf("Error retrieving user availability: %v", err)
 		return slots, err
@@ -83,7 +182,7 @@ func (userRepo *userAvailabilityRepository) GetUserAvailability(ctx context.Cont
 
 	for rows.Next() {
 		var slot model.EventSlot
-		if err := rows.Scan(&slot.ID, &slot.StartTime, &slot.EndTime); err != nil {
+		if err := rows.Scan(&slot.ID, &slot.StartTime, &slot.EndTime, &slot.TimeZone); err != nil {
 			log.Printf("Error scanning user availability: %v", err)
 			return slots, err
 		}
@@ -92,3 +191,188 @@ func (userRepo *userAvailabilityRepository) GetUserAvailability(ctx context.Cont
 
 	return slots, nil
 }
+
+// InsertAvailabilityRule: stores a recurring availability rule of a user for an event.
+func (userRepo *userAvailabilityRepository) InsertAvailabilityRule(ctx context.Context, tx *sql.Tx, userID int64, eventID int64, rule model.AvailabilityRule) (int64, error) {
+	query := `INSERT INTO user_availability_rule (event_id, user_id, start_time, end_time, time_zone, rrule) VALUES (?, ?, ?, ?, ?, ?)`
+	result, err := tx.ExecContext(ctx, query, eventID, userID, rule.StartTime, rule.EndTime, rule.TimeZone, rule.RRule)
+	if err != nil {
+		log.Printf("Error inserting availability rule: %v", err)
+		// foreign key violation, the referenced event does not exist
+		if isMySQLError(err, mysqlErrNoReferencedRow) {
+			return 0, &model.NotFoundError{Resource: "event", ID: eventID}
+		}
+		return 0, err
+	}
+
+	lastInsertID, err := result.LastInsertId()
+	if err != nil {
+		log.Printf("Error retrieving last insert ID: %v", err)
+		return 0, err
+	}
+	return lastInsertID, nil
+}
+
+// DeleteAvailabilityRules: deletes the recurring availability rules of a user for an event.
+func (userRepo *userAvailabilityRepository) DeleteAvailabilityRules(ctx context.Context, tx *sql.Tx, userID int64, eventID int64) error {
+	query := `DELETE FROM user_availability_rule WHERE event_id = ? AND user_id = ?`
+	_, err := tx.ExecContext(ctx, query, eventID, userID)
+	if err != nil {
+		log.Printf("Error deleting availability rules: %v", err)
+		return err
+	}
+	return nil
+}
+
+// GetAvailabilityRules: retrieves the recurring availability rules of a specific user for a specific event.
+func (userRepo *userAvailabilityRepository) GetAvailabilityRules(ctx context.Context, eventID int64, userID int64) ([]model.AvailabilityRule, error) {
+	rules := []model.AvailabilityRule{}
+	query := `SELECT id, start_time, end_time, time_zone, rrule FROM user_availability_rule WHERE event_id = ? AND user_id = ?`
+	rows, err := userRepo.dbConn.QueryContext(ctx, query, eventID, userID)
+	if err != nil {
+		log.Printf("Error retrieving availability rules: %v", err)
+		return rules, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rule model.AvailabilityRule
+		if err := rows.Scan(&rule.ID, &rule.StartTime, &rule.EndTime, &rule.TimeZone, &rule.RRule); err != nil {
+			log.Printf("Error scanning availability rule: %v", err)
+			return rules, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// GetAllEventRules: retrieves the recurring availability rules of every user of an event keyed by user id.
+func (userRepo *userAvailabilityRepository) GetAllEventRules(ctx context.Context, eventID int64) (map[int64][]model.AvailabilityRule, error) {
+	eventRules := make(map[int64][]model.AvailabilityRule)
+	query := `SELECT id, user_id, start_time, end_time, time_zone, rrule FROM user_availability_rule WHERE event_id = ? order by user_id ASC`
+	rows, err := userRepo.dbConn.QueryContext(ctx, query, eventID)
+	if err != nil {
+		log.Printf("Error retrieving availability rules: %v", err)
+		return eventRules, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID int64
+		var rule model.AvailabilityRule
+		if err := rows.Scan(&rule.ID, &userID, &rule.StartTime, &rule.EndTime, &rule.TimeZone, &rule.RRule); err != nil {
+			log.Printf("Error scanning availability rule: %v", err)
+			return eventRules, err
+		}
+		eventRules[userID] = append(eventRules[userID], rule)
+	}
+	return eventRules, nil
+}
+
+// ListUserEventAvailability: lists the events a user is invited to or responded to, ordered by id so that the last
+// id can be used as the next cursor, with the slots and rules the user submitted for each.
+func (userRepo *userAvailabilityRepository) ListUserEventAvailability(ctx context.Context, userID int64, filter model.UserEventFilter) ([]model.UserEventAvailability, error) {
+	query := `SELECT ` + eventColumns + `, invitation.event_id IS NOT NULL, COALESCE(invitation.is_required, 0) FROM event_detail
+	LEFT JOIN (SELECT event_id, is_required FROM event_attendee WHERE user_id = ?) AS invitation ON invitation.event_id = event_detail.id
+	WHERE id > ? AND (invitation.event_id IS NOT NULL
+		OR id IN (SELECT event_id FROM user_availability WHERE user_id = ?)
+		OR id IN (SELECT event_id FROM user_availability_rule WHERE user_id = ?))`
+	args := []any{userID, filter.AfterID, userID, userID}
+	if filter.Status != "" {
+		query += ` AND status = ?`
+		args = append(args, filter.Status)
+	}
+	query += ` ORDER BY id ASC LIMIT ?`
+	args = append(args, filter.Limit)
+
+	rows, err := userRepo.dbConn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Error listing user events: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	userEvents := []model.UserEventAvailability{}
+	eventIDs := []int64{}
+	for rows.Next() {
+		userEvent := model.UserEventAvailability{Availability: []model.EventSlot{}, Rules: []model.AvailabilityRule{}}
+		userEvent.Event, err = scanEvent(scanFunc(func(dest ...any) error {
+			return rows.Scan(append(dest, &userEvent.Invited, &userEvent.Required)...)
+		}))
+		if err != nil {
+			log.Printf("Error scanning user event: %v", err)
+			return nil, err
+		}
+		userEvents = append(userEvents, userEvent)
+		eventIDs = append(eventIDs, userEvent.Event.ID)
+	}
+	if len(eventIDs) == 0 {
+		return userEvents, nil
+	}
+
+	slots, err := userRepo.getUserSlots(ctx, userID, eventIDs)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := userRepo.getUserRules(ctx, userID, eventIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range userEvents {
+		if eventSlots, ok := slots[userEvents[i].Event.ID]; ok {
+			userEvents[i].Availability = eventSlots
+		}
+		if eventRules, ok := rules[userEvents[i].Event.ID]; ok {
+			userEvents[i].Rules = eventRules
+		}
+	}
+	return userEvents, nil
+}
+
+// getUserSlots: retrieves the availability slots of a user for the given events keyed by event id.
+func (userRepo *userAvailabilityRepository) getUserSlots(ctx context.Context, userID int64, eventIDs []int64) (map[int64][]model.EventSlot, error) {
+	placeholders, args := inClause(eventIDs)
+	query := `SELECT event_id, id, start_time, end_time, time_zone FROM user_availability WHERE user_id = ? AND event_id IN (` + placeholders + `) ORDER BY event_id, start_time`
+	rows, err := userRepo.dbConn.QueryContext(ctx, query, append([]any{userID}, args...)...)
+	if err != nil {
+		log.Printf("Error retrieving user availability: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	eventSlots := make(map[int64][]model.EventSlot)
+	for rows.Next() {
+		var eventID int64
+		var slot model.EventSlot
+		if err := rows.Scan(&eventID, &slot.ID, &slot.StartTime, &slot.EndTime, &slot.TimeZone); err != nil {
+			log.Printf("Error scanning user availability: %v", err)
+			return nil, err
+		}
+		eventSlots[eventID] = append(eventSlots[eventID], slot)
+	}
+	return eventSlots, nil
+}
+
+// getUserRules: retrieves the recurring availability rules of a user for the given events keyed by event id.
+func (userRepo *userAvailabilityRepository) getUserRules(ctx context.Context, userID int64, eventIDs []int64) (map[int64][]model.AvailabilityRule, error) {
+	placeholders, args := inClause(eventIDs)
+	query := `SELECT event_id, id, start_time, end_time, time_zone, rrule FROM user_availability_rule WHERE user_id = ? AND event_id IN (` + placeholders + `) ORDER BY event_id, id`
+	rows, err := userRepo.dbConn.QueryContext(ctx, query, append([]any{userID}, args...)...)
+	if err != nil {
+		log.Printf("Error retrieving availability rules: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	eventRules := make(map[int64][]model.AvailabilityRule)
+	for rows.Next() {
+		var eventID int64
+		var rule model.AvailabilityRule
+		if err := rows.Scan(&eventID, &rule.ID, &rule.StartTime, &rule.EndTime, &rule.TimeZone, &rule.RRule); err != nil {
+			log.Printf("Error scanning availability rule: %v", err)
+			return nil, err
+		}
+		eventRules[eventID] = append(eventRules[eventID], rule)
+	}
+	return eventRules, nil
+}
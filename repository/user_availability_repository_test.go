@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
 	"github.com/rahulshewale153/meeting-scheduler-api/model"
 	"github.com/stretchr/testify/assert"
 )
@@ -35,22 +36,31 @@ func TestInsertUserAvailability(t *testing.T) {
 		},
 	}
 
-	query := `INSERT INTO user_availability (event_id, user_id, start_time, end_time) VALUES (?, ?, ?, ?)`
+	query := `INSERT INTO user_availability (event_id, user_id, start_time, end_time, time_zone) VALUES (?, ?, ?, ?, ?)`
 	t.Run("Function must return an error when the write operation fails", func(t *testing.T) {
 		mock.ExpectExec(regexp.QuoteMeta(query)).
-			WithArgs(createUserAvailabilityReq.EventID, createUserAvailabilityReq.UserID, createUserAvailabilityReq.Availability[0].StartTime, createUserAvailabilityReq.Availability[0].EndTime).
+			WithArgs(createUserAvailabilityReq.EventID, createUserAvailabilityReq.UserID, createUserAvailabilityReq.Availability[0].StartTime, createUserAvailabilityReq.Availability[0].EndTime, "Asia/Kolkata").
 			WillReturnError(assert.AnError)
 
-		_, err := repository.InsertUserAvailability(ctx, tx, createUserAvailabilityReq.UserID, createUserAvailabilityReq.EventID, createUserAvailabilityReq.Availability[0].StartTime, createUserAvailabilityReq.Availability[0].EndTime)
+		_, err := repository.InsertUserAvailability(ctx, tx, createUserAvailabilityReq.UserID, createUserAvailabilityReq.EventID, createUserAvailabilityReq.Availability[0].StartTime, createUserAvailabilityReq.Availability[0].EndTime, "Asia/Kolkata")
 		assert.Error(t, err)
 	})
 
+	t.Run("Function must return a not found error when the event does not exist", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(createUserAvailabilityReq.EventID, createUserAvailabilityReq.UserID, createUserAvailabilityReq.Availability[0].StartTime, createUserAvailabilityReq.Availability[0].EndTime, "Asia/Kolkata").
+			WillReturnError(&mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails"})
+
+		_, err := repository.InsertUserAvailability(ctx, tx, createUserAvailabilityReq.UserID, createUserAvailabilityReq.EventID, createUserAvailabilityReq.Availability[0].StartTime, createUserAvailabilityReq.Availability[0].EndTime, "Asia/Kolkata")
+		assert.ErrorIs(t, err, model.ErrNotFound)
+	})
+
 	t.Run("Function must return the last inserted ID when the insert operation is successful", func(t *testing.T) {
 		mock.ExpectExec(regexp.QuoteMeta(query)).
-			WithArgs(createUserAvailabilityReq.EventID, createUserAvailabilityReq.UserID, createUserAvailabilityReq.Availability[0].StartTime, createUserAvailabilityReq.Availability[0].EndTime).
+			WithArgs(createUserAvailabilityReq.EventID, createUserAvailabilityReq.UserID, createUserAvailabilityReq.Availability[0].StartTime, createUserAvailabilityReq.Availability[0].EndTime, "Asia/Kolkata").
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
-		lastInsertID, err := repository.InsertUserAvailability(ctx, tx, createUserAvailabilityReq.UserID, createUserAvailabilityReq.EventID, createUserAvailabilityReq.Availability[0].StartTime, createUserAvailabilityReq.Availability[0].EndTime)
+		lastInsertID, err := repository.InsertUserAvailability(ctx, tx, createUserAvailabilityReq.UserID, createUserAvailabilityReq.EventID, createUserAvailabilityReq.Availability[0].StartTime, createUserAvailabilityReq.Availability[0].EndTime, "Asia/Kolkata")
 		assert.NoError(t, err)
 		assert.Equal(t, int64(1), lastInsertID)
 	})
@@ -69,7 +79,7 @@ func TestGetAllEventUsers(t *testing.T) {
 	startTime := time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC)
 	endTime := time.Date(2025, 07, 13, 11, 0, 0, 0, time.UTC)
 
-	query := `SELECT id, user_id, start_time, end_time FROM user_availability WHERE event_id = ?`
+	query := `SELECT id, user_id, start_time, end_time, time_zone FROM user_availability WHERE event_id = ?`
 	t.Run("Function must return an error when the read operation fails", func(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(query)).
 			WithArgs(eventID).
@@ -80,9 +90,9 @@ func TestGetAllEventUsers(t *testing.T) {
 	})
 
 	t.Run("Function must return a map of user IDs and their availability when the read operation is successful", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "user_id", "start_time", "end_time"}).
-			AddRow(1, 1, startTime, endTime).
-			AddRow(2, 2, startTime, endTime)
+		rows := sqlmock.NewRows([]string{"id", "user_id", "start_time", "end_time", "time_zone"}).
+			AddRow(1, 1, startTime, endTime, "UTC").
+			AddRow(2, 2, startTime, endTime, "Asia/Kolkata")
 
 		mock.ExpectQuery(regexp.QuoteMeta(query)).
 			WithArgs(eventID).
@@ -91,8 +101,8 @@ func TestGetAllEventUsers(t *testing.T) {
 		eventUsers, err := repository.GetAllEventUsers(ctx, eventID)
 		assert.NoError(t, err)
 		assert.Len(t, eventUsers, 2)
-		assert.Contains(t, eventUsers[1], model.EventSlot{ID: 1, StartTime: time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 13, 11, 0, 0, 0, time.UTC)})
-		assert.Contains(t, eventUsers[2], model.EventSlot{ID: 2, StartTime: time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 13, 11, 0, 0, 0, time.UTC)})
+		assert.Contains(t, eventUsers[1], model.EventSlot{ID: 1, StartTime: time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 13, 11, 0, 0, 0, time.UTC), TimeZone: "UTC"})
+		assert.Contains(t, eventUsers[2], model.EventSlot{ID: 2, StartTime: time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 13, 11, 0, 0, 0, time.UTC), TimeZone: "Asia/Kolkata"})
 	})
 }
 
@@ -133,6 +143,40 @@ func TestDeleteUserAvailability(t *testing.T) {
 
 }
 
+func TestDeleteUserAvailabilitySlot(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	repository := NewUserAvailabilityRepository(db)
+	ctx := context.Background()
+
+	query := `DELETE FROM user_availability WHERE id = ? AND user_id = ? AND event_id = ?`
+	t.Run("Function must return an error when the delete operation fails", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(7, 1, 2).
+			WillReturnError(assert.AnError)
+
+		err := repository.DeleteUserAvailabilitySlot(ctx, tx, 1, 2, 7)
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must delete only the slot of the user for the event", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(7, 1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repository.DeleteUserAvailabilitySlot(ctx, tx, 1, 2, 7)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestGetUserAvailability(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.Nil(t, err)
@@ -146,7 +190,7 @@ func TestGetUserAvailability(t *testing.T) {
 	startTime := time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC)
 	endTime := time.Date(2025, 07, 13, 11, 0, 0, 0, time.UTC)
 
-	query := `SELECT id, start_time, end_time FROM user_availability WHERE event_id = ? AND user_id = ?`
+	query := `SELECT id, start_time, end_time, time_zone FROM user_availability WHERE event_id = ? AND user_id = ?`
 	t.Run("Function must return an error when the read operation fails", func(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(query)).
 			WithArgs(eventID, userID).
@@ -157,9 +201,9 @@ func TestGetUserAvailability(t *testing.T) {
 	})
 
 	t.Run("Function must return a slice of EventSlot when the read operation is successful", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "start_time", "end_time"}).
-			AddRow(1, startTime, endTime).
-			AddRow(2, startTime, endTime)
+		rows := sqlmock.NewRows([]string{"id", "start_time", "end_time", "time_zone"}).
+			AddRow(1, startTime, endTime, "Asia/Kolkata").
+			AddRow(2, startTime, endTime, "Asia/Kolkata")
 
 		mock.ExpectQuery(regexp.QuoteMeta(query)).
 			WithArgs(eventID, userID).
@@ -170,5 +214,362 @@ func TestGetUserAvailability(t *testing.T) {
 		assert.Len(t, slots, 2)
 		assert.Equal(t, slots[0].StartTime, time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC))
 		assert.Equal(t, slots[0].EndTime, time.Date(2025, 07, 13, 11, 0, 0, 0, time.UTC))
+		assert.Equal(t, "Asia/Kolkata", slots[0].TimeZone)
+	})
+}
+
+func TestGetSubmittedAvailability(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	repository := NewUserAvailabilityRepository(db)
+	ctx := context.Background()
+	startTime := time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC)
+
+	query := `SELECT id, start_time, end_time, time_zone FROM user_availability WHERE event_id = ? AND user_id = ? AND source = ?`
+	t.Run("Function must return an error when the read operation fails", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(1, 2, model.AvailabilitySourceManual).
+			WillReturnError(assert.AnError)
+
+		_, err := repository.GetSubmittedAvailability(ctx, 1, 2)
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must return only the slots the user submitted", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(1, 2, model.AvailabilitySourceManual).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "start_time", "end_time", "time_zone"}).
+				AddRow(4, startTime, startTime.Add(time.Hour), "UTC"))
+
+		slots, err := repository.GetSubmittedAvailability(ctx, 1, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []model.EventSlot{{ID: 4, StartTime: startTime, EndTime: startTime.Add(time.Hour), TimeZone: "UTC"}}, slots)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestInsertAvailabilityRule(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	repository := NewUserAvailabilityRepository(db)
+	ctx := context.Background()
+	rule := model.AvailabilityRule{
+		StartTime: time.Date(2025, 07, 14, 4, 30, 0, 0, time.UTC),
+		EndTime:   time.Date(2025, 07, 14, 6, 30, 0, 0, time.UTC),
+		TimeZone:  "Asia/Kolkata",
+		RRule:     "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+	}
+
+	query := `INSERT INTO user_availability_rule (event_id, user_id, start_time, end_time, time_zone, rrule) VALUES (?, ?, ?, ?, ?, ?)`
+	t.Run("Function must return a not found error when the event does not exist", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(2, 1, rule.StartTime, rule.EndTime, rule.TimeZone, rule.RRule).
+			WillReturnError(&mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row"})
+
+		_, err := repository.InsertAvailabilityRule(ctx, tx, 1, 2, rule)
+		var notFoundErr *model.NotFoundError
+		assert.ErrorAs(t, err, &notFoundErr)
+		assert.Equal(t, "event", notFoundErr.Resource)
+	})
+
+	t.Run("Function must return the id of the stored rule", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(2, 1, rule.StartTime, rule.EndTime, rule.TimeZone, rule.RRule).
+			WillReturnResult(sqlmock.NewResult(7, 1))
+
+		id, err := repository.InsertAvailabilityRule(ctx, tx, 1, 2, rule)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), id)
+	})
+}
+
+func TestDeleteAvailabilityRules(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	repository := NewUserAvailabilityRepository(db)
+	ctx := context.Background()
+
+	query := `DELETE FROM user_availability_rule WHERE event_id = ? AND user_id = ?`
+	t.Run("Function must return an error when the delete operation fails", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(2, 1).
+			WillReturnError(assert.AnError)
+
+		err := repository.DeleteAvailabilityRules(ctx, tx, 1, 2)
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must return nil when the delete operation is successful", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(2, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repository.DeleteAvailabilityRules(ctx, tx, 1, 2)
+		assert.NoError(t, err)
+	})
+}
+
+func TestReplaceSyncedAvailability(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	repository := NewUserAvailabilityRepository(db)
+	ctx := context.Background()
+	startTime := time.Date(2025, 07, 14, 9, 0, 0, 0, time.UTC)
+	slots := []model.EventSlot{
+		{StartTime: startTime, EndTime: startTime.Add(time.Hour), TimeZone: "Europe/Berlin"},
+		{StartTime: startTime.Add(3 * time.Hour), EndTime: startTime.Add(4 * time.Hour), TimeZone: "Europe/Berlin"},
+	}
+
+	deleteQuery := `DELETE FROM user_availability WHERE event_id = ? AND user_id = ? AND source = ?`
+	insertQuery := `INSERT INTO user_availability (event_id, user_id, start_time, end_time, time_zone, source) VALUES (?, ?, ?, ?, ?, ?)`
+	t.Run("Function must return an error when the synced slots cannot be deleted", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(deleteQuery)).
+			WithArgs(2, 1, model.AvailabilitySourceCalDAV).
+			WillReturnError(assert.AnError)
+
+		err := repository.ReplaceSyncedAvailability(ctx, tx, 1, 2, slots)
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must return a not found error when the event does not exist", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(deleteQuery)).
+			WithArgs(2, 1, model.AvailabilitySourceCalDAV).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(insertQuery)).
+			WithArgs(2, 1, slots[0].StartTime, slots[0].EndTime, "Europe/Berlin", model.AvailabilitySourceCalDAV).
+			WillReturnError(&mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails"})
+
+		err := repository.ReplaceSyncedAvailability(ctx, tx, 1, 2, slots)
+		assert.ErrorIs(t, err, model.ErrNotFound)
+	})
+
+	t.Run("Function must replace the synced slots with the given ones", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(deleteQuery)).
+			WithArgs(2, 1, model.AvailabilitySourceCalDAV).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		for _, slot := range slots {
+			mock.ExpectExec(regexp.QuoteMeta(insertQuery)).
+				WithArgs(2, 1, slot.StartTime, slot.EndTime, "Europe/Berlin", model.AvailabilitySourceCalDAV).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+		}
+
+		err := repository.ReplaceSyncedAvailability(ctx, tx, 1, 2, slots)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetAvailabilityRules(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	repository := NewUserAvailabilityRepository(db)
+	ctx := context.Background()
+	startTime := time.Date(2025, 07, 14, 4, 30, 0, 0, time.UTC)
+	endTime := time.Date(2025, 07, 14, 6, 30, 0, 0, time.UTC)
+
+	query := `SELECT id, start_time, end_time, time_zone, rrule FROM user_availability_rule WHERE event_id = ? AND user_id = ?`
+	t.Run("Function must return an error when the read operation fails", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(2, 1).
+			WillReturnError(assert.AnError)
+
+		_, err := repository.GetAvailabilityRules(ctx, 2, 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must return the stored rules", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(2, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "start_time", "end_time", "time_zone", "rrule"}).
+				AddRow(7, startTime, endTime, "Asia/Kolkata", "FREQ=DAILY"))
+
+		rules, err := repository.GetAvailabilityRules(ctx, 2, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []model.AvailabilityRule{{ID: 7, StartTime: startTime, EndTime: endTime, TimeZone: "Asia/Kolkata", RRule: "FREQ=DAILY"}}, rules)
+	})
+}
+
+func TestGetAllEventRules(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	repository := NewUserAvailabilityRepository(db)
+	ctx := context.Background()
+	startTime := time.Date(2025, 07, 14, 4, 30, 0, 0, time.UTC)
+	endTime := time.Date(2025, 07, 14, 6, 30, 0, 0, time.UTC)
+
+	query := `SELECT id, user_id, start_time, end_time, time_zone, rrule FROM user_availability_rule WHERE event_id = ? order by user_id ASC`
+	t.Run("Function must return an error when the read operation fails", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(2).
+			WillReturnError(assert.AnError)
+
+		_, err := repository.GetAllEventRules(ctx, 2)
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must group the rules by user", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "start_time", "end_time", "time_zone", "rrule"}).
+				AddRow(7, 1, startTime, endTime, "Asia/Kolkata", "FREQ=DAILY").
+				AddRow(8, 1, startTime, endTime, "Asia/Kolkata", "FREQ=WEEKLY").
+				AddRow(9, 3, startTime, endTime, "UTC", "FREQ=DAILY"))
+
+		rules, err := repository.GetAllEventRules(ctx, 2)
+		assert.NoError(t, err)
+		assert.Len(t, rules, 2)
+		assert.Len(t, rules[1], 2)
+		assert.Equal(t, "FREQ=WEEKLY", rules[1][1].RRule)
+		assert.Equal(t, int64(9), rules[3][0].ID)
+	})
+}
+
+func TestListUserEventAvailability(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	repository := NewUserAvailabilityRepository(db)
+	ctx := context.Background()
+	createdAT := time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC)
+	startTime := time.Date(2025, 07, 14, 4, 30, 0, 0, time.UTC)
+	endTime := time.Date(2025, 07, 14, 6, 30, 0, 0, time.UTC)
+	columns := []string{"id", "title", "organizer_id", "duration_minutes", "time_zone", "rrule", "status", "confirmed_start_time", "confirmed_end_time", "created_at", "updated_at", "invited", "is_required"}
+	eventsQuery := `SELECT id, title, organizer_id, duration_minutes, time_zone, rrule, status, confirmed_start_time, confirmed_end_time, created_at, updated_at, invitation.event_id IS NOT NULL, COALESCE(invitation.is_required, 0) FROM event_detail
+	LEFT JOIN (SELECT event_id, is_required FROM event_attendee WHERE user_id = ?) AS invitation ON invitation.event_id = event_detail.id
+	WHERE id > ? AND (invitation.event_id IS NOT NULL
+		OR id IN (SELECT event_id FROM user_availability WHERE user_id = ?)
+		OR id IN (SELECT event_id FROM user_availability_rule WHERE user_id = ?))`
+
+	t.Run("Function must return an error when the read operation fails", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(eventsQuery+` ORDER BY id ASC LIMIT ?`)).
+			WithArgs(7, int64(0), 7, 7, 10).
+			WillReturnError(assert.AnError)
+
+		_, err := repository.ListUserEventAvailability(ctx, 7, model.UserEventFilter{Limit: 10})
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must not read any availability when the user has no events", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(eventsQuery+` ORDER BY id ASC LIMIT ?`)).
+			WithArgs(7, int64(0), 7, 7, 10).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		userEvents, err := repository.ListUserEventAvailability(ctx, 7, model.UserEventFilter{Limit: 10})
+		assert.NoError(t, err)
+		assert.Empty(t, userEvents)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Function must return the slots and rules of the user for each event", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(eventsQuery+` AND status = ? ORDER BY id ASC LIMIT ?`)).
+			WithArgs(7, int64(5), 7, 7, model.EventStatusPolling, 10).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(6, "Weekly sync", 2, 30, "UTC", "FREQ=WEEKLY", "polling", nil, nil, createdAT, createdAT, true, true).
+				AddRow(9, "Design sync", 2, 60, "Asia/Kolkata", "", "polling", nil, nil, createdAT, createdAT, false, false))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT event_id, id, start_time, end_time, time_zone FROM user_availability WHERE user_id = ? AND event_id IN (?, ?) ORDER BY event_id, start_time`)).
+			WithArgs(7, int64(6), int64(9)).
+			WillReturnRows(sqlmock.NewRows([]string{"event_id", "id", "start_time", "end_time", "time_zone"}).
+				AddRow(9, 3, startTime, endTime, "Asia/Kolkata"))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT event_id, id, start_time, end_time, time_zone, rrule FROM user_availability_rule WHERE user_id = ? AND event_id IN (?, ?) ORDER BY event_id, id`)).
+			WithArgs(7, int64(6), int64(9)).
+			WillReturnRows(sqlmock.NewRows([]string{"event_id", "id", "start_time", "end_time", "time_zone", "rrule"}))
+
+		userEvents, err := repository.ListUserEventAvailability(ctx, 7, model.UserEventFilter{Status: model.EventStatusPolling, AfterID: 5, Limit: 10})
+		assert.NoError(t, err)
+		assert.Len(t, userEvents, 2)
+		assert.True(t, userEvents[0].Invited)
+		assert.True(t, userEvents[0].Required)
+		assert.Empty(t, userEvents[0].Availability)
+		assert.False(t, userEvents[1].Invited)
+		assert.Equal(t, []model.EventSlot{{ID: 3, StartTime: startTime, EndTime: endTime, TimeZone: "Asia/Kolkata"}}, userEvents[1].Availability)
+		assert.Equal(t, []model.AvailabilityRule{}, userEvents[1].Rules)
+	})
+}
+
+func TestInsertUserAvailabilityBatch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	repository := NewUserAvailabilityRepository(db)
+	ctx := context.Background()
+	ten := time.Date(2025, 07, 14, 10, 0, 0, 0, time.UTC)
+	items := []model.UserAvailability{
+		{UserID: 1, Availability: []model.EventSlot{{StartTime: ten, EndTime: ten.Add(time.Hour), TimeZone: "UTC"}}},
+		{UserID: 2, Availability: []model.EventSlot{{StartTime: ten, EndTime: ten.Add(2 * time.Hour), TimeZone: "Asia/Kolkata"}}, Rules: []model.AvailabilityRule{
+			{StartTime: ten, EndTime: ten.Add(time.Hour), TimeZone: "Asia/Kolkata", RRule: "FREQ=DAILY"},
+		}},
+	}
+	slotsQuery := `INSERT INTO user_availability (event_id, user_id, start_time, end_time, time_zone) VALUES (?, ?, ?, ?, ?), (?, ?, ?, ?, ?)`
+	rulesQuery := `INSERT INTO user_availability_rule (event_id, user_id, start_time, end_time, time_zone, rrule) VALUES (?, ?, ?, ?, ?, ?)`
+
+	t.Run("Function must return a not found error when the event does not exist", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(slotsQuery)).
+			WithArgs(3, 1, ten, ten.Add(time.Hour), "UTC", 3, 2, ten, ten.Add(2*time.Hour), "Asia/Kolkata").
+			WillReturnError(&mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails"})
+
+		err := repository.InsertUserAvailabilityBatch(ctx, tx, 3, items)
+		assert.ErrorIs(t, err, model.ErrNotFound)
+	})
+
+	t.Run("Function must insert every slot and rule in one statement each", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(slotsQuery)).
+			WithArgs(3, 1, ten, ten.Add(time.Hour), "UTC", 3, 2, ten, ten.Add(2*time.Hour), "Asia/Kolkata").
+			WillReturnResult(sqlmock.NewResult(1, 2))
+		mock.ExpectExec(regexp.QuoteMeta(rulesQuery)).
+			WithArgs(3, 2, ten, ten.Add(time.Hour), "Asia/Kolkata", "FREQ=DAILY").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := repository.InsertUserAvailabilityBatch(ctx, tx, 3, items)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Function must split large batches into several statements", func(t *testing.T) {
+		many := make([]model.EventSlot, insertBatchSize+1)
+		for i := range many {
+			many[i] = model.EventSlot{StartTime: ten, EndTime: ten.Add(time.Hour), TimeZone: "UTC"}
+		}
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO user_availability`)).WillReturnResult(sqlmock.NewResult(1, insertBatchSize))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO user_availability (event_id, user_id, start_time, end_time, time_zone) VALUES (?, ?, ?, ?, ?)`)).
+			WithArgs(3, 1, ten, ten.Add(time.Hour), "UTC").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := repository.InsertUserAvailabilityBatch(ctx, tx, 3, []model.UserAvailability{{UserID: 1, Availability: many}})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/rahulshewale153/meeting-scheduler-api/utils"
+)
+
+type userRepository struct {
+	dbConn *sql.DB
+}
+
+func NewUserRepository(dbConn *sql.DB) UserRepositoryI {
+	return &userRepository{dbConn: dbConn}
+}
+
+// UpsertUserProfile: creates the profile of the user or replaces the stored one.
+func (userRepo *userRepository) UpsertUserProfile(ctx context.Context, tx *sql.Tx, profile model.UserProfile) error {
+	query := `INSERT INTO user_profile (user_id, time_zone) VALUES (?, ?) ON DUPLICATE KEY UPDATE time_zone = VALUES(time_zone)`
+	_, err := tx.ExecContext(ctx, query, profile.UserID, profile.TimeZone)
+	if err != nil {
+		log.Printf("Error upserting user profile: %v", err)
+		return err
+	}
+	return nil
+}
+
+// GetUserProfile: retrieves the profile of a user, users who never saved one are not found.
+func (userRepo *userRepository) GetUserProfile(ctx context.Context, userID int64) (model.UserProfile, error) {
+	query := `SELECT user_id, time_zone FROM user_profile WHERE user_id = ?`
+	var profile model.UserProfile
+	if err := userRepo.dbConn.QueryRowContext(ctx, query, userID).Scan(&profile.UserID, &profile.TimeZone); err != nil {
+		if err == sql.ErrNoRows {
+			return model.UserProfile{}, &model.NotFoundError{Resource: "user", ID: userID}
+		}
+		log.Printf("Error retrieving user profile: %v", err)
+		return model.UserProfile{}, err
+	}
+
+	workingHours, err := userRepo.getWorkingHours(ctx, []int64{userID})
+	if err != nil {
+		return model.UserProfile{}, err
+	}
+	profile.WorkingHours = workingHours[userID]
+	return profile, nil
+}
+
+// GetUserProfiles: retrieves the profiles of several users keyed by user id, users who never saved one are left out.
+func (userRepo *userRepository) GetUserProfiles(ctx context.Context, userIDs []int64) (map[int64]model.UserProfile, error) {
+	profiles := make(map[int64]model.UserProfile)
+	if len(userIDs) == 0 {
+		return profiles, nil
+	}
+	placeholders, args := inClause(userIDs)
+	query := `SELECT user_id, time_zone FROM user_profile WHERE user_id IN (` + placeholders + `)`
+	rows, err := userRepo.dbConn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Error retrieving user profiles: %v", err)
+		return profiles, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var profile model.UserProfile
+		if err := rows.Scan(&profile.UserID, &profile.TimeZone); err != nil {
+			log.Printf("Error scanning user profile: %v", err)
+			return profiles, err
+		}
+		profiles[profile.UserID] = profile
+	}
+
+	workingHours, err := userRepo.getWorkingHours(ctx, userIDs)
+	if err != nil {
+		return profiles, err
+	}
+	for userID, hours := range workingHours {
+		profile, ok := profiles[userID]
+		if !ok {
+			continue
+		}
+		profile.WorkingHours = hours
+		profiles[userID] = profile
+	}
+	return profiles, nil
+}
+
+// ReplaceWorkingHours: removes the stored working hours of a user and inserts the given ones.
+func (userRepo *userRepository) ReplaceWorkingHours(ctx context.Context, tx *sql.Tx, userID int64, workingHours []model.WorkingHours) error {
+	query := `DELETE FROM user_working_hours WHERE user_id = ?`
+	if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+		log.Printf("Error deleting working hours: %v", err)
+		return err
+	}
+
+	query = `INSERT INTO user_working_hours (user_id, weekday, start_minute, end_minute) VALUES (?, ?, ?, ?)`
+	for _, hours := range workingHours {
+		start, err := utils.ParseClock(hours.Start)
+		if err != nil {
+			return err
+		}
+		end, err := utils.ParseClock(hours.End)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, query, userID, int(hours.Weekday), start, end); err != nil {
+			log.Printf("Error inserting working hours: %v", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// getWorkingHours: retrieves the working hours of the users keyed by user id, ordered by weekday and start.
+func (userRepo *userRepository) getWorkingHours(ctx context.Context, userIDs []int64) (map[int64][]model.WorkingHours, error) {
+	workingHours := make(map[int64][]model.WorkingHours)
+	placeholders, args := inClause(userIDs)
+	query := `SELECT user_id, weekday, start_minute, end_minute FROM user_working_hours WHERE user_id IN (` + placeholders + `) ORDER BY user_id, weekday, start_minute`
+	rows, err := userRepo.dbConn.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Error retrieving working hours: %v", err)
+		return workingHours, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID int64
+		var weekday, start, end int
+		if err := rows.Scan(&userID, &weekday, &start, &end); err != nil {
+			log.Printf("Error scanning working hours: %v", err)
+			return workingHours, err
+		}
+		workingHours[userID] = append(workingHours[userID], model.WorkingHours{
+			Weekday: time.Weekday(weekday),
+			Start:   utils.FormatClock(start),
+			End:     utils.FormatClock(end),
+		})
+	}
+	return workingHours, nil
+}
+
+// inClause returns the placeholders and arguments of an IN list for the values
+func inClause[T any](values []T) (string, []any) {
+	args := make([]any, 0, len(values))
+	for _, value := range values {
+		args = append(args, value)
+	}
+	return strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", "), args
+}
@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpsertUserProfile(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	repository := NewUserRepository(db)
+	ctx := context.Background()
+	profile := model.UserProfile{UserID: 1, TimeZone: "Asia/Kolkata"}
+
+	query := `INSERT INTO user_profile (user_id, time_zone) VALUES (?, ?) ON DUPLICATE KEY UPDATE time_zone = VALUES(time_zone)`
+	t.Run("Function must return an error when the write operation fails", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(profile.UserID, profile.TimeZone).
+			WillReturnError(assert.AnError)
+
+		err := repository.UpsertUserProfile(ctx, tx, profile)
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must return nil when the upsert operation is successful", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(profile.UserID, profile.TimeZone).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := repository.UpsertUserProfile(ctx, tx, profile)
+		assert.NoError(t, err)
+	})
+}
+
+func TestGetUserProfile(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	repository := NewUserRepository(db)
+	ctx := context.Background()
+	userID := int64(1)
+
+	query := `SELECT user_id, time_zone FROM user_profile WHERE user_id = ?`
+	hoursQuery := `SELECT user_id, weekday, start_minute, end_minute FROM user_working_hours WHERE user_id IN (?) ORDER BY user_id, weekday, start_minute`
+	t.Run("Function must return an error when the read operation fails", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(userID).
+			WillReturnError(assert.AnError)
+
+		_, err := repository.GetUserProfile(ctx, userID)
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must return a not found error when the user has no profile", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "time_zone"}))
+
+		_, err := repository.GetUserProfile(ctx, userID)
+		var notFoundErr *model.NotFoundError
+		assert.ErrorAs(t, err, &notFoundErr)
+		assert.Equal(t, "user", notFoundErr.Resource)
+	})
+
+	t.Run("Function must return the profile when the read operation is successful", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "time_zone"}).AddRow(1, "Asia/Kolkata"))
+		mock.ExpectQuery(regexp.QuoteMeta(hoursQuery)).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "weekday", "start_minute", "end_minute"}).
+				AddRow(1, 1, 540, 1020).
+				AddRow(1, 5, 600, 1440))
+
+		profile, err := repository.GetUserProfile(ctx, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, model.UserProfile{
+			UserID:   1,
+			TimeZone: "Asia/Kolkata",
+			WorkingHours: []model.WorkingHours{
+				{Weekday: time.Monday, Start: "09:00", End: "17:00"},
+				{Weekday: time.Friday, Start: "10:00", End: "24:00"},
+			},
+		}, profile)
+	})
+
+	t.Run("Function must return an error when reading the working hours fails", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "time_zone"}).AddRow(1, "Asia/Kolkata"))
+		mock.ExpectQuery(regexp.QuoteMeta(hoursQuery)).
+			WithArgs(userID).
+			WillReturnError(assert.AnError)
+
+		_, err := repository.GetUserProfile(ctx, userID)
+		assert.Error(t, err)
+	})
+}
+
+func TestGetUserProfiles(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	repository := NewUserRepository(db)
+	ctx := context.Background()
+
+	query := `SELECT user_id, time_zone FROM user_profile WHERE user_id IN (?, ?, ?)`
+	hoursQuery := `SELECT user_id, weekday, start_minute, end_minute FROM user_working_hours WHERE user_id IN (?, ?, ?) ORDER BY user_id, weekday, start_minute`
+	t.Run("Function must not query the database when no user is given", func(t *testing.T) {
+		profiles, err := repository.GetUserProfiles(ctx, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, profiles)
+	})
+
+	t.Run("Function must return an error when the read operation fails", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(1, 2, 3).
+			WillReturnError(assert.AnError)
+
+		_, err := repository.GetUserProfiles(ctx, []int64{1, 2, 3})
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must return the stored profiles with their working hours", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(1, 2, 3).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "time_zone"}).
+				AddRow(1, "Asia/Kolkata").
+				AddRow(2, "America/New_York"))
+		mock.ExpectQuery(regexp.QuoteMeta(hoursQuery)).
+			WithArgs(1, 2, 3).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "weekday", "start_minute", "end_minute"}).
+				AddRow(1, 1, 540, 1020))
+
+		profiles, err := repository.GetUserProfiles(ctx, []int64{1, 2, 3})
+		assert.NoError(t, err)
+		assert.Equal(t, map[int64]model.UserProfile{
+			1: {UserID: 1, TimeZone: "Asia/Kolkata", WorkingHours: []model.WorkingHours{{Weekday: time.Monday, Start: "09:00", End: "17:00"}}},
+			2: {UserID: 2, TimeZone: "America/New_York"},
+		}, profiles)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReplaceWorkingHours(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	repository := NewUserRepository(db)
+	ctx := context.Background()
+	userID := int64(1)
+	workingHours := []model.WorkingHours{
+		{Weekday: time.Monday, Start: "09:00", End: "17:00"},
+		{Weekday: time.Tuesday, Start: "13:30", End: "24:00"},
+	}
+
+	deleteQuery := `DELETE FROM user_working_hours WHERE user_id = ?`
+	insertQuery := `INSERT INTO user_working_hours (user_id, weekday, start_minute, end_minute) VALUES (?, ?, ?, ?)`
+	t.Run("Function must return an error when the delete operation fails", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(deleteQuery)).
+			WithArgs(userID).
+			WillReturnError(assert.AnError)
+
+		err := repository.ReplaceWorkingHours(ctx, tx, userID, workingHours)
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must return an error when an insert operation fails", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(deleteQuery)).
+			WithArgs(userID).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(regexp.QuoteMeta(insertQuery)).
+			WithArgs(userID, 1, 540, 1020).
+			WillReturnError(assert.AnError)
+
+		err := repository.ReplaceWorkingHours(ctx, tx, userID, workingHours)
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must store the working hours as minutes after midnight", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(deleteQuery)).
+			WithArgs(userID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(insertQuery)).
+			WithArgs(userID, 1, 540, 1020).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(insertQuery)).
+			WithArgs(userID, 2, 810, 1440).
+			WillReturnResult(sqlmock.NewResult(2, 1))
+
+		err := repository.ReplaceWorkingHours(ctx, tx, userID, workingHours)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
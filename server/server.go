@@ -12,14 +12,20 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/rahulshewale153/meeting-scheduler-api/configreader"
 	"github.com/rahulshewale153/meeting-scheduler-api/handler"
+	"github.com/rahulshewale153/meeting-scheduler-api/middleware"
 	"github.com/rahulshewale153/meeting-scheduler-api/repository"
 	"github.com/rahulshewale153/meeting-scheduler-api/service"
 )
 
+// defaultSyncInterval is how often availability is synced from CalDAV when no interval is configured
+const defaultSyncInterval = 15 * time.Minute
+
 type server struct {
 	httpServer *http.Server
 	config     *configreader.Config
 	mysqlDB    *sql.DB
+	// stopSync stops the CalDAV availability sync, nil when it is not configured
+	stopSync context.CancelFunc
 }
 
 func NewServer(config *configreader.Config) *server {
@@ -40,7 +46,6 @@ func NewServer(config *configreader.Config) *server {
 
 func setupMysqlDBConnection(config *configreader.Config) (*sql.DB, error) {
 	mysqlConfig := config.MySQL
-	fmt.Println(mysqlConfig)
 	mCfg := mysql.Config{
 		User:      mysqlConfig.Username,
 		Passwd:    mysqlConfig.Password,
@@ -77,19 +82,54 @@ func (s *server) Start() {
 	transactionManager := repository.NewTransactionManager(s.mysqlDB)
 	eventRepo := repository.NewEventRepository(s.mysqlDB)
 	userAvailabilityRepo := repository.NewUserAvailabilityRepository(s.mysqlDB)
+	userRepo := repository.NewUserRepository(s.mysqlDB)
+	resourceRepo := repository.NewResourceRepository(s.mysqlDB)
 
 	//setup service
-	eventService := service.NewEventService(transactionManager, eventRepo)
-	userAvailabilityService := service.NewUserAvailabilityService(transactionManager, userAvailabilityRepo)
-	recommendationService := service.NewRecommendationService(eventRepo, userAvailabilityRepo)
+	recommendationStep := time.Duration(s.config.Recommendation.StepMinutes) * time.Minute
+	recommendationService := service.NewRecommendationService(eventRepo, userAvailabilityRepo, userRepo, resourceRepo, recommendationStep)
+	eventService := service.NewEventService(transactionManager, eventRepo, resourceRepo, recommendationService)
+	userAvailabilityService := service.NewUserAvailabilityService(transactionManager, userAvailabilityRepo, userRepo, eventRepo)
+	userService := service.NewUserService(transactionManager, userRepo)
+	calendarService := service.NewCalendarService(eventService, eventRepo, s.config.Calendar.Domain)
+	conflictService := service.NewConflictService(eventRepo)
+	resourceService := service.NewResourceService(transactionManager, resourceRepo, eventRepo)
+
+	//setup calendar sync
+	if s.config.CalDAV.BaseURL != "" {
+		freeBusyRepo := repository.NewCalDAVRepository(s.config.CalDAV)
+		syncService := service.NewAvailabilitySyncService(transactionManager, userAvailabilityRepo, userRepo, eventRepo, freeBusyRepo)
+		syncInterval := time.Duration(s.config.CalDAV.SyncIntervalMinutes) * time.Minute
+		if syncInterval <= 0 {
+			syncInterval = defaultSyncInterval
+		}
+		var syncCtx context.Context
+		syncCtx, s.stopSync = context.WithCancel(context.Background())
+		go service.RunAvailabilitySync(syncCtx, syncService, syncInterval)
+		log.Printf("CalDAV availability sync every %s", syncInterval)
+	}
 
 	//setup handler
 	eventHandler := handler.NewEventHandler(eventService)
 	userAvailabilityHandler := handler.NewUserAvailabilityHandler(userAvailabilityService)
 	recommendationHandler := handler.NewRecommendationHandler(recommendationService)
+	userHandler := handler.NewUserHandler(userService)
+	calendarHandler := handler.NewCalendarHandler(calendarService)
+	conflictHandler := handler.NewConflictHandler(conflictService)
+	resourceHandler := handler.NewResourceHandler(resourceService)
 
 	//setup http server
 	r := mux.NewRouter()
+	r.Use(middleware.RequestID)
+	r.NotFoundHandler = middleware.RequestID(http.HandlerFunc(handler.RouteNotFound))
+	r.MethodNotAllowedHandler = middleware.RequestID(http.HandlerFunc(handler.MethodNotAllowed))
+	if s.config.Auth.Enabled {
+		authentication, err := middleware.NewAuthentication(s.config.Auth, handler.Unauthorized, "/health")
+		if err != nil {
+			log.Fatalf("Failed to set up authentication: %v", err)
+		}
+		r.Use(authentication.Middleware)
+	}
 	//basic health api
 	r.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -98,14 +138,46 @@ func (s *server) Start() {
 
 	//event related api
 	r.HandleFunc("/events", eventHandler.InsertEvent).Methods(http.MethodPost)
+	r.HandleFunc("/events", eventHandler.ListEvents).Methods(http.MethodGet)
+	// registered before /events/{event_id}, which would otherwise match the .ics suffix as part of the id
+	r.HandleFunc("/events/{event_id:[0-9]+}.ics", calendarHandler.ExportEvent).Methods(http.MethodGet)
+	r.HandleFunc("/events/{event_id}", eventHandler.GetEvent).Methods(http.MethodGet)
 	r.HandleFunc("/events/{event_id}", eventHandler.UpdateEvent).Methods(http.MethodPut)
 	r.HandleFunc("/events/{event_id}", eventHandler.DeleteEvent).Methods(http.MethodDelete)
+	r.HandleFunc("/events/{event_id}/confirm", eventHandler.ConfirmEvent).Methods(http.MethodPost)
+	r.HandleFunc("/events/{event_id}/cancel", eventHandler.CancelEvent).Methods(http.MethodPost)
+	r.HandleFunc("/events/{event_id}/attendees", eventHandler.ListAttendees).Methods(http.MethodGet)
+	r.HandleFunc("/events/{event_id}/attendees", eventHandler.AddAttendee).Methods(http.MethodPost)
+	r.HandleFunc("/events/{event_id}/attendees/{user_id}", eventHandler.RemoveAttendee).Methods(http.MethodDelete)
+	r.HandleFunc("/events/{event_id}/exceptions/{occurrence_date}", eventHandler.SetEventException).Methods(http.MethodPut)
+	r.HandleFunc("/events/{event_id}/exceptions/{occurrence_date}", eventHandler.RemoveEventException).Methods(http.MethodDelete)
+	r.HandleFunc("/events/{event_id}/resources", resourceHandler.ListEventResources).Methods(http.MethodGet)
+	r.HandleFunc("/events/{event_id}/resources", resourceHandler.AddEventResource).Methods(http.MethodPost)
+	r.HandleFunc("/events/{event_id}/resources/{requirement_id}", resourceHandler.RemoveEventResource).Methods(http.MethodDelete)
 
 	//user availability related api
+	r.HandleFunc("/events/{event_id}/availability:batch", userAvailabilityHandler.InsertUserAvailabilityBatch).Methods(http.MethodPost)
 	r.HandleFunc("/events/{event_id}/availability/{user_id}", userAvailabilityHandler.InsertUserAvailability).Methods(http.MethodPost)
 	r.HandleFunc("/events/{event_id}/availability/{user_id}", userAvailabilityHandler.GetUserAvailability).Methods(http.MethodGet)
 	r.HandleFunc("/events/{event_id}/availability/{user_id}", userAvailabilityHandler.UpdateUserAvailability).Methods(http.MethodPut)
 	r.HandleFunc("/events/{event_id}/availability/{user_id}", userAvailabilityHandler.DeleteUserAvailability).Methods(http.MethodDelete)
+	r.HandleFunc("/events/{event_id}/availability/{user_id}/import", userAvailabilityHandler.ImportUserAvailability).Methods(http.MethodPost)
+
+	//user related api
+	r.HandleFunc("/users/{user_id}/profile", userHandler.GetUserProfile).Methods(http.MethodGet)
+	r.HandleFunc("/users/{user_id}/profile", userHandler.UpdateUserProfile).Methods(http.MethodPut)
+	r.HandleFunc("/users/{user_id}/calendar.ics", calendarHandler.ExportUserCalendar).Methods(http.MethodGet)
+	r.HandleFunc("/users/{user_id}/conflicts", conflictHandler.GetUserConflicts).Methods(http.MethodGet)
+	r.HandleFunc("/users/{user_id}/availability", userAvailabilityHandler.ListUserEventAvailability).Methods(http.MethodGet)
+
+	//resource related api
+	r.HandleFunc("/resources", resourceHandler.InsertResource).Methods(http.MethodPost)
+	r.HandleFunc("/resources", resourceHandler.ListResources).Methods(http.MethodGet)
+	r.HandleFunc("/resources/{resource_id}", resourceHandler.GetResource).Methods(http.MethodGet)
+	r.HandleFunc("/resources/{resource_id}", resourceHandler.UpdateResource).Methods(http.MethodPut)
+	r.HandleFunc("/resources/{resource_id}", resourceHandler.DeleteResource).Methods(http.MethodDelete)
+	r.HandleFunc("/resources/{resource_id}/availability", resourceHandler.GetResourceAvailability).Methods(http.MethodGet)
+	r.HandleFunc("/resources/{resource_id}/availability", resourceHandler.SetResourceAvailability).Methods(http.MethodPut)
 
 	//recommendation related api
 	r.HandleFunc("/events/{event_id}/recommendation", recommendationHandler.GetRecommendedSlots).Methods(http.MethodGet)
@@ -123,6 +195,10 @@ func (s *server) Start() {
 
 // service stop all http connection correctly, graceful shutdown occurred during running process
 func (s *server) Stop() {
+	if s.stopSync != nil {
+		s.stopSync()
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
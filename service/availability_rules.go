@@ -0,0 +1,37 @@
+package service
+
+import (
+	"log"
+	"time"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/rahulshewale153/meeting-scheduler-api/utils"
+)
+
+// eventWindow returns the span from the earliest proposed slot start to the latest proposed slot end
+func eventWindow(slots []model.EventSlot) (from time.Time, to time.Time, ok bool) {
+	for i, slot := range slots {
+		if i == 0 || slot.StartTime.Before(from) {
+			from = slot.StartTime
+		}
+		if i == 0 || slot.EndTime.After(to) {
+			to = slot.EndTime
+		}
+	}
+	return from, to, len(slots) > 0
+}
+
+// expandRules turns recurring availability into the concrete slots that overlap [from, to).
+// Rules were validated when stored, one that no longer parses is logged and skipped
+func expandRules(rules []model.AvailabilityRule, from time.Time, to time.Time) []model.EventSlot {
+	slots := []model.EventSlot{}
+	for _, rule := range rules {
+		occurrences, err := utils.ExpandRule(rule, from, to)
+		if err != nil {
+			log.Println("Error expanding availability rule:", rule.ID, err)
+			continue
+		}
+		slots = append(slots, occurrences...)
+	}
+	return slots
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandRules(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	assert.NoError(t, err)
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	starts := func(slots []model.EventSlot) []string {
+		formatted := []string{}
+		for _, slot := range slots {
+			formatted = append(formatted, slot.StartTime.Format(time.RFC3339))
+		}
+		return formatted
+	}
+
+	t.Run("Function must repeat a weekday rule on the wall clock of its zone", func(t *testing.T) {
+		// Monday 2025-07-07 10:00-12:00 in Kolkata, expanded over the week of 2025-07-14
+		rule := model.AvailabilityRule{
+			StartTime: time.Date(2025, 07, 07, 10, 0, 0, 0, kolkata),
+			EndTime:   time.Date(2025, 07, 07, 12, 0, 0, 0, kolkata),
+			TimeZone:  "Asia/Kolkata",
+			RRule:     "RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+		}
+		slots := expandRules([]model.AvailabilityRule{rule}, time.Date(2025, 07, 14, 0, 0, 0, 0, time.UTC), time.Date(2025, 07, 21, 0, 0, 0, 0, time.UTC))
+		assert.Equal(t, []string{
+			"2025-07-14T04:30:00Z", "2025-07-15T04:30:00Z", "2025-07-16T04:30:00Z", "2025-07-17T04:30:00Z", "2025-07-18T04:30:00Z",
+		}, starts(slots))
+		assert.Equal(t, 2*time.Hour, slots[0].EndTime.Sub(slots[0].StartTime))
+		assert.Equal(t, "Asia/Kolkata", slots[0].TimeZone)
+	})
+
+	t.Run("Function must keep the local time across a DST change", func(t *testing.T) {
+		// New York leaves daylight saving time on 2025-11-02
+		rule := model.AvailabilityRule{
+			StartTime: time.Date(2025, 10, 31, 9, 0, 0, 0, newYork),
+			EndTime:   time.Date(2025, 10, 31, 10, 0, 0, 0, newYork),
+			TimeZone:  "America/New_York",
+			RRule:     "FREQ=DAILY;COUNT=4",
+		}
+		slots := expandRules([]model.AvailabilityRule{rule}, rule.StartTime, rule.StartTime.AddDate(0, 0, 10))
+		assert.Equal(t, []string{"2025-10-31T13:00:00Z", "2025-11-01T13:00:00Z", "2025-11-02T14:00:00Z", "2025-11-03T14:00:00Z"}, starts(slots))
+	})
+
+	t.Run("Function must honour interval, until, month days and the overlap with the window", func(t *testing.T) {
+		utcRule := func(start time.Time, rrule string) model.AvailabilityRule {
+			return model.AvailabilityRule{StartTime: start, EndTime: start.Add(2 * time.Hour), TimeZone: "UTC", RRule: rrule}
+		}
+		tests := []struct {
+			name     string
+			rule     model.AvailabilityRule
+			from, to time.Time
+			starts   []string
+		}{
+			{
+				name:   "every other week",
+				rule:   utcRule(time.Date(2025, 07, 02, 9, 0, 0, 0, time.UTC), "FREQ=WEEKLY;INTERVAL=2"),
+				from:   time.Date(2025, 07, 01, 0, 0, 0, 0, time.UTC),
+				to:     time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+				starts: []string{"2025-07-02T09:00:00Z", "2025-07-16T09:00:00Z", "2025-07-30T09:00:00Z"},
+			},
+			{
+				name:   "until a date",
+				rule:   utcRule(time.Date(2025, 07, 14, 9, 0, 0, 0, time.UTC), "FREQ=DAILY;UNTIL=20250716"),
+				from:   time.Date(2025, 07, 01, 0, 0, 0, 0, time.UTC),
+				to:     time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+				starts: []string{"2025-07-14T09:00:00Z", "2025-07-15T09:00:00Z", "2025-07-16T09:00:00Z"},
+			},
+			{
+				name:   "month days that do not exist are skipped",
+				rule:   utcRule(time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC), "FREQ=MONTHLY;BYMONTHDAY=15,30,-1"),
+				from:   time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+				to:     time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+				starts: []string{"2025-02-15T09:00:00Z", "2025-02-28T09:00:00Z"},
+			},
+			{
+				name:   "an occurrence running into the window counts",
+				rule:   utcRule(time.Date(2025, 07, 14, 9, 0, 0, 0, time.UTC), "FREQ=DAILY"),
+				from:   time.Date(2025, 07, 15, 10, 0, 0, 0, time.UTC),
+				to:     time.Date(2025, 07, 16, 9, 0, 0, 0, time.UTC),
+				starts: []string{"2025-07-15T09:00:00Z"},
+			},
+		}
+		for _, tt := range tests {
+			assert.Equal(t, tt.starts, starts(expandRules([]model.AvailabilityRule{tt.rule}, tt.from, tt.to)), tt.name)
+		}
+	})
+
+	t.Run("Function must skip rules that do not parse", func(t *testing.T) {
+		rule := model.AvailabilityRule{StartTime: time.Now(), EndTime: time.Now().Add(time.Hour), RRule: "FREQ=YEARLY"}
+		assert.Empty(t, expandRules([]model.AvailabilityRule{rule}, time.Now(), time.Now().Add(24*time.Hour)))
+	})
+}
+
+func TestEventWindow(t *testing.T) {
+	_, _, ok := eventWindow(nil)
+	assert.False(t, ok)
+
+	from, to, ok := eventWindow([]model.EventSlot{slotAt(12, 0, 13, 0), slotAt(9, 0, 10, 0), slotAt(11, 0, 15, 0)})
+	assert.True(t, ok)
+	assert.Equal(t, slotAt(9, 0, 15, 0).StartTime, from)
+	assert.Equal(t, slotAt(9, 0, 15, 0).EndTime, to)
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/rahulshewale153/meeting-scheduler-api/repository"
+)
+
+// syncPageSize is how many open events are read at a time during a sync
+const syncPageSize = 100
+
+type availabilitySyncService struct {
+	transactionManager   repository.TransactionManagerI
+	userAvailabilityRepo repository.UserAvailabilityRepositoryI
+	userRepo             repository.UserRepositoryI
+	eventRepo            repository.EventRepositoryI
+	freeBusyRepo         repository.FreeBusyRepositoryI
+}
+
+// NewAvailabilitySyncService creates a new instance of availabilitySyncService, freeBusyRepo is the calendar server
+// the busy time of attendees is read from
+func NewAvailabilitySyncService(transactionManager repository.TransactionManagerI, userAvailabilityRepo repository.UserAvailabilityRepositoryI, userRepo repository.UserRepositoryI, eventRepo repository.EventRepositoryI, freeBusyRepo repository.FreeBusyRepositoryI) AvailabilitySyncServiceI {
+	return &availabilitySyncService{
+		transactionManager:   transactionManager,
+		userAvailabilityRepo: userAvailabilityRepo,
+		userRepo:             userRepo,
+		eventRepo:            eventRepo,
+		freeBusyRepo:         freeBusyRepo,
+	}
+}
+
+// RunAvailabilitySync syncs the open events right away, so that availability is fresh after a deploy, and then every
+// interval until ctx is done
+func RunAvailabilitySync(ctx context.Context, syncService AvailabilitySyncServiceI, interval time.Duration) {
+	syncOpenEvents := func() {
+		if err := syncService.SyncOpenEvents(ctx); err != nil {
+			log.Println("Error syncing availability:", err)
+		}
+	}
+	syncOpenEvents()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			syncOpenEvents()
+		}
+	}
+}
+
+// SyncOpenEvents refreshes the synced availability of every polling and draft event. An event that fails to sync
+// is logged and does not stop the others
+func (s *availabilitySyncService) SyncOpenEvents(ctx context.Context) error {
+	failed := 0
+	// polling events go first so that drafts which start polling during the sync are not synced twice
+	for _, status := range []string{model.EventStatusPolling, model.EventStatusDraft} {
+		filter := model.EventFilter{Status: status, Limit: syncPageSize}
+		for {
+			events, err := s.eventRepo.ListEvents(ctx, filter)
+			if err != nil {
+				log.Println("Error listing events:", err)
+				return err
+			}
+			for _, event := range events {
+				if err := s.syncEvent(ctx, event); err != nil {
+					log.Printf("Error syncing availability of event %d: %v", event.ID, err)
+					failed++
+				}
+			}
+			if len(events) < syncPageSize {
+				break
+			}
+			filter.AfterID = events[len(events)-1].ID
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("availability sync failed for %d events", failed)
+	}
+	return nil
+}
+
+// syncEvent stores, for every attendee with a calendar, the free parts of the proposed slots that can hold the event
+func (s *availabilitySyncService) syncEvent(ctx context.Context, event model.Event) error {
+	proposedSlots, err := s.eventRepo.GetEventSlots(ctx, event.ID)
+	if err != nil {
+		log.Println("Error retrieving event slots:", err)
+		return err
+	}
+	from, to, ok := eventWindow(proposedSlots)
+	if !ok {
+		return nil
+	}
+	windows := mergeIntervals(proposedSlots)
+
+	attendees, err := s.eventRepo.GetEventAttendees(ctx, event.ID)
+	if err != nil {
+		log.Println("Error getting event attendees:", err)
+		return err
+	}
+
+	errs := []error{}
+	for _, attendee := range attendees {
+		if err := s.syncUser(ctx, event, windows, from, to, attendee.UserID); err != nil {
+			if errors.Is(err, model.ErrConflict) {
+				// the event was confirmed or cancelled during the sync, its availability is locked
+				break
+			}
+			errs = append(errs, fmt.Errorf("user %d: %w", attendee.UserID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// syncUser replaces the synced availability of one user. Users without a calendar on the server are skipped
+func (s *availabilitySyncService) syncUser(ctx context.Context, event model.Event, windows []model.EventSlot, from time.Time, to time.Time, userID int64) (err error) {
+	busy, err := s.freeBusyRepo.GetFreeBusy(ctx, userID, from, to)
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return nil
+		}
+		log.Println("Error getting free/busy:", err)
+		return err
+	}
+
+	timeZone, err := userTimeZone(ctx, s.userRepo, userID)
+	if err != nil {
+		return err
+	}
+	free := subtractIntervals(windows, mergeIntervals(busy), time.Duration(event.DurationMinutes)*time.Minute)
+	for i := range free {
+		free[i].StartTime = free[i].StartTime.UTC()
+		free[i].EndTime = free[i].EndTime.UTC()
+		free[i].TimeZone = timeZone
+	}
+
+	tx, err := s.transactionManager.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	// Ensure that the transaction is rolled back or committed properly
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				log.Println("Error rolling back transaction:", rollbackErr)
+			}
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			log.Println("Error committing transaction:", commitErr)
+			return
+		}
+	}()
+
+	if event, err = lockOpenEvent(ctx, tx, s.eventRepo, event.ID); err != nil {
+		return err
+	}
+	// an empty list still clears the slots synced before, the user may have become busy since
+	if err = s.userAvailabilityRepo.ReplaceSyncedAvailability(ctx, tx, userID, event.ID, free); err != nil {
+		log.Println("Error replacing synced availability:", err)
+		return err
+	}
+	if len(free) == 0 {
+		return nil
+	}
+	if err = startPolling(ctx, tx, s.eventRepo, event); err != nil {
+		return err
+	}
+	return nil
+}
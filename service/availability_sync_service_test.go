@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	mock_repository "github.com/rahulshewale153/meeting-scheduler-api/mock/repository"
+	mock_service "github.com/rahulshewale153/meeting-scheduler-api/mock/service"
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
+)
+
+func TestSyncEvent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	mockUserAvailRepo := new(mock_repository.MockUserAvailabilityRepository)
+	mockUserRepo := new(mock_repository.MockUserRepository)
+	mockEventRepo := new(mock_repository.MockEventRepository)
+	mockFreeBusyRepo := new(mock_repository.MockFreeBusyRepository)
+	mockTransactionManager := new(mock_repository.MockTransactionManager)
+	syncService := NewAvailabilitySyncService(mockTransactionManager, mockUserAvailRepo, mockUserRepo, mockEventRepo, mockFreeBusyRepo).(*availabilitySyncService)
+	ctx := context.Background()
+	at := func(hour, minute int) time.Time { return time.Date(2025, 07, 14, hour, minute, 0, 0, time.UTC) }
+	proposedSlots := []model.EventSlot{{ID: 1, StartTime: at(9, 0), EndTime: at(12, 0)}, {ID: 2, StartTime: at(14, 0), EndTime: at(15, 0)}}
+
+	t.Run("Function must store the free time of attendees with a calendar and start polling a draft", func(t *testing.T) {
+		mockEventRepo.On("GetEventSlots", ctx, int64(2)).Return(proposedSlots, nil).Once()
+		mockEventRepo.On("GetEventAttendees", ctx, int64(2)).Return([]model.Attendee{{UserID: 1}, {UserID: 3}, {UserID: 4}}, nil).Once()
+
+		// user 1 is busy 09:30-10:00 and 14:00-15:00, leaving 10:00-12:00 for an hour long meeting
+		mockFreeBusyRepo.On("GetFreeBusy", ctx, int64(1), at(9, 0), at(15, 0)).Return([]model.EventSlot{
+			{StartTime: at(14, 0), EndTime: at(15, 0)},
+			{StartTime: at(9, 30), EndTime: at(10, 0)},
+		}, nil).Once()
+		mockUserRepo.On("GetUserProfile", ctx, int64(1)).Return(model.UserProfile{UserID: 1, TimeZone: "Asia/Kolkata"}, nil).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockUserAvailRepo.On("ReplaceSyncedAvailability", ctx, tx, int64(1), int64(2), []model.EventSlot{
+			{StartTime: at(10, 0), EndTime: at(12, 0), TimeZone: "Asia/Kolkata"},
+		}).Return(nil).Once()
+		mockEventRepo.On("LockEvent", ctx, tx, int64(2)).Return(model.Event{ID: 2, DurationMinutes: 60, Status: model.EventStatusDraft}, nil).Once()
+		mockEventRepo.On("StartPolling", ctx, tx, int64(2)).Return(nil).Once()
+
+		// user 3 has no calendar on the server
+		mockFreeBusyRepo.On("GetFreeBusy", ctx, int64(3), at(9, 0), at(15, 0)).Return([]model.EventSlot{}, &model.NotFoundError{Resource: "calendar", ID: 3}).Once()
+
+		// user 4 is busy throughout, the slots synced before are cleared and the event is already polling
+		mockFreeBusyRepo.On("GetFreeBusy", ctx, int64(4), at(9, 0), at(15, 0)).Return([]model.EventSlot{{StartTime: at(8, 0), EndTime: at(16, 0)}}, nil).Once()
+		mockUserRepo.On("GetUserProfile", ctx, int64(4)).Return(model.UserProfile{}, &model.NotFoundError{Resource: "user profile", ID: 4}).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("LockEvent", ctx, tx, int64(2)).Return(model.Event{ID: 2, DurationMinutes: 60, Status: model.EventStatusPolling}, nil).Once()
+		mockUserAvailRepo.On("ReplaceSyncedAvailability", ctx, tx, int64(4), int64(2), []model.EventSlot{}).Return(nil).Once()
+
+		err := syncService.syncEvent(ctx, model.Event{ID: 2, DurationMinutes: 60, Status: model.EventStatusDraft})
+		assert.NoError(t, err)
+		mockFreeBusyRepo.AssertExpectations(t)
+		mockUserAvailRepo.AssertExpectations(t)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must keep syncing the other attendees when one of them fails", func(t *testing.T) {
+		mockEventRepo.On("GetEventSlots", ctx, int64(2)).Return(proposedSlots, nil).Once()
+		mockEventRepo.On("GetEventAttendees", ctx, int64(2)).Return([]model.Attendee{{UserID: 1}, {UserID: 4}}, nil).Once()
+		mockFreeBusyRepo.On("GetFreeBusy", ctx, int64(1), at(9, 0), at(15, 0)).Return([]model.EventSlot{}, assert.AnError).Once()
+		mockFreeBusyRepo.On("GetFreeBusy", ctx, int64(4), at(9, 0), at(15, 0)).Return([]model.EventSlot{{StartTime: at(8, 0), EndTime: at(16, 0)}}, nil).Once()
+		mockUserRepo.On("GetUserProfile", ctx, int64(4)).Return(model.UserProfile{UserID: 4, TimeZone: "UTC"}, nil).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("LockEvent", ctx, tx, int64(2)).Return(model.Event{ID: 2, DurationMinutes: 60, Status: model.EventStatusPolling}, nil).Once()
+		mockUserAvailRepo.On("ReplaceSyncedAvailability", ctx, tx, int64(4), int64(2), []model.EventSlot{}).Return(nil).Once()
+
+		err := syncService.syncEvent(ctx, model.Event{ID: 2, DurationMinutes: 60, Status: model.EventStatusPolling})
+		assert.ErrorIs(t, err, assert.AnError)
+		mockUserAvailRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must stop syncing once the event is confirmed during the sync", func(t *testing.T) {
+		mockEventRepo.On("GetEventSlots", ctx, int64(2)).Return(proposedSlots, nil).Once()
+		mockEventRepo.On("GetEventAttendees", ctx, int64(2)).Return([]model.Attendee{{UserID: 1}, {UserID: 4}}, nil).Once()
+		mockFreeBusyRepo.On("GetFreeBusy", ctx, int64(1), at(9, 0), at(15, 0)).Return([]model.EventSlot{}, nil).Once()
+		mockUserRepo.On("GetUserProfile", ctx, int64(1)).Return(model.UserProfile{UserID: 1, TimeZone: "UTC"}, nil).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("LockEvent", ctx, tx, int64(2)).Return(model.Event{ID: 2, Status: model.EventStatusConfirmed}, nil).Once()
+
+		err := syncService.syncEvent(ctx, model.Event{ID: 2, DurationMinutes: 60, Status: model.EventStatusPolling})
+		assert.NoError(t, err)
+		mockFreeBusyRepo.AssertExpectations(t)
+		mockEventRepo.AssertExpectations(t)
+	})
+}
+
+func TestSyncOpenEvents(t *testing.T) {
+	mockEventRepo := new(mock_repository.MockEventRepository)
+	syncService := NewAvailabilitySyncService(new(mock_repository.MockTransactionManager), new(mock_repository.MockUserAvailabilityRepository), new(mock_repository.MockUserRepository), mockEventRepo, new(mock_repository.MockFreeBusyRepository))
+	ctx := context.Background()
+
+	t.Run("Function must return an error when the events cannot be listed", func(t *testing.T) {
+		mockEventRepo.On("ListEvents", ctx, model.EventFilter{Status: model.EventStatusPolling, Limit: syncPageSize}).Return([]model.Event{}, assert.AnError).Once()
+
+		err := syncService.SyncOpenEvents(ctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must page through polling and draft events and report the ones that failed", func(t *testing.T) {
+		firstPage := make([]model.Event, syncPageSize)
+		for i := range firstPage {
+			firstPage[i] = model.Event{ID: int64(i + 1), Status: model.EventStatusPolling}
+			// events without proposed slots have nothing to sync
+			mockEventRepo.On("GetEventSlots", ctx, firstPage[i].ID).Return([]model.EventSlot{}, nil).Once()
+		}
+		mockEventRepo.On("ListEvents", ctx, model.EventFilter{Status: model.EventStatusPolling, Limit: syncPageSize}).Return(firstPage, nil).Once()
+		mockEventRepo.On("ListEvents", ctx, model.EventFilter{Status: model.EventStatusPolling, AfterID: syncPageSize, Limit: syncPageSize}).Return([]model.Event{}, nil).Once()
+		mockEventRepo.On("ListEvents", ctx, model.EventFilter{Status: model.EventStatusDraft, Limit: syncPageSize}).Return([]model.Event{{ID: 500, Status: model.EventStatusDraft}}, nil).Once()
+		mockEventRepo.On("GetEventSlots", ctx, int64(500)).Return([]model.EventSlot{}, assert.AnError).Once()
+
+		err := syncService.SyncOpenEvents(ctx)
+		assert.EqualError(t, err, "availability sync failed for 1 events")
+		mockEventRepo.AssertExpectations(t)
+	})
+}
+
+func TestRunAvailabilitySync(t *testing.T) {
+	t.Run("Function must sync right away instead of waiting for the first interval", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		mockSyncService := new(mock_service.MockAvailabilitySyncService)
+		mockSyncService.On("SyncOpenEvents", ctx).Run(func(testifyMock.Arguments) { cancel() }).Return(assert.AnError).Once()
+
+		done := make(chan struct{})
+		go func() {
+			RunAvailabilitySync(ctx, mockSyncService, time.Hour)
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("the first sync did not run before the interval")
+		}
+		mockSyncService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/rahulshewale153/meeting-scheduler-api/repository"
+	"github.com/rahulshewale153/meeting-scheduler-api/utils"
+)
+
+const (
+	calendarProdID = "-//meeting-scheduler-api//EN"
+	// defaultCalendarDomain names UIDs and user addresses when no domain is configured
+	defaultCalendarDomain = "meeting-scheduler.local"
+)
+
+type calendarService struct {
+	eventService EventServiceI
+	eventRepo    repository.EventRepositoryI
+	domain       string
+}
+
+// NewCalendarService creates a new instance of calendarService, domain qualifies the UIDs and user addresses it writes
+func NewCalendarService(eventService EventServiceI, eventRepo repository.EventRepositoryI, domain string) CalendarServiceI {
+	if domain == "" {
+		domain = defaultCalendarDomain
+	}
+	return &calendarService{
+		eventService: eventService,
+		eventRepo:    eventRepo,
+		domain:       domain,
+	}
+}
+
+// ExportEvent renders an event as iCalendar, a confirmed event as one VEVENT at its confirmed slot and an event
+// that is still being scheduled as a tentative VEVENT per proposed slot
+func (s *calendarService) ExportEvent(ctx context.Context, eventID int64) ([]byte, error) {
+	event, err := s.eventService.GetEvent(ctx, eventID)
+	if err != nil {
+		log.Println("Error getting event:", err)
+		return nil, err
+	}
+
+	entries, err := s.eventEntries(event)
+	if err != nil {
+		return nil, err
+	}
+	return utils.WriteICalendar(calendarProdID, entries), nil
+}
+
+// ExportUserCalendar renders every confirmed event the user organizes, attends or responded to as iCalendar. Events
+// cancelled after they were confirmed stay in it as cancelled, so that subscribed calendars remove them
+func (s *calendarService) ExportUserCalendar(ctx context.Context, userID int64) ([]byte, error) {
+	if err := authorizeUserCalendar(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	events, err := s.eventRepo.ListUserEvents(ctx, userID, []string{model.EventStatusConfirmed, model.EventStatusCancelled})
+	if err != nil {
+		log.Println("Error listing user events:", err)
+		return nil, err
+	}
+
+	entries := []utils.ICalEvent{}
+	for _, event := range events {
+		// an event cancelled before it was confirmed never was in the calendar
+		if event.ConfirmedSlot == nil {
+			continue
+		}
+		// the calendar belongs to the caller, the events in it need no check of their own
+		detail, err := loadEventDetail(ctx, s.eventRepo, event)
+		if err != nil {
+			return nil, err
+		}
+		eventEntries, err := s.eventEntries(detail)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, eventEntries...)
+	}
+	return utils.WriteICalendar(calendarProdID, entries), nil
+}
+
+// eventEntries maps an event to its VEVENTs
+func (s *calendarService) eventEntries(event model.EventDetail) ([]utils.ICalEvent, error) {
+	template := utils.ICalEvent{
+		Stamp:     event.UpdatedAt,
+		Summary:   event.Title,
+		Organizer: s.userAddress(event.OrganizerID),
+	}
+	if template.Stamp.IsZero() {
+		template.Stamp = now()
+	}
+	cancelled := event.Status == model.EventStatusCancelled
+	if cancelled {
+		template.Sequence = 1
+	}
+	for _, attendee := range event.Attendees {
+		address := s.userAddress(attendee.UserID)
+		address.Required = attendee.Required
+		template.Attendees = append(template.Attendees, address)
+	}
+
+	if event.ConfirmedSlot == nil {
+		// not scheduled yet, every proposed slot is a tentative hold that does not make anyone busy
+		entries := make([]utils.ICalEvent, 0, len(event.ProposedSlots))
+		for _, slot := range event.ProposedSlots {
+			entry := template
+			entry.UID = fmt.Sprintf("event-%d-slot-%d@%s", event.ID, slot.ID, s.domain)
+			entry.Start = slot.StartTime
+			entry.End = slot.EndTime
+			entry.Status = utils.ICalStatusTentative
+			if cancelled {
+				entry.Status = utils.ICalStatusCancelled
+			}
+			entry.Transparent = true
+			entries = append(entries, entry)
+		}
+		return entries, nil
+	}
+
+	entry := template
+	entry.UID = fmt.Sprintf("event-%d@%s", event.ID, s.domain)
+	entry.Start = event.ConfirmedSlot.StartTime
+	entry.End = event.ConfirmedSlot.EndTime
+	entry.Status = utils.ICalStatusConfirmed
+	if cancelled {
+		entry.Status = utils.ICalStatusCancelled
+	}
+	if event.RRule == "" {
+		return []utils.ICalEvent{entry}, nil
+	}
+
+	// a series repeats on the wall clock of the event, cancelled occurrences are excluded and moved ones overridden
+	loc, err := utils.LoadTimeZone(event.TimeZone)
+	if err != nil {
+		log.Println("Error loading event time zone:", err)
+		return nil, err
+	}
+	entry.TimeZone = loc
+	entry.RRule = event.RRule
+
+	entries := []utils.ICalEvent{}
+	for _, exception := range event.Exceptions {
+		original, err := occurrenceStart(entry.Start, exception.OccurrenceDate, loc)
+		if err != nil {
+			log.Println("Error parsing occurrence date:", err)
+			return nil, err
+		}
+		if exception.Cancelled {
+			entry.ExDates = append(entry.ExDates, original)
+			continue
+		}
+		moved := template
+		moved.UID = entry.UID
+		moved.RecurrenceID = original
+		moved.Start = exception.StartTime
+		moved.End = exception.EndTime
+		moved.TimeZone = loc
+		moved.Status = entry.Status
+		entries = append(entries, moved)
+	}
+	return append([]utils.ICalEvent{entry}, entries...), nil
+}
+
+// occurrenceStart returns when the occurrence on date of a series starting at seriesStart begins, on the wall clock of loc
+func occurrenceStart(seriesStart time.Time, date string, loc *time.Location) (time.Time, error) {
+	day, err := time.ParseInLocation(time.DateOnly, date, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	start := seriesStart.In(loc)
+	return time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), start.Second(), 0, loc), nil
+}
+
+// userAddress is the calendar address of a user, users are only known by id so it is derived from the domain
+func (s *calendarService) userAddress(userID int64) utils.ICalAttendee {
+	return utils.ICalAttendee{
+		Address: fmt.Sprintf("mailto:user-%d@%s", userID, s.domain),
+		Name:    fmt.Sprintf("User %d", userID),
+	}
+}
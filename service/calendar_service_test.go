@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	mock_repository "github.com/rahulshewale153/meeting-scheduler-api/mock/repository"
+	mock_service "github.com/rahulshewale153/meeting-scheduler-api/mock/service"
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportEvent(t *testing.T) {
+	mockEventService := new(mock_service.MockEventService)
+	mockEventRepo := new(mock_repository.MockEventRepository)
+	service := NewCalendarService(mockEventService, mockEventRepo, "example.com")
+	ctx := context.Background()
+	updatedAt := time.Date(2025, 07, 10, 8, 0, 0, 0, time.UTC)
+
+	t.Run("Function must return an error when the event is not found", func(t *testing.T) {
+		mockEventService.On("GetEvent", ctx, int64(1)).Return(model.EventDetail{}, &model.NotFoundError{Resource: "event", ID: 1}).Once()
+
+		_, err := service.ExportEvent(ctx, 1)
+		assert.ErrorIs(t, err, model.ErrNotFound)
+	})
+
+	t.Run("Function must write a tentative transparent VEVENT per proposed slot before confirmation", func(t *testing.T) {
+		mockEventService.On("GetEvent", ctx, int64(2)).Return(model.EventDetail{
+			Event: model.Event{ID: 2, Title: "Design review, round 2", OrganizerID: 7, Status: model.EventStatusPolling, UpdatedAt: updatedAt},
+			ProposedSlots: []model.EventSlot{
+				{ID: 11, StartTime: time.Date(2025, 07, 14, 9, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 14, 10, 0, 0, 0, time.UTC)},
+				{ID: 12, StartTime: time.Date(2025, 07, 15, 9, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 15, 10, 0, 0, 0, time.UTC)},
+			},
+			Attendees: []model.Attendee{{UserID: 8, Required: true}, {UserID: 9}},
+		}, nil).Once()
+
+		calendar, err := service.ExportEvent(ctx, 2)
+		assert.NoError(t, err)
+		ics := string(calendar)
+		assert.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+		assert.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+		assert.Equal(t, 2, strings.Count(ics, "BEGIN:VEVENT"))
+		assert.Contains(t, ics, "UID:event-2-slot-11@example.com\r\n")
+		assert.Contains(t, ics, "UID:event-2-slot-12@example.com\r\n")
+		assert.Contains(t, ics, "DTSTAMP:20250710T080000Z\r\n")
+		assert.Contains(t, ics, "DTSTART:20250714T090000Z\r\nDTEND:20250714T100000Z\r\n")
+		assert.Contains(t, ics, "SUMMARY:Design review\\, round 2\r\n")
+		assert.Equal(t, 2, strings.Count(ics, "STATUS:TENTATIVE\r\nTRANSP:TRANSPARENT\r\n"))
+		assert.Contains(t, ics, `ORGANIZER;CN="User 7":mailto:user-7@example.com`+"\r\n")
+		assert.Contains(t, ics, `ATTENDEE;ROLE=REQ-PARTICIPANT;CN="User 8":mailto:user-8@example.com`+"\r\n")
+		assert.Contains(t, ics, `ATTENDEE;ROLE=OPT-PARTICIPANT;CN="User 9":mailto:user-9@example.com`+"\r\n")
+	})
+
+	t.Run("Function must write one confirmed VEVENT at the confirmed slot", func(t *testing.T) {
+		confirmed := model.EventSlot{StartTime: time.Date(2025, 07, 15, 9, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 15, 10, 0, 0, 0, time.UTC)}
+		mockEventService.On("GetEvent", ctx, int64(3)).Return(model.EventDetail{
+			Event:         model.Event{ID: 3, Title: "Sync", OrganizerID: 7, Status: model.EventStatusConfirmed, ConfirmedSlot: &confirmed, UpdatedAt: updatedAt},
+			ProposedSlots: []model.EventSlot{{ID: 12, StartTime: confirmed.StartTime, EndTime: confirmed.EndTime}},
+		}, nil).Once()
+
+		calendar, err := service.ExportEvent(ctx, 3)
+		assert.NoError(t, err)
+		ics := string(calendar)
+		assert.Equal(t, 1, strings.Count(ics, "BEGIN:VEVENT"))
+		assert.Contains(t, ics, "UID:event-3@example.com\r\n")
+		assert.Contains(t, ics, "DTSTART:20250715T090000Z\r\n")
+		assert.Contains(t, ics, "STATUS:CONFIRMED\r\nTRANSP:OPAQUE\r\n")
+		assert.Contains(t, ics, "SEQUENCE:0\r\n")
+		assert.NotContains(t, ics, "VTIMEZONE")
+	})
+
+	t.Run("Function must write a recurring event on the wall clock of its zone with its exceptions", func(t *testing.T) {
+		berlin, _ := time.LoadLocation("Europe/Berlin")
+		confirmed := model.EventSlot{StartTime: time.Date(2025, 10, 20, 9, 0, 0, 0, berlin), EndTime: time.Date(2025, 10, 20, 10, 0, 0, 0, berlin)}
+		mockEventService.On("GetEvent", ctx, int64(4)).Return(model.EventDetail{
+			Event: model.Event{ID: 4, Title: "Standup", OrganizerID: 7, TimeZone: "Europe/Berlin", RRule: "FREQ=WEEKLY",
+				Status: model.EventStatusConfirmed, ConfirmedSlot: &confirmed, UpdatedAt: updatedAt},
+			Exceptions: []model.EventException{
+				{OccurrenceDate: "2025-10-27", Cancelled: true},
+				{OccurrenceDate: "2025-11-03", StartTime: time.Date(2025, 11, 4, 14, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 11, 4, 15, 0, 0, 0, time.UTC)},
+			},
+		}, nil).Once()
+
+		calendar, err := service.ExportEvent(ctx, 4)
+		assert.NoError(t, err)
+		ics := string(calendar)
+		assert.Contains(t, ics, "BEGIN:VTIMEZONE\r\nTZID:Europe/Berlin\r\n")
+		// observances follow the offset changes after the series starts, to winter time first and back to summer time
+		assert.Contains(t, ics, "BEGIN:STANDARD\r\nDTSTART:20251026T030000\r\nTZOFFSETFROM:+0200\r\nTZOFFSETTO:+0100\r\n")
+		assert.Contains(t, ics, "BEGIN:DAYLIGHT\r\nDTSTART:20260329T020000\r\nTZOFFSETFROM:+0100\r\nTZOFFSETTO:+0200\r\n")
+		assert.Contains(t, ics, "DTSTART;TZID=Europe/Berlin:20251020T090000\r\n")
+		assert.Contains(t, ics, "RRULE:FREQ=WEEKLY\r\n")
+		// the occurrence after the change to winter time keeps 09:00 local time
+		assert.Contains(t, ics, "EXDATE;TZID=Europe/Berlin:20251027T090000\r\n")
+		assert.Equal(t, 2, strings.Count(ics, "UID:event-4@example.com\r\n"))
+		assert.Contains(t, ics, "DTSTART;TZID=Europe/Berlin:20251104T150000\r\nDTEND;TZID=Europe/Berlin:20251104T160000\r\nRECURRENCE-ID;TZID=Europe/Berlin:20251103T090000\r\n")
+	})
+
+	t.Run("Function must fold lines longer than 75 octets", func(t *testing.T) {
+		mockEventService.On("GetEvent", ctx, int64(5)).Return(model.EventDetail{
+			Event:         model.Event{ID: 5, Title: strings.Repeat("é", 60), OrganizerID: 7, Status: model.EventStatusDraft, UpdatedAt: updatedAt},
+			ProposedSlots: []model.EventSlot{{ID: 1, StartTime: updatedAt, EndTime: updatedAt.Add(time.Hour)}},
+		}, nil).Once()
+
+		calendar, err := service.ExportEvent(ctx, 5)
+		assert.NoError(t, err)
+		for _, line := range strings.Split(string(calendar), "\r\n") {
+			assert.LessOrEqual(t, len(line), 75)
+		}
+		unfolded := strings.ReplaceAll(string(calendar), "\r\n ", "")
+		assert.Contains(t, unfolded, "SUMMARY:"+strings.Repeat("é", 60)+"\r\n")
+	})
+}
+
+func TestExportUserCalendar(t *testing.T) {
+	mockEventService := new(mock_service.MockEventService)
+	mockEventRepo := new(mock_repository.MockEventRepository)
+	service := NewCalendarService(mockEventService, mockEventRepo, "")
+	ctx := context.Background()
+	statuses := []string{model.EventStatusConfirmed, model.EventStatusCancelled}
+
+	t.Run("Function must return an error when the events cannot be listed", func(t *testing.T) {
+		mockEventRepo.On("ListUserEvents", ctx, int64(7), statuses).Return([]model.Event{}, assert.AnError).Once()
+
+		_, err := service.ExportUserCalendar(ctx, 7)
+		assert.Error(t, err)
+	})
+
+	t.Run("Function must write every confirmed event of the user", func(t *testing.T) {
+		first := model.EventSlot{StartTime: time.Date(2025, 07, 15, 9, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 15, 10, 0, 0, 0, time.UTC)}
+		second := model.EventSlot{StartTime: time.Date(2025, 07, 16, 9, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 16, 10, 0, 0, 0, time.UTC)}
+		mockEventRepo.On("ListUserEvents", ctx, int64(7), statuses).Return([]model.Event{
+			{ID: 3, Title: "Sync", OrganizerID: 7, Status: model.EventStatusConfirmed, ConfirmedSlot: &first},
+			{ID: 6, Title: "Retro", OrganizerID: 2, Status: model.EventStatusConfirmed, ConfirmedSlot: &second},
+		}, nil).Once()
+		mockEventRepo.On("GetEventSlots", ctx, int64(3)).Return([]model.EventSlot{first}, nil).Once()
+		mockEventRepo.On("GetEventAttendees", ctx, int64(3)).Return([]model.Attendee{}, nil).Once()
+		mockEventRepo.On("GetEventSlots", ctx, int64(6)).Return([]model.EventSlot{second}, nil).Once()
+		mockEventRepo.On("GetEventAttendees", ctx, int64(6)).Return([]model.Attendee{{UserID: 9}}, nil).Once()
+
+		calendar, err := service.ExportUserCalendar(ctx, 7)
+		assert.NoError(t, err)
+		ics := string(calendar)
+		assert.Equal(t, 2, strings.Count(ics, "BEGIN:VEVENT"))
+		assert.Contains(t, ics, "UID:event-3@meeting-scheduler.local\r\n")
+		assert.Contains(t, ics, "UID:event-6@meeting-scheduler.local\r\n")
+		// events without an update time are stamped with the current time
+		assert.Contains(t, ics, "DTSTAMP:20250701T000000Z\r\n")
+	})
+
+	t.Run("Function must write events cancelled after confirmation as cancelled with a new sequence", func(t *testing.T) {
+		slot := model.EventSlot{StartTime: time.Date(2025, 07, 15, 9, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 15, 10, 0, 0, 0, time.UTC)}
+		mockEventRepo.On("ListUserEvents", ctx, int64(7), statuses).Return([]model.Event{
+			{ID: 3, Title: "Sync", OrganizerID: 7, Status: model.EventStatusCancelled, ConfirmedSlot: &slot},
+			{ID: 4, Title: "Draft", OrganizerID: 7, Status: model.EventStatusCancelled},
+		}, nil).Once()
+		mockEventRepo.On("GetEventSlots", ctx, int64(3)).Return([]model.EventSlot{slot}, nil).Once()
+		mockEventRepo.On("GetEventAttendees", ctx, int64(3)).Return([]model.Attendee{}, nil).Once()
+
+		calendar, err := service.ExportUserCalendar(ctx, 7)
+		assert.NoError(t, err)
+		ics := string(calendar)
+		assert.Equal(t, 1, strings.Count(ics, "BEGIN:VEVENT"))
+		assert.Contains(t, ics, "UID:event-3@meeting-scheduler.local\r\n")
+		assert.Contains(t, ics, "STATUS:CANCELLED\r\n")
+		assert.Contains(t, ics, "SEQUENCE:1\r\n")
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return a forbidden error when another user reads the calendar", func(t *testing.T) {
+		_, err := service.ExportUserCalendar(organizer, 7)
+		assert.ErrorIs(t, err, model.ErrForbidden)
+	})
+
+	t.Run("Function must write an empty calendar for a user without confirmed events", func(t *testing.T) {
+		mockEventRepo.On("ListUserEvents", ctx, int64(8), statuses).Return([]model.Event{}, nil).Once()
+
+		calendar, err := service.ExportUserCalendar(ctx, 8)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(calendar), "BEGIN:VEVENT")
+		assert.Contains(t, string(calendar), "BEGIN:VCALENDAR")
+	})
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/rahulshewale153/meeting-scheduler-api/repository"
+	"github.com/rahulshewale153/meeting-scheduler-api/utils"
+)
+
+const (
+	// defaultConflictRange is how far ahead conflicts are looked for when no end is given
+	defaultConflictRange = 30 * 24 * time.Hour
+	maxConflictRange     = 366 * 24 * time.Hour
+)
+
+type conflictService struct {
+	eventRepo repository.EventRepositoryI
+}
+
+// NewConflictService creates a new instance of conflictService
+func NewConflictService(eventRepo repository.EventRepositoryI) ConflictServiceI {
+	return &conflictService{eventRepo: eventRepo}
+}
+
+// GetUserConflicts returns every pair of confirmed meetings of the user that overlap between from and to, ordered by
+// the start of the overlap. A zero from is now and a zero to is defaultConflictRange after from
+func (s *conflictService) GetUserConflicts(ctx context.Context, userID int64, from time.Time, to time.Time) ([]model.MeetingConflict, error) {
+	if err := authorizeUserCalendar(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	from, to, err := lookAheadRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	meetings, err := confirmedMeetings(ctx, s.eventRepo, []int64{userID}, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return overlappingMeetings(meetings[userID]), nil
+}
+
+// lookAheadRange fills in a zero from with now and a zero to with defaultConflictRange after from, and checks that
+// the range is not empty nor longer than maxConflictRange
+func lookAheadRange(from time.Time, to time.Time) (time.Time, time.Time, error) {
+	if from.IsZero() {
+		from = now()
+	}
+	if to.IsZero() {
+		to = from.Add(defaultConflictRange)
+	}
+	validationErr := &utils.ValidationError{}
+	if !to.After(from) {
+		validationErr.Add("to", to, "to must be after from")
+	} else if to.Sub(from) > maxConflictRange {
+		validationErr.Add("to", to, fmt.Sprintf("to must be at most %d days after from", int(maxConflictRange.Hours()/24)))
+	}
+	return from, to, validationErr.OrNil()
+}
+
+// confirmedMeetings returns the occurrences of the confirmed events of each user that overlap [from, to), ordered by
+// start. Moved occurrences of a recurring event are at their new time and cancelled ones are left out
+func confirmedMeetings(ctx context.Context, eventRepo repository.EventRepositoryI, userIDs []int64, from time.Time, to time.Time) (map[int64][]model.Meeting, error) {
+	userEvents, err := eventRepo.ListConfirmedUserEvents(ctx, userIDs, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// users often share events, each one is expanded once
+	expanded := make(map[int64][]model.Meeting)
+	meetings := make(map[int64][]model.Meeting, len(userEvents))
+	for userID, events := range userEvents {
+		for _, event := range events {
+			occurrences, ok := expanded[event.ID]
+			if !ok {
+				if occurrences, err = eventMeetings(ctx, eventRepo, event, from, to); err != nil {
+					return nil, err
+				}
+				expanded[event.ID] = occurrences
+			}
+			meetings[userID] = append(meetings[userID], occurrences...)
+		}
+		sort.SliceStable(meetings[userID], func(i, j int) bool {
+			return meetings[userID][i].Slot.StartTime.Before(meetings[userID][j].Slot.StartTime)
+		})
+	}
+	return meetings, nil
+}
+
+// eventMeetings returns the occurrences of a confirmed event that overlap [from, to)
+func eventMeetings(ctx context.Context, eventRepo repository.EventRepositoryI, event model.Event, from time.Time, to time.Time) ([]model.Meeting, error) {
+	meetings := []model.Meeting{}
+	if event.ConfirmedSlot == nil {
+		return meetings, nil
+	}
+	overlaps := func(slot model.EventSlot) bool {
+		return slot.StartTime.Before(to) && slot.EndTime.After(from)
+	}
+	if event.RRule == "" {
+		if overlaps(*event.ConfirmedSlot) {
+			meetings = append(meetings, model.Meeting{EventID: event.ID, Title: event.Title, Slot: *event.ConfirmedSlot})
+		}
+		return meetings, nil
+	}
+
+	rrule, err := utils.ParseRRule(event.RRule)
+	if err != nil {
+		log.Println("Error parsing event recurrence rule:", err)
+		return nil, err
+	}
+	loc, err := utils.LoadTimeZone(event.TimeZone)
+	if err != nil {
+		log.Println("Error loading event time zone:", err)
+		return nil, err
+	}
+	exceptions, err := eventRepo.GetEventExceptions(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+	skipped := make(map[string]bool, len(exceptions))
+	for _, exception := range exceptions {
+		skipped[exception.OccurrenceDate] = true
+		moved := model.EventSlot{StartTime: exception.StartTime, EndTime: exception.EndTime, TimeZone: event.TimeZone}
+		if !exception.Cancelled && overlaps(moved) {
+			meetings = append(meetings, model.Meeting{EventID: event.ID, Title: event.Title, Slot: moved})
+		}
+	}
+
+	duration := event.ConfirmedSlot.EndTime.Sub(event.ConfirmedSlot.StartTime)
+	rrule.Each(event.ConfirmedSlot.StartTime.In(loc), func(start time.Time) bool {
+		if !start.Before(to) {
+			return false
+		}
+		slot := model.EventSlot{StartTime: start, EndTime: start.Add(duration), TimeZone: event.TimeZone}
+		if !skipped[start.Format(time.DateOnly)] && overlaps(slot) {
+			meetings = append(meetings, model.Meeting{EventID: event.ID, Title: event.Title, Slot: slot})
+		}
+		return true
+	})
+	return meetings, nil
+}
+
+// overlappingMeetings returns every pair of the meetings, sorted by start, that overlap
+func overlappingMeetings(meetings []model.Meeting) []model.MeetingConflict {
+	conflicts := []model.MeetingConflict{}
+	for i, first := range meetings {
+		for _, second := range meetings[i+1:] {
+			if !second.Slot.StartTime.Before(first.Slot.EndTime) {
+				break
+			}
+			conflicts = append(conflicts, meetingConflict(first, second))
+		}
+	}
+	sort.SliceStable(conflicts, func(i, j int) bool {
+		return conflicts[i].Overlap.StartTime.Before(conflicts[j].Overlap.StartTime)
+	})
+	return conflicts
+}
+
+// meetingConflict returns the conflict of two overlapping meetings
+func meetingConflict(first model.Meeting, second model.Meeting) model.MeetingConflict {
+	start, end := first.Slot.StartTime, first.Slot.EndTime
+	if second.Slot.StartTime.After(start) {
+		start = second.Slot.StartTime
+	}
+	if second.Slot.EndTime.Before(end) {
+		end = second.Slot.EndTime
+	}
+	return model.MeetingConflict{
+		First:   first,
+		Second:  second,
+		Overlap: model.EventSlot{StartTime: start, EndTime: end, TimeZone: first.Slot.TimeZone},
+	}
+}
+
+// confirmedRange returns the range the occurrences of the event confirmed for slot are checked in, it reaches
+// maxConflictRange past the slot for a recurring event
+func confirmedRange(event model.Event, slot model.EventSlot) (time.Time, time.Time) {
+	if event.RRule != "" {
+		return slot.StartTime, slot.StartTime.Add(maxConflictRange)
+	}
+	return slot.StartTime, slot.EndTime
+}
+
+// confirmedOccurrences returns the occurrences of the event once confirmed for slot within confirmedRange
+func confirmedOccurrences(ctx context.Context, eventRepo repository.EventRepositoryI, event model.Event, slot model.EventSlot) (time.Time, time.Time, []model.Meeting, error) {
+	from, to := confirmedRange(event, slot)
+	confirmed := event
+	confirmed.Status = model.EventStatusConfirmed
+	confirmed.ConfirmedSlot = &slot
+	meetings, err := eventMeetings(ctx, eventRepo, confirmed, from, to)
+	return from, to, meetings, err
+}
+
+// checkDoubleBooking locks the participants and their confirmed events that may overlap slot and returns a
+// ConflictError listing the meetings that overlap the event confirmed for slot. It must run in the transaction
+// confirming the event, a concurrent confirmation involving one of the participants then waits for it on the lock of
+// that participant and sees the slot it confirmed
+func checkDoubleBooking(ctx context.Context, eventRepo repository.EventRepositoryI, tx *sql.Tx, event model.Event, participants []int64, slot model.EventSlot) error {
+	if err := eventRepo.LockUsers(ctx, tx, participants); err != nil {
+		return err
+	}
+	from, to := confirmedRange(event, slot)
+	userEvents, err := eventRepo.LockUserEvents(ctx, tx, participants, from, to)
+	if err != nil {
+		return err
+	}
+	_, _, occurrences, err := confirmedOccurrences(ctx, eventRepo, event, slot)
+	if err != nil {
+		return err
+	}
+
+	conflicts := []model.MeetingConflict{}
+	expanded := make(map[int64][]model.Meeting)
+	for _, userID := range participants {
+		for _, other := range userEvents[userID] {
+			meetings, ok := expanded[other.ID]
+			if !ok {
+				if meetings, err = eventMeetings(ctx, eventRepo, other, from, to); err != nil {
+					return err
+				}
+				expanded[other.ID] = meetings
+			}
+			for _, occurrence := range occurrences {
+				for _, meeting := range meetings {
+					if meeting.Slot.StartTime.Before(occurrence.Slot.EndTime) && meeting.Slot.EndTime.After(occurrence.Slot.StartTime) {
+						conflict := meetingConflict(occurrence, meeting)
+						conflict.UserID = userID
+						conflicts = append(conflicts, conflict)
+					}
+				}
+			}
+		}
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	listed := make(map[int64]bool)
+	clashes := []string{}
+	for _, conflict := range conflicts {
+		if !listed[conflict.Second.EventID] {
+			listed[conflict.Second.EventID] = true
+			clashes = append(clashes, fmt.Sprintf("event %d %q of user %d", conflict.Second.EventID, conflict.Second.Title, conflict.UserID))
+		}
+	}
+	return &model.ConflictError{
+		Message:   fmt.Sprintf("event %d would double-book its participants with confirmed %s", event.ID, strings.Join(clashes, ", ")),
+		Conflicts: conflicts,
+	}
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mock_repository "github.com/rahulshewale153/meeting-scheduler-api/mock/repository"
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/rahulshewale153/meeting-scheduler-api/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUserConflicts(t *testing.T) {
+	mockEventRepo := new(mock_repository.MockEventRepository)
+	conflictService := NewConflictService(mockEventRepo)
+	ctx := context.Background()
+	at := func(day, hour, minute int) time.Time { return time.Date(2025, 07, day, hour, minute, 0, 0, time.UTC) }
+	from := at(14, 0, 0)
+	to := at(21, 0, 0)
+
+	t.Run("Function must reject a range that ends before it starts", func(t *testing.T) {
+		_, err := conflictService.GetUserConflicts(ctx, 3, to, from)
+		var validationErr *utils.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "to", validationErr.Errors.Field[0].Name)
+	})
+
+	t.Run("Function must reject a range longer than the maximum", func(t *testing.T) {
+		_, err := conflictService.GetUserConflicts(ctx, 3, from, from.Add(maxConflictRange+time.Hour))
+		var validationErr *utils.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+
+	t.Run("Function must return a forbidden error for the conflicts of another user", func(t *testing.T) {
+		_, err := conflictService.GetUserConflicts(organizer, 3, from, to)
+		assert.ErrorIs(t, err, model.ErrForbidden)
+	})
+
+	t.Run("Function must look ahead from now by default", func(t *testing.T) {
+		mockEventRepo.On("ListConfirmedUserEvents", ctx, []int64{3}, now(), now().Add(defaultConflictRange)).Return(map[int64][]model.Event{}, assert.AnError).Once()
+
+		_, err := conflictService.GetUserConflicts(ctx, 3, time.Time{}, time.Time{})
+		assert.ErrorIs(t, err, assert.AnError)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return the overlapping meetings, expanding recurring events", func(t *testing.T) {
+		planning := model.Event{ID: 4, Title: "Planning", Status: model.EventStatusConfirmed, ConfirmedSlot: &model.EventSlot{StartTime: at(15, 9, 0), EndTime: at(15, 10, 0)}}
+		review := model.Event{ID: 5, Title: "Review", Status: model.EventStatusConfirmed, ConfirmedSlot: &model.EventSlot{StartTime: at(17, 14, 0), EndTime: at(17, 15, 0)}}
+		// daily at 09:30 from the 10th, the 15th is cancelled and the 16th moved to 14:30 on the 17th
+		standup := model.Event{ID: 6, Title: "Standup", TimeZone: "UTC", RRule: "FREQ=DAILY", Status: model.EventStatusConfirmed, ConfirmedSlot: &model.EventSlot{StartTime: at(10, 9, 30), EndTime: at(10, 10, 0)}}
+		mockEventRepo.On("ListConfirmedUserEvents", ctx, []int64{3}, from, to).Return(map[int64][]model.Event{3: {planning, review, standup}}, nil).Once()
+		mockEventRepo.On("GetEventExceptions", ctx, int64(6)).Return([]model.EventException{
+			{OccurrenceDate: "2025-07-15", Cancelled: true},
+			{OccurrenceDate: "2025-07-16", StartTime: at(17, 14, 30), EndTime: at(17, 15, 0)},
+		}, nil).Once()
+
+		conflicts, err := conflictService.GetUserConflicts(ctx, 3, from, to)
+		assert.NoError(t, err)
+		assert.Equal(t, []model.MeetingConflict{
+			{
+				First:   model.Meeting{EventID: 5, Title: "Review", Slot: model.EventSlot{StartTime: at(17, 14, 0), EndTime: at(17, 15, 0)}},
+				Second:  model.Meeting{EventID: 6, Title: "Standup", Slot: model.EventSlot{StartTime: at(17, 14, 30), EndTime: at(17, 15, 0), TimeZone: "UTC"}},
+				Overlap: model.EventSlot{StartTime: at(17, 14, 30), EndTime: at(17, 15, 0)},
+			},
+		}, conflicts)
+		mockEventRepo.AssertExpectations(t)
+	})
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"log"
 
 	"github.com/rahulshewale153/meeting-scheduler-api/model"
@@ -9,20 +11,78 @@ import (
 	"github.com/rahulshewale153/meeting-scheduler-api/utils"
 )
 
+const (
+	defaultEventPageSize  = 20
+	maxEventPageSize      = 100
+	defaultAttendeeWeight = 1
+)
+
 type eventService struct {
-	transactionManager repository.TransactionManagerI
-	eventRepo          repository.EventRepositoryI
+	transactionManager    repository.TransactionManagerI
+	eventRepo             repository.EventRepositoryI
+	resourceRepo          repository.ResourceRepositoryI
+	recommendationService RecommendationServiceI
 }
 
-func NewEventService(transactionManager repository.TransactionManagerI, eventRepo repository.EventRepositoryI) EventServiceI {
+// NewEventService creates a new instance of eventService, the recommendation service picks the slot of a confirmation without one
+func NewEventService(transactionManager repository.TransactionManagerI, eventRepo repository.EventRepositoryI, resourceRepo repository.ResourceRepositoryI, recommendationService RecommendationServiceI) EventServiceI {
 	return &eventService{
-		transactionManager: transactionManager,
-		eventRepo:          eventRepo,
+		transactionManager:    transactionManager,
+		eventRepo:             eventRepo,
+		resourceRepo:          resourceRepo,
+		recommendationService: recommendationService,
+	}
+}
+
+// checkEventOpen returns a conflict for an event that was confirmed or cancelled and can no longer be changed
+func checkEventOpen(event model.Event) error {
+	switch event.Status {
+	case model.EventStatusConfirmed, model.EventStatusCancelled:
+		return &model.ConflictError{Message: fmt.Sprintf("event %d is %s and can no longer be changed", event.ID, event.Status)}
+	}
+	return nil
+}
+
+// checkEventNotCancelled returns a conflict for a cancelled event. Occurrences of a confirmed recurring event can
+// still be moved or cancelled one by one
+func checkEventNotCancelled(event model.Event) error {
+	if event.Status == model.EventStatusCancelled {
+		return &model.ConflictError{Message: fmt.Sprintf("event %d is cancelled and can no longer be changed", event.ID)}
+	}
+	return nil
+}
+
+// lockOpenEvent locks the row of the event for the rest of the transaction and checks that the event is still open.
+// The event may have been confirmed or cancelled since it was read outside the transaction
+func lockOpenEvent(ctx context.Context, tx *sql.Tx, eventRepo repository.EventRepositoryI, eventID int64) (model.Event, error) {
+	event, err := eventRepo.LockEvent(ctx, tx, eventID)
+	if err != nil {
+		log.Println("Error locking event:", err)
+		return model.Event{}, err
+	}
+	return event, checkEventOpen(event)
+}
+
+// lockUncancelledEvent locks the row of the event for the rest of the transaction and checks that it was not
+// cancelled meanwhile
+func lockUncancelledEvent(ctx context.Context, tx *sql.Tx, eventRepo repository.EventRepositoryI, eventID int64) error {
+	event, err := eventRepo.LockEvent(ctx, tx, eventID)
+	if err != nil {
+		log.Println("Error locking event:", err)
+		return err
 	}
+	return checkEventNotCancelled(event)
 }
 
 // InsertEvent inserts a new event into the database.
 func (s *eventService) InsertEvent(ctx context.Context, createEventReq model.EventRequest) (int64, error) {
+	if err := validateEventRequest(createEventReq); err != nil {
+		return 0, err
+	}
+	if err := authorizeOrganizer(ctx, createEventReq.OrganizerID); err != nil {
+		return 0, err
+	}
+
 	tx, err := s.transactionManager.BeginTransaction(ctx)
 	if err != nil {
 		return 0, err
@@ -41,6 +101,9 @@ func (s *eventService) InsertEvent(ctx context.Context, createEventReq model.Eve
 		}
 	}()
 
+	if createEventReq.Event.TimeZone == "" {
+		createEventReq.Event.TimeZone = utils.DefaultTimeZone
+	}
 	eventID, err := s.eventRepo.InsertEvent(ctx, tx, createEventReq.Event)
 	if err != nil {
 		return 0, err
@@ -48,6 +111,9 @@ func (s *eventService) InsertEvent(ctx context.Context, createEventReq model.Eve
 
 	//insert event slot
 	for _, slot := range createEventReq.ProposedSlots {
+		if slot.TimeZone == "" {
+			slot.TimeZone = createEventReq.Event.TimeZone
+		}
 		slot.StartTime, err = utils.ConvertTimeToUTC(ctx, slot.StartTime)
 		if err != nil {
 			log.Println("Error converting start time to UTC:", err)
@@ -65,11 +131,52 @@ func (s *eventService) InsertEvent(ctx context.Context, createEventReq model.Eve
 			return 0, err
 		}
 	}
+
+	//insert event attendees
+	if err = s.insertAttendees(ctx, tx, eventID, createEventReq.Attendees); err != nil {
+		return 0, err
+	}
 	return eventID, nil
 }
 
+// insertAttendees stores the attendees of an event, optional attendees without a weight count as 1
+func (s *eventService) insertAttendees(ctx context.Context, tx *sql.Tx, eventID int64, attendees []model.Attendee) error {
+	for _, attendee := range attendees {
+		if attendee.Weight == 0 {
+			attendee.Weight = defaultAttendeeWeight
+		}
+		if err := s.eventRepo.InsertEventAttendee(ctx, tx, eventID, attendee); err != nil {
+			log.Println("Error inserting event attendee:", err)
+			return err
+		}
+	}
+	return nil
+}
+
 // UpdateEvent updates an existing event in the database.
 func (s *eventService) UpdateEvent(ctx context.Context, updateEventReq model.EventRequest) error {
+	if err := validateEventRequest(updateEventReq); err != nil {
+		return err
+	}
+
+	// make sure the event exists and is still open before touching it
+	event, err := s.eventRepo.GetEvent(ctx, updateEventReq.Event.ID)
+	if err != nil {
+		log.Println("Error getting event:", err)
+		return err
+	}
+	if err := authorizeEventChange(ctx, event); err != nil {
+		return err
+	}
+	if updateEventReq.OrganizerID != event.OrganizerID {
+		if err := authorizeOrganizer(ctx, updateEventReq.OrganizerID); err != nil {
+			return err
+		}
+	}
+	if err := checkEventOpen(event); err != nil {
+		return err
+	}
+
 	tx, err := s.transactionManager.BeginTransaction(ctx)
 	if err != nil {
 		return err
@@ -88,7 +195,13 @@ func (s *eventService) UpdateEvent(ctx context.Context, updateEventReq model.Eve
 		}
 	}()
 
-	if err := s.eventRepo.UpdateEvent(ctx, tx, updateEventReq.Event); err != nil {
+	if _, err = lockOpenEvent(ctx, tx, s.eventRepo, updateEventReq.Event.ID); err != nil {
+		return err
+	}
+	if updateEventReq.Event.TimeZone == "" {
+		updateEventReq.Event.TimeZone = utils.DefaultTimeZone
+	}
+	if err = s.eventRepo.UpdateEvent(ctx, tx, updateEventReq.Event); err != nil {
 		log.Println("Error updating event:", err)
 		return err
 	}
@@ -113,6 +226,9 @@ func (s *eventService) UpdateEvent(ctx context.Context, updateEventReq model.Eve
 		key := utils.SlotKey(slot)
 		incomingMap[key] = slot
 		if _, ok := existingMap[key]; !ok {
+			if slot.TimeZone == "" {
+				slot.TimeZone = updateEventReq.Event.TimeZone
+			}
 			slot.StartTime, err = utils.ConvertTimeToUTC(ctx, slot.StartTime)
 			if err != nil {
 				log.Println("Error converting start time to UTC:", err)
@@ -142,11 +258,32 @@ func (s *eventService) UpdateEvent(ctx context.Context, updateEventReq model.Eve
 		}
 	}
 
+	// replace the attendees only when the request carries them, an empty list clears them
+	if updateEventReq.Attendees != nil {
+		if err = s.eventRepo.DeleteEventAttendees(ctx, tx, updateEventReq.Event.ID); err != nil {
+			log.Println("Error deleting event attendees:", err)
+			return err
+		}
+		if err = s.insertAttendees(ctx, tx, updateEventReq.Event.ID, updateEventReq.Attendees); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 // DeleteEvent deletes an event from the database.
 func (s *eventService) DeleteEvent(ctx context.Context, eventID int64) error {
+	// make sure the event exists before deleting it
+	event, err := s.eventRepo.GetEvent(ctx, eventID)
+	if err != nil {
+		log.Println("Error getting event:", err)
+		return err
+	}
+	if err := authorizeEventChange(ctx, event); err != nil {
+		return err
+	}
+
 	tx, err := s.transactionManager.BeginTransaction(ctx)
 	if err != nil {
 		return err
@@ -166,16 +303,434 @@ func (s *eventService) DeleteEvent(ctx context.Context, eventID int64) error {
 	}()
 
 	// Delete all event slots associated with the event
-	if err := s.eventRepo.DeleteEventSlots(ctx, tx, eventID); err != nil {
+	if err = s.eventRepo.DeleteAllEventSlots(ctx, tx, eventID); err != nil {
 		log.Println("Error deleting event slots:", err)
 		return err
 	}
 
 	// Delete the event itself
-	if err := s.eventRepo.DeleteEvent(ctx, tx, eventID); err != nil {
+	if err = s.eventRepo.DeleteEvent(ctx, tx, eventID); err != nil {
 		log.Println("Error deleting event:", err)
 		return err
 	}
 
 	return nil
 }
+
+// GetEvent retrieves an event along with its proposed slots, attendees and, for a recurring event, its exceptions.
+func (s *eventService) GetEvent(ctx context.Context, eventID int64) (model.EventDetail, error) {
+	event, err := s.eventRepo.GetEvent(ctx, eventID)
+	if err != nil {
+		log.Println("Error getting event:", err)
+		return model.EventDetail{}, err
+	}
+
+	detail, err := loadEventDetail(ctx, s.eventRepo, event)
+	if err != nil {
+		return model.EventDetail{}, err
+	}
+	if err := authorizeEventRead(ctx, event, detail.Attendees); err != nil {
+		return model.EventDetail{}, err
+	}
+	return detail, nil
+}
+
+// loadEventDetail reads the slots, attendees and exceptions of an event
+func loadEventDetail(ctx context.Context, eventRepo repository.EventRepositoryI, event model.Event) (model.EventDetail, error) {
+	slots, err := eventRepo.GetEventSlots(ctx, event.ID)
+	if err != nil {
+		log.Println("Error getting event slots:", err)
+		return model.EventDetail{}, err
+	}
+	if slots == nil {
+		slots = []model.EventSlot{}
+	}
+
+	attendees, err := eventRepo.GetEventAttendees(ctx, event.ID)
+	if err != nil {
+		log.Println("Error getting event attendees:", err)
+		return model.EventDetail{}, err
+	}
+
+	detail := model.EventDetail{Event: event, ProposedSlots: slots, Attendees: attendees}
+	// only a recurring event has occurrences to cancel or move
+	if event.RRule != "" {
+		detail.Exceptions, err = eventRepo.GetEventExceptions(ctx, event.ID)
+		if err != nil {
+			log.Println("Error getting event exceptions:", err)
+			return model.EventDetail{}, err
+		}
+	}
+	return detail, nil
+}
+
+// ListEvents retrieves a page of events matching the filter.
+func (s *eventService) ListEvents(ctx context.Context, filter model.EventFilter) (model.EventList, error) {
+	if err := authorizeEventList(ctx, &filter); err != nil {
+		return model.EventList{}, err
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultEventPageSize
+	}
+	if filter.Limit > maxEventPageSize {
+		filter.Limit = maxEventPageSize
+	}
+	pageSize := filter.Limit
+
+	// fetch one extra row to find out whether another page exists
+	filter.Limit = pageSize + 1
+	events, err := s.eventRepo.ListEvents(ctx, filter)
+	if err != nil {
+		log.Println("Error listing events:", err)
+		return model.EventList{}, err
+	}
+
+	result := model.EventList{Events: events}
+	if len(events) > pageSize {
+		result.Events = events[:pageSize]
+		result.NextCursor = utils.EncodeCursor(result.Events[pageSize-1].ID)
+	}
+	return result, nil
+}
+
+// AddAttendee invites a user to an existing event.
+func (s *eventService) AddAttendee(ctx context.Context, eventID int64, attendee model.Attendee) error {
+	if attendee.Weight < 0 {
+		validationErr := &utils.ValidationError{}
+		validationErr.Add("weight", attendee.Weight, "weight must not be negative")
+		return validationErr
+	}
+
+	event, err := s.eventRepo.GetEvent(ctx, eventID)
+	if err != nil {
+		log.Println("Error getting event:", err)
+		return err
+	}
+	if err := authorizeEventChange(ctx, event); err != nil {
+		return err
+	}
+
+	tx, err := s.transactionManager.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	// Ensure that the transaction is rolled back or committed properly
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				log.Println("Error rolling back transaction:", rollbackErr)
+			}
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			log.Println("Error committing transaction:", commitErr)
+			return
+		}
+	}()
+
+	// attendees join open events only, a confirmed event checked its attendees for double bookings
+	if _, err = lockOpenEvent(ctx, tx, s.eventRepo, eventID); err != nil {
+		return err
+	}
+	err = s.insertAttendees(ctx, tx, eventID, []model.Attendee{attendee})
+	return err
+}
+
+// RemoveAttendee removes a user from the attendees of an event.
+func (s *eventService) RemoveAttendee(ctx context.Context, eventID int64, userID int64) error {
+	event, err := s.eventRepo.GetEvent(ctx, eventID)
+	if err != nil {
+		log.Println("Error getting event:", err)
+		return err
+	}
+	if err := authorizeAttendeeRemoval(ctx, event, userID); err != nil {
+		return err
+	}
+
+	tx, err := s.transactionManager.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	// Ensure that the transaction is rolled back or committed properly
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				log.Println("Error rolling back transaction:", rollbackErr)
+			}
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			log.Println("Error committing transaction:", commitErr)
+			return
+		}
+	}()
+
+	if err = s.eventRepo.DeleteEventAttendee(ctx, tx, eventID, userID); err != nil {
+		log.Println("Error deleting event attendee:", err)
+		return err
+	}
+	return nil
+}
+
+// ListAttendees retrieves the attendees of an event.
+func (s *eventService) ListAttendees(ctx context.Context, eventID int64) ([]model.Attendee, error) {
+	event, err := s.eventRepo.GetEvent(ctx, eventID)
+	if err != nil {
+		log.Println("Error getting event:", err)
+		return nil, err
+	}
+
+	attendees, err := s.eventRepo.GetEventAttendees(ctx, eventID)
+	if err != nil {
+		log.Println("Error getting event attendees:", err)
+		return nil, err
+	}
+	if err := authorizeEventRead(ctx, event, attendees); err != nil {
+		return nil, err
+	}
+	return attendees, nil
+}
+
+// SetEventException cancels or moves one occurrence of a recurring event, replacing an earlier exception of that date.
+func (s *eventService) SetEventException(ctx context.Context, eventID int64, exception model.EventException) error {
+	event, err := s.eventRepo.GetEvent(ctx, eventID)
+	if err != nil {
+		log.Println("Error getting event:", err)
+		return err
+	}
+	if err := authorizeEventChange(ctx, event); err != nil {
+		return err
+	}
+	if err := validateEventException(event, exception); err != nil {
+		return err
+	}
+
+	tx, err := s.transactionManager.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	// Ensure that the transaction is rolled back or committed properly
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				log.Println("Error rolling back transaction:", rollbackErr)
+			}
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			log.Println("Error committing transaction:", commitErr)
+			return
+		}
+	}()
+
+	if err = lockUncancelledEvent(ctx, tx, s.eventRepo, eventID); err != nil {
+		return err
+	}
+	if !exception.Cancelled {
+		exception.StartTime = exception.StartTime.UTC()
+		exception.EndTime = exception.EndTime.UTC()
+	}
+	if err = s.eventRepo.UpsertEventException(ctx, tx, eventID, exception); err != nil {
+		log.Println("Error upserting event exception:", err)
+		return err
+	}
+	return nil
+}
+
+// RemoveEventException restores one occurrence of a recurring event to its regular time.
+func (s *eventService) RemoveEventException(ctx context.Context, eventID int64, occurrenceDate string) error {
+	event, err := s.eventRepo.GetEvent(ctx, eventID)
+	if err != nil {
+		log.Println("Error getting event:", err)
+		return err
+	}
+	if err := authorizeEventChange(ctx, event); err != nil {
+		return err
+	}
+
+	tx, err := s.transactionManager.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	// Ensure that the transaction is rolled back or committed properly
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				log.Println("Error rolling back transaction:", rollbackErr)
+			}
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			log.Println("Error committing transaction:", commitErr)
+			return
+		}
+	}()
+
+	if err = lockUncancelledEvent(ctx, tx, s.eventRepo, eventID); err != nil {
+		return err
+	}
+	if err = s.eventRepo.DeleteEventException(ctx, tx, eventID, occurrenceDate); err != nil {
+		log.Println("Error deleting event exception:", err)
+		return err
+	}
+	return nil
+}
+
+// ConfirmEvent picks the final slot of an event and locks it. Without a slot the top recommendation is confirmed
+// if it is feasible, a given slot must last the duration of the event and lie inside one of its proposed slots. The slot is rejected
+// when it overlaps a confirmed event of the organizer or an attendee, see checkDoubleBooking, and a free resource is
+// reserved for every resource requirement of the event.
+func (s *eventService) ConfirmEvent(ctx context.Context, eventID int64, slot *model.EventSlot) (model.EventSlot, error) {
+	event, err := s.eventRepo.GetEvent(ctx, eventID)
+	if err != nil {
+		log.Println("Error getting event:", err)
+		return model.EventSlot{}, err
+	}
+	if err := authorizeEventChange(ctx, event); err != nil {
+		return model.EventSlot{}, err
+	}
+	if err := checkEventOpen(event); err != nil {
+		return model.EventSlot{}, err
+	}
+
+	var confirmed model.EventSlot
+	if slot == nil {
+		recommendations, err := s.recommendationService.GetRecommendedSlots(ctx, eventID, model.RecommendationOptions{Limit: 1})
+		if err != nil {
+			log.Println("Error getting recommended slots:", err)
+			return model.EventSlot{}, err
+		}
+		if len(recommendations) == 0 {
+			return model.EventSlot{}, &model.ConflictError{Message: fmt.Sprintf("event %d has no slot where any attendee is available", eventID)}
+		}
+		// the best slot is only infeasible when no slot suits every required attendee
+		if !recommendations[0].Feasible {
+			return model.EventSlot{}, &model.ConflictError{Message: fmt.Sprintf("event %d has no slot where every required attendee is available", eventID)}
+		}
+		confirmed = recommendations[0].Slot
+	} else {
+		proposedSlots, err := s.eventRepo.GetEventSlots(ctx, eventID)
+		if err != nil {
+			log.Println("Error getting event slots:", err)
+			return model.EventSlot{}, err
+		}
+		if err := validateConfirmedSlot(event, proposedSlots, *slot); err != nil {
+			return model.EventSlot{}, err
+		}
+		confirmed = *slot
+	}
+	if confirmed.TimeZone == "" {
+		confirmed.TimeZone = event.TimeZone
+	}
+	confirmed = model.EventSlot{StartTime: confirmed.StartTime.UTC(), EndTime: confirmed.EndTime.UTC(), TimeZone: confirmed.TimeZone}
+
+	tx, err := s.transactionManager.BeginTransaction(ctx)
+	if err != nil {
+		return model.EventSlot{}, err
+	}
+	// Ensure that the transaction is rolled back or committed properly
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				log.Println("Error rolling back transaction:", rollbackErr)
+			}
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			log.Println("Error committing transaction:", commitErr)
+			return
+		}
+	}()
+
+	// attendees and resources are read once the event is locked, so that nobody joins or changes it meanwhile
+	if event, err = lockOpenEvent(ctx, tx, s.eventRepo, eventID); err != nil {
+		return model.EventSlot{}, err
+	}
+	var attendees []model.Attendee
+	if attendees, err = s.eventRepo.GetEventAttendees(ctx, eventID); err != nil {
+		log.Println("Error getting event attendees:", err)
+		return model.EventSlot{}, err
+	}
+	participants := eventParticipants(event, attendees)
+	var requirements []model.ResourceRequirement
+	if requirements, err = s.resourceRepo.GetEventResources(ctx, eventID); err != nil {
+		log.Println("Error getting event resources:", err)
+		return model.EventSlot{}, err
+	}
+	var plan *resourcePlan
+	if len(requirements) > 0 {
+		if plan, err = newResourcePlan(ctx, s.resourceRepo, requirements, len(participants)); err != nil {
+			log.Println("Error planning event resources:", err)
+			return model.EventSlot{}, err
+		}
+	}
+
+	if err = checkDoubleBooking(ctx, s.eventRepo, tx, event, participants, confirmed); err != nil {
+		log.Println("Error checking double bookings:", err)
+		return model.EventSlot{}, err
+	}
+	if err = reserveResources(ctx, tx, s.resourceRepo, s.eventRepo, plan, event, confirmed); err != nil {
+		log.Println("Error reserving event resources:", err)
+		return model.EventSlot{}, err
+	}
+	if err = s.eventRepo.ConfirmEvent(ctx, tx, eventID, confirmed); err != nil {
+		log.Println("Error confirming event:", err)
+		return model.EventSlot{}, err
+	}
+	return confirmed, nil
+}
+
+// eventParticipants returns the organizer of the event followed by its attendees
+func eventParticipants(event model.Event, attendees []model.Attendee) []int64 {
+	participants := []int64{event.OrganizerID}
+	for _, attendee := range attendees {
+		if attendee.UserID != event.OrganizerID {
+			participants = append(participants, attendee.UserID)
+		}
+	}
+	return participants
+}
+
+// CancelEvent cancels an event in any status, a cancelled event keeps the slot it was confirmed for but releases the
+// resources booked for it.
+func (s *eventService) CancelEvent(ctx context.Context, eventID int64) error {
+	event, err := s.eventRepo.GetEvent(ctx, eventID)
+	if err != nil {
+		log.Println("Error getting event:", err)
+		return err
+	}
+	if err := authorizeEventChange(ctx, event); err != nil {
+		return err
+	}
+	if event.Status == model.EventStatusCancelled {
+		return &model.ConflictError{Message: fmt.Sprintf("event %d is already cancelled", eventID)}
+	}
+
+	tx, err := s.transactionManager.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	// Ensure that the transaction is rolled back or committed properly
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				log.Println("Error rolling back transaction:", rollbackErr)
+			}
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			log.Println("Error committing transaction:", commitErr)
+			return
+		}
+	}()
+
+	if err = s.eventRepo.CancelEvent(ctx, tx, eventID); err != nil {
+		log.Println("Error cancelling event:", err)
+		return err
+	}
+	if err = s.resourceRepo.ReleaseEventResources(ctx, tx, eventID); err != nil {
+		log.Println("Error releasing event resources:", err)
+		return err
+	}
+	return nil
+}
@@ -2,13 +2,19 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"slices"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	mock_repository "github.com/rahulshewale153/meeting-scheduler-api/mock/repository"
+	mock_service "github.com/rahulshewale153/meeting-scheduler-api/mock/service"
 	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/rahulshewale153/meeting-scheduler-api/utils"
 	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
 )
 
 func TestInsertEvent(t *testing.T) {
@@ -23,13 +29,14 @@ func TestInsertEvent(t *testing.T) {
 
 	mockEventRepo := new(mock_repository.MockEventRepository)
 	mockTransactionManager := new(mock_repository.MockTransactionManager)
-	service := NewEventService(mockTransactionManager, mockEventRepo)
+	service := NewEventService(mockTransactionManager, mockEventRepo, nil, nil)
 	ctx := context.Background()
 	createEventReq := model.EventRequest{
 		Event: model.Event{
 			Title:           "Test Event",
 			OrganizerID:     1,
 			DurationMinutes: 60,
+			TimeZone:        "Asia/Kolkata",
 		},
 		ProposedSlots: []model.EventSlot{
 			{
@@ -39,6 +46,18 @@ func TestInsertEvent(t *testing.T) {
 		},
 	}
 
+	t.Run("Function must return a validation error when the proposed slots are invalid", func(t *testing.T) {
+		invalidReq := createEventReq
+		invalidReq.ProposedSlots = []model.EventSlot{{
+			StartTime: time.Date(2025, 07, 12, 11, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2025, 07, 12, 10, 0, 0, 0, time.UTC),
+		}}
+		_, err := service.InsertEvent(ctx, invalidReq)
+		var validationErr *utils.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		mockTransactionManager.AssertNotCalled(t, "BeginTransaction", ctx)
+	})
+
 	t.Run("Function must return an error when the transaction cannot be started", func(t *testing.T) {
 		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, assert.AnError).Once()
 		_, err := service.InsertEvent(ctx, createEventReq)
@@ -65,6 +84,7 @@ func TestInsertEvent(t *testing.T) {
 			repoEventSlot := model.EventSlot{
 				StartTime: time.Date(2025, 07, 12, 10, 0, 0, 0, time.UTC),
 				EndTime:   time.Date(2025, 07, 12, 11, 0, 0, 0, time.UTC),
+				TimeZone:  "Asia/Kolkata",
 			}
 			mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
 			mockEventRepo.On("InsertEvent", ctx, tx, createEventReq.Event).
@@ -84,6 +104,7 @@ func TestInsertEvent(t *testing.T) {
 			repoEventSlot := model.EventSlot{
 				StartTime: time.Date(2025, 07, 12, 10, 0, 0, 0, time.UTC),
 				EndTime:   time.Date(2025, 07, 12, 11, 0, 0, 0, time.UTC),
+				TimeZone:  "Asia/Kolkata",
 			}
 			mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
 			mockEventRepo.On("InsertEvent", ctx, tx, createEventReq.Event).
@@ -99,6 +120,50 @@ func TestInsertEvent(t *testing.T) {
 			mock.ExpectCommit()
 		})
 
+		t.Run("Function must store the attendees with the default weight when none is given", func(t *testing.T) {
+			withAttendees := createEventReq
+			withAttendees.Attendees = []model.Attendee{{UserID: 2, Required: true}, {UserID: 3, Weight: 2.5}}
+			repoEventSlot := model.EventSlot{
+				StartTime: time.Date(2025, 07, 12, 10, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2025, 07, 12, 11, 0, 0, 0, time.UTC),
+				TimeZone:  "Asia/Kolkata",
+			}
+			mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+			mockEventRepo.On("InsertEvent", ctx, tx, withAttendees.Event).
+				Return(int64(1), nil).Once()
+			mockEventRepo.On("InsertEventSlots", ctx, tx, int64(1), repoEventSlot).
+				Return(nil).Once()
+			mockEventRepo.On("InsertEventAttendee", ctx, tx, int64(1), model.Attendee{UserID: 2, Required: true, Weight: 1}).
+				Return(nil).Once()
+			mockEventRepo.On("InsertEventAttendee", ctx, tx, int64(1), model.Attendee{UserID: 3, Weight: 2.5}).
+				Return(nil).Once()
+
+			_, err := service.InsertEvent(ctx, withAttendees)
+			assert.NoError(t, err)
+			mockEventRepo.AssertExpectations(t)
+		})
+
+		t.Run("Function must store the default time zone on the event and its slots when none is given", func(t *testing.T) {
+			withoutZone := createEventReq
+			withoutZone.Event.TimeZone = ""
+			utcEvent := withoutZone.Event
+			utcEvent.TimeZone = "UTC"
+			repoEventSlot := model.EventSlot{
+				StartTime: time.Date(2025, 07, 12, 10, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2025, 07, 12, 11, 0, 0, 0, time.UTC),
+				TimeZone:  "UTC",
+			}
+			mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+			mockEventRepo.On("InsertEvent", ctx, tx, utcEvent).
+				Return(int64(1), nil).Once()
+			mockEventRepo.On("InsertEventSlots", ctx, tx, int64(1), repoEventSlot).
+				Return(nil).Once()
+
+			_, err := service.InsertEvent(ctx, withoutZone)
+			assert.NoError(t, err)
+			mockEventRepo.AssertExpectations(t)
+		})
+
 	})
 
 }
@@ -115,14 +180,15 @@ func TestUpdateEvent(t *testing.T) {
 
 	mockEventRepo := new(mock_repository.MockEventRepository)
 	mockTransactionManager := new(mock_repository.MockTransactionManager)
-	service := NewEventService(mockTransactionManager, mockEventRepo)
+	service := NewEventService(mockTransactionManager, mockEventRepo, nil, nil)
 	ctx := context.Background()
 	updateEventReq := model.EventRequest{
 		Event: model.Event{
 			ID:              1,
 			Title:           "Updated Event",
 			OrganizerID:     2,
-			DurationMinutes: 90,
+			DurationMinutes: 60,
+			TimeZone:        "Asia/Kolkata",
 		},
 		ProposedSlots: []model.EventSlot{
 			{
@@ -132,15 +198,57 @@ func TestUpdateEvent(t *testing.T) {
 		},
 	}
 
+	t.Run("Function must return a not found error when the event does not exist", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, updateEventReq.Event.ID).Return(model.Event{}, &model.NotFoundError{Resource: "event", ID: updateEventReq.Event.ID}).Once()
+		err := service.UpdateEvent(ctx, updateEventReq)
+		assert.ErrorIs(t, err, model.ErrNotFound)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return a forbidden error when the caller does not organize the event", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", otherOrganizer, updateEventReq.Event.ID).Return(updateEventReq.Event, nil).Once()
+		err := service.UpdateEvent(otherOrganizer, updateEventReq)
+		assert.ErrorIs(t, err, model.ErrForbidden)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return a forbidden error when the organizer hands the event to another user", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", organizer, updateEventReq.Event.ID).Return(model.Event{ID: 1, OrganizerID: 5}, nil).Once()
+		err := service.UpdateEvent(organizer, updateEventReq)
+		assert.ErrorIs(t, err, model.ErrForbidden)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return a conflict error when the event is confirmed", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, updateEventReq.Event.ID).Return(model.Event{ID: 1, Status: model.EventStatusConfirmed}, nil).Once()
+		err := service.UpdateEvent(ctx, updateEventReq)
+		assert.ErrorIs(t, err, model.ErrConflict)
+		mockEventRepo.AssertExpectations(t)
+	})
+
 	t.Run("Function must return an error when the transaction cannot be started", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, updateEventReq.Event.ID).Return(updateEventReq.Event, nil).Once()
 		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, assert.AnError).Once()
 		err := service.UpdateEvent(ctx, updateEventReq)
 		assert.Error(t, err)
 		mockTransactionManager.AssertExpectations(t)
 	})
 
+	t.Run("Function must return a conflict error when the event is confirmed while waiting for the lock", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, updateEventReq.Event.ID).Return(updateEventReq.Event, nil).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("LockEvent", ctx, tx, updateEventReq.Event.ID).Return(model.Event{ID: 1, Status: model.EventStatusConfirmed}, nil).Once()
+
+		err := service.UpdateEvent(ctx, updateEventReq)
+		assert.ErrorIs(t, err, model.ErrConflict)
+		mockEventRepo.AssertExpectations(t)
+		mock.ExpectRollback()
+	})
+
 	t.Run("Function must return an error when the update operation fails", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, updateEventReq.Event.ID).Return(updateEventReq.Event, nil).Once()
 		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("LockEvent", ctx, tx, updateEventReq.Event.ID).Return(updateEventReq.Event, nil).Once()
 		mockEventRepo.On("UpdateEvent", ctx, tx, updateEventReq.Event).
 			Return(assert.AnError).Once()
 
@@ -153,7 +261,9 @@ func TestUpdateEvent(t *testing.T) {
 
 	t.Run("Function must return nil when the update operation is successful", func(t *testing.T) {
 		t.Run("Function must return an error when the get slot operation fails", func(t *testing.T) {
+			mockEventRepo.On("GetEvent", ctx, updateEventReq.Event.ID).Return(updateEventReq.Event, nil).Once()
 			mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+			mockEventRepo.On("LockEvent", ctx, tx, updateEventReq.Event.ID).Return(updateEventReq.Event, nil).Once()
 			mockEventRepo.On("UpdateEvent", ctx, tx, updateEventReq.Event).
 				Return(nil).Once()
 			mockEventRepo.On("GetEventSlots", ctx, updateEventReq.Event.ID).
@@ -166,7 +276,9 @@ func TestUpdateEvent(t *testing.T) {
 			mock.ExpectRollback()
 		})
 		t.Run("Function must return an error when the insert slot operation fails", func(t *testing.T) {
+			mockEventRepo.On("GetEvent", ctx, updateEventReq.Event.ID).Return(updateEventReq.Event, nil).Once()
 			mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+			mockEventRepo.On("LockEvent", ctx, tx, updateEventReq.Event.ID).Return(updateEventReq.Event, nil).Once()
 			mockEventRepo.On("UpdateEvent", ctx, tx, updateEventReq.Event).
 				Return(nil).Once()
 			mockEventRepo.On("GetEventSlots", ctx, updateEventReq.Event.ID).
@@ -174,6 +286,7 @@ func TestUpdateEvent(t *testing.T) {
 			mockEventRepo.On("InsertEventSlots", ctx, tx, updateEventReq.Event.ID, model.EventSlot{
 				StartTime: time.Date(2025, 07, 12, 12, 0, 0, 0, time.UTC),
 				EndTime:   time.Date(2025, 07, 12, 13, 0, 0, 0, time.UTC),
+				TimeZone:  "Asia/Kolkata",
 			}).Return(assert.AnError).Once()
 
 			err := service.UpdateEvent(ctx, updateEventReq)
@@ -184,7 +297,9 @@ func TestUpdateEvent(t *testing.T) {
 		})
 
 		t.Run("Function must return nil when the update operation is successful", func(t *testing.T) {
+			mockEventRepo.On("GetEvent", ctx, updateEventReq.Event.ID).Return(updateEventReq.Event, nil).Once()
 			mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+			mockEventRepo.On("LockEvent", ctx, tx, updateEventReq.Event.ID).Return(updateEventReq.Event, nil).Once()
 			mockEventRepo.On("UpdateEvent", ctx, tx, updateEventReq.Event).
 				Return(nil).Once()
 			mockEventRepo.On("GetEventSlots", ctx, updateEventReq.Event.ID).
@@ -192,6 +307,7 @@ func TestUpdateEvent(t *testing.T) {
 			mockEventRepo.On("InsertEventSlots", ctx, tx, updateEventReq.Event.ID, model.EventSlot{
 				StartTime: time.Date(2025, 07, 12, 12, 0, 0, 0, time.UTC),
 				EndTime:   time.Date(2025, 07, 12, 13, 0, 0, 0, time.UTC),
+				TimeZone:  "Asia/Kolkata",
 			}).Return(nil).Once()
 
 			err := service.UpdateEvent(ctx, updateEventReq)
@@ -216,11 +332,26 @@ func TestDeleteEvent(t *testing.T) {
 
 	mockEventRepo := new(mock_repository.MockEventRepository)
 	mockTransactionManager := new(mock_repository.MockTransactionManager)
-	service := NewEventService(mockTransactionManager, mockEventRepo)
+	service := NewEventService(mockTransactionManager, mockEventRepo, nil, nil)
 	ctx := context.Background()
 	eventID := int64(1)
 
+	t.Run("Function must return a not found error when the event does not exist", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{}, &model.NotFoundError{Resource: "event", ID: eventID}).Once()
+		err := service.DeleteEvent(ctx, eventID)
+		assert.ErrorIs(t, err, model.ErrNotFound)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return a forbidden error when the caller does not organize the event", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", attendee, eventID).Return(model.Event{ID: eventID, OrganizerID: 5}, nil).Once()
+		err := service.DeleteEvent(attendee, eventID)
+		assert.ErrorIs(t, err, model.ErrForbidden)
+		mockEventRepo.AssertExpectations(t)
+	})
+
 	t.Run("Function must return an error when the transaction cannot be started", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{ID: eventID}, nil).Once()
 		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, assert.AnError).Once()
 		err := service.DeleteEvent(ctx, eventID)
 		assert.Error(t, err)
@@ -228,8 +359,9 @@ func TestDeleteEvent(t *testing.T) {
 	})
 
 	t.Run("Function must return an error when the delete event slot operation fails", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{ID: eventID}, nil).Once()
 		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
-		mockEventRepo.On("DeleteEventSlots", ctx, tx, eventID).
+		mockEventRepo.On("DeleteAllEventSlots", ctx, tx, eventID).
 			Return(assert.AnError).Once()
 
 		err := service.DeleteEvent(ctx, eventID)
@@ -240,23 +372,25 @@ func TestDeleteEvent(t *testing.T) {
 	})
 
 	t.Run("Function must return an error when the delete operation fails", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{ID: eventID}, nil).Once()
 		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
-		mockEventRepo.On("DeleteEventSlots", ctx, tx, eventID).
+		mockEventRepo.On("DeleteAllEventSlots", ctx, tx, eventID).
 			Return(nil).Once()
 
 		mockEventRepo.On("DeleteEvent", ctx, tx, eventID).
-			Return(nil).Once()
+			Return(assert.AnError).Once()
 
 		err := service.DeleteEvent(ctx, eventID)
-		assert.NoError(t, err)
+		assert.Error(t, err)
 		mockTransactionManager.AssertExpectations(t)
 		mockEventRepo.AssertExpectations(t)
 		mock.ExpectRollback()
 	})
 
 	t.Run("Function must return nil when the delete operation is successful", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{ID: eventID}, nil).Once()
 		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
-		mockEventRepo.On("DeleteEventSlots", ctx, tx, eventID).
+		mockEventRepo.On("DeleteAllEventSlots", ctx, tx, eventID).
 			Return(nil).Once()
 
 		mockEventRepo.On("DeleteEvent", ctx, tx, eventID).
@@ -270,3 +404,804 @@ func TestDeleteEvent(t *testing.T) {
 	})
 
 }
+
+func TestGetEvent(t *testing.T) {
+	mockEventRepo := new(mock_repository.MockEventRepository)
+	mockTransactionManager := new(mock_repository.MockTransactionManager)
+	service := NewEventService(mockTransactionManager, mockEventRepo, nil, nil)
+	ctx := context.Background()
+	eventID := int64(1)
+	event := model.Event{ID: eventID, Title: "Test Event", OrganizerID: 1, DurationMinutes: 60}
+	slots := []model.EventSlot{
+		{
+			ID:        1,
+			StartTime: time.Date(2025, 07, 12, 10, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2025, 07, 12, 11, 0, 0, 0, time.UTC),
+		},
+	}
+
+	t.Run("Function must return an error when the get event operation fails", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{}, assert.AnError).Once()
+		_, err := service.GetEvent(ctx, eventID)
+		assert.Error(t, err)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return an error when the get event slots operation fails", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(event, nil).Once()
+		mockEventRepo.On("GetEventSlots", ctx, eventID).Return([]model.EventSlot{}, assert.AnError).Once()
+		_, err := service.GetEvent(ctx, eventID)
+		assert.Error(t, err)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return an error when the get event attendees operation fails", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(event, nil).Once()
+		mockEventRepo.On("GetEventSlots", ctx, eventID).Return(slots, nil).Once()
+		mockEventRepo.On("GetEventAttendees", ctx, eventID).Return([]model.Attendee{}, assert.AnError).Once()
+		_, err := service.GetEvent(ctx, eventID)
+		assert.Error(t, err)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return the event with its slots when all operations are successful", func(t *testing.T) {
+		attendees := []model.Attendee{{UserID: 2, Required: true, Weight: 1}}
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(event, nil).Once()
+		mockEventRepo.On("GetEventSlots", ctx, eventID).Return(slots, nil).Once()
+		mockEventRepo.On("GetEventAttendees", ctx, eventID).Return(attendees, nil).Once()
+		eventDetail, err := service.GetEvent(ctx, eventID)
+		assert.NoError(t, err)
+		assert.Equal(t, event, eventDetail.Event)
+		assert.Equal(t, slots, eventDetail.ProposedSlots)
+		assert.Equal(t, attendees, eventDetail.Attendees)
+		assert.Empty(t, eventDetail.Exceptions)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return a forbidden error when the caller neither organizes nor attends the event", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", attendee, eventID).Return(event, nil).Once()
+		mockEventRepo.On("GetEventSlots", attendee, eventID).Return(slots, nil).Once()
+		mockEventRepo.On("GetEventAttendees", attendee, eventID).Return([]model.Attendee{{UserID: 2}}, nil).Once()
+		_, err := service.GetEvent(attendee, eventID)
+		assert.ErrorIs(t, err, model.ErrForbidden)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return the exceptions of a recurring event", func(t *testing.T) {
+		recurring := event
+		recurring.RRule = "FREQ=WEEKLY"
+		exceptions := []model.EventException{{OccurrenceDate: "2025-07-21", Cancelled: true}}
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(recurring, nil).Once()
+		mockEventRepo.On("GetEventSlots", ctx, eventID).Return(slots, nil).Once()
+		mockEventRepo.On("GetEventAttendees", ctx, eventID).Return([]model.Attendee{}, nil).Once()
+		mockEventRepo.On("GetEventExceptions", ctx, eventID).Return(exceptions, nil).Once()
+		eventDetail, err := service.GetEvent(ctx, eventID)
+		assert.NoError(t, err)
+		assert.Equal(t, exceptions, eventDetail.Exceptions)
+		mockEventRepo.AssertExpectations(t)
+	})
+}
+
+func TestListEvents(t *testing.T) {
+	mockEventRepo := new(mock_repository.MockEventRepository)
+	mockTransactionManager := new(mock_repository.MockTransactionManager)
+	service := NewEventService(mockTransactionManager, mockEventRepo, nil, nil)
+	ctx := context.Background()
+
+	t.Run("Function must return an error when the list operation fails", func(t *testing.T) {
+		mockEventRepo.On("ListEvents", ctx, model.EventFilter{Limit: defaultEventPageSize + 1}).Return([]model.Event{}, assert.AnError).Once()
+		_, err := service.ListEvents(ctx, model.EventFilter{})
+		assert.Error(t, err)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return a next cursor when more events are available", func(t *testing.T) {
+		events := []model.Event{{ID: 3}, {ID: 4}, {ID: 7}}
+		mockEventRepo.On("ListEvents", ctx, model.EventFilter{OrganizerID: 1, Limit: 3}).Return(events, nil).Once()
+		eventList, err := service.ListEvents(ctx, model.EventFilter{OrganizerID: 1, Limit: 2})
+		assert.NoError(t, err)
+		assert.Len(t, eventList.Events, 2)
+		assert.Equal(t, utils.EncodeCursor(4), eventList.NextCursor)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must list only the events of the calling organizer", func(t *testing.T) {
+		mockEventRepo.On("ListEvents", organizer, model.EventFilter{OrganizerID: 5, Limit: defaultEventPageSize + 1}).Return([]model.Event{{ID: 3}}, nil).Once()
+		eventList, err := service.ListEvents(organizer, model.EventFilter{})
+		assert.NoError(t, err)
+		assert.Len(t, eventList.Events, 1)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return a forbidden error when listing the events of another organizer", func(t *testing.T) {
+		_, err := service.ListEvents(otherOrganizer, model.EventFilter{OrganizerID: 5})
+		assert.ErrorIs(t, err, model.ErrForbidden)
+		mockEventRepo.AssertNotCalled(t, "ListEvents", otherOrganizer, testifyMock.Anything)
+	})
+
+	t.Run("Function must not return a next cursor on the last page", func(t *testing.T) {
+		events := []model.Event{{ID: 3}}
+		mockEventRepo.On("ListEvents", ctx, model.EventFilter{Limit: maxEventPageSize + 1}).Return(events, nil).Once()
+		eventList, err := service.ListEvents(ctx, model.EventFilter{Limit: 1000})
+		assert.NoError(t, err)
+		assert.Len(t, eventList.Events, 1)
+		assert.Empty(t, eventList.NextCursor)
+		mockEventRepo.AssertExpectations(t)
+	})
+}
+
+func TestAddAttendee(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	mockEventRepo := new(mock_repository.MockEventRepository)
+	mockTransactionManager := new(mock_repository.MockTransactionManager)
+	service := NewEventService(mockTransactionManager, mockEventRepo, nil, nil)
+	ctx := context.Background()
+	eventID := int64(1)
+
+	t.Run("Function must return a validation error when the weight is negative", func(t *testing.T) {
+		err := service.AddAttendee(ctx, eventID, model.Attendee{UserID: 2, Weight: -1})
+		var validationErr *utils.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+
+	t.Run("Function must return a not found error when the event does not exist", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{}, &model.NotFoundError{Resource: "event", ID: eventID}).Once()
+		err := service.AddAttendee(ctx, eventID, model.Attendee{UserID: 2})
+		assert.ErrorIs(t, err, model.ErrNotFound)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return a conflict error when the event was confirmed meanwhile", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{ID: eventID}, nil).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("LockEvent", ctx, tx, eventID).Return(model.Event{ID: eventID, Status: model.EventStatusConfirmed}, nil).Once()
+
+		err := service.AddAttendee(ctx, eventID, model.Attendee{UserID: 2})
+		assert.ErrorIs(t, err, model.ErrConflict)
+		mockEventRepo.AssertExpectations(t)
+		mock.ExpectRollback()
+	})
+
+	t.Run("Function must return an error when the insert operation fails", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{ID: eventID}, nil).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("LockEvent", ctx, tx, eventID).Return(model.Event{ID: eventID, Status: model.EventStatusPolling}, nil).Once()
+		mockEventRepo.On("InsertEventAttendee", ctx, tx, eventID, model.Attendee{UserID: 2, Weight: 1}).
+			Return(&model.ConflictError{Message: "user 2 is already an attendee of event 1"}).Once()
+
+		err := service.AddAttendee(ctx, eventID, model.Attendee{UserID: 2})
+		assert.ErrorIs(t, err, model.ErrConflict)
+		mockEventRepo.AssertExpectations(t)
+		mock.ExpectRollback()
+	})
+
+	t.Run("Function must return nil when the insert operation is successful", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{ID: eventID}, nil).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("LockEvent", ctx, tx, eventID).Return(model.Event{ID: eventID, Status: model.EventStatusPolling}, nil).Once()
+		mockEventRepo.On("InsertEventAttendee", ctx, tx, eventID, model.Attendee{UserID: 3, Required: true, Weight: 1}).
+			Return(nil).Once()
+
+		err := service.AddAttendee(ctx, eventID, model.Attendee{UserID: 3, Required: true})
+		assert.NoError(t, err)
+		mockEventRepo.AssertExpectations(t)
+		mock.ExpectCommit()
+	})
+}
+
+func TestRemoveAttendee(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	mockEventRepo := new(mock_repository.MockEventRepository)
+	mockTransactionManager := new(mock_repository.MockTransactionManager)
+	service := NewEventService(mockTransactionManager, mockEventRepo, nil, nil)
+	ctx := context.Background()
+	eventID := int64(1)
+	userID := int64(2)
+
+	t.Run("Function must return a not found error when the event does not exist", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{}, &model.NotFoundError{Resource: "event", ID: eventID}).Once()
+		err := service.RemoveAttendee(ctx, eventID, userID)
+		assert.ErrorIs(t, err, model.ErrNotFound)
+	})
+
+	mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{ID: eventID, OrganizerID: 5, Status: model.EventStatusPolling}, nil)
+
+	t.Run("Function must return an error when the transaction cannot be started", func(t *testing.T) {
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, assert.AnError).Once()
+		err := service.RemoveAttendee(ctx, eventID, userID)
+		assert.Error(t, err)
+		mockTransactionManager.AssertExpectations(t)
+	})
+
+	t.Run("Function must return a not found error when the user is not an attendee", func(t *testing.T) {
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("DeleteEventAttendee", ctx, tx, eventID, userID).
+			Return(&model.NotFoundError{Resource: "attendee", ID: userID}).Once()
+
+		err := service.RemoveAttendee(ctx, eventID, userID)
+		assert.ErrorIs(t, err, model.ErrNotFound)
+		mockEventRepo.AssertExpectations(t)
+		mock.ExpectRollback()
+	})
+
+	t.Run("Function must return nil when the delete operation is successful", func(t *testing.T) {
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("DeleteEventAttendee", ctx, tx, eventID, userID).Return(nil).Once()
+
+		err := service.RemoveAttendee(ctx, eventID, userID)
+		assert.NoError(t, err)
+		mockEventRepo.AssertExpectations(t)
+		mock.ExpectCommit()
+	})
+}
+
+func TestListAttendees(t *testing.T) {
+	mockEventRepo := new(mock_repository.MockEventRepository)
+	service := NewEventService(nil, mockEventRepo, nil, nil)
+	ctx := context.Background()
+	eventID := int64(1)
+
+	t.Run("Function must return a not found error when the event does not exist", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{}, &model.NotFoundError{Resource: "event", ID: eventID}).Once()
+		_, err := service.ListAttendees(ctx, eventID)
+		assert.ErrorIs(t, err, model.ErrNotFound)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return a forbidden error when the caller neither organizes nor attends the event", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", attendee, eventID).Return(model.Event{ID: eventID, OrganizerID: 5}, nil).Once()
+		mockEventRepo.On("GetEventAttendees", attendee, eventID).Return([]model.Attendee{{UserID: 2}}, nil).Once()
+		_, err := service.ListAttendees(attendee, eventID)
+		assert.ErrorIs(t, err, model.ErrForbidden)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return the attendees when the read operation is successful", func(t *testing.T) {
+		attendees := []model.Attendee{{UserID: 2, Required: true, Weight: 1}}
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{ID: eventID}, nil).Once()
+		mockEventRepo.On("GetEventAttendees", ctx, eventID).Return(attendees, nil).Once()
+		result, err := service.ListAttendees(ctx, eventID)
+		assert.NoError(t, err)
+		assert.Equal(t, attendees, result)
+		mockEventRepo.AssertExpectations(t)
+	})
+}
+
+func TestSetEventException(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	mockEventRepo := new(mock_repository.MockEventRepository)
+	mockTransactionManager := new(mock_repository.MockTransactionManager)
+	service := NewEventService(mockTransactionManager, mockEventRepo, nil, nil)
+	ctx := context.Background()
+	eventID := int64(1)
+	recurring := model.Event{ID: eventID, RRule: "FREQ=WEEKLY;BYDAY=MO"}
+
+	t.Run("Function must return a not found error when the event does not exist", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{}, &model.NotFoundError{Resource: "event", ID: eventID}).Once()
+		err := service.SetEventException(ctx, eventID, model.EventException{OccurrenceDate: "2025-07-21", Cancelled: true})
+		assert.ErrorIs(t, err, model.ErrNotFound)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return a validation error when the event does not recur", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{ID: eventID}, nil).Once()
+		err := service.SetEventException(ctx, eventID, model.EventException{OccurrenceDate: "2025-07-21", Cancelled: true})
+		var validationErr *utils.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return a conflict error when the event was cancelled meanwhile", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(recurring, nil).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("LockEvent", ctx, tx, eventID).Return(model.Event{ID: eventID, RRule: recurring.RRule, Status: model.EventStatusCancelled}, nil).Once()
+
+		err := service.SetEventException(ctx, eventID, model.EventException{OccurrenceDate: "2025-07-21", Cancelled: true})
+		assert.ErrorIs(t, err, model.ErrConflict)
+		mockEventRepo.AssertExpectations(t)
+		mock.ExpectRollback()
+	})
+
+	t.Run("Function must cancel an occurrence of a confirmed event", func(t *testing.T) {
+		confirmed := recurring
+		confirmed.Status = model.EventStatusConfirmed
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(confirmed, nil).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("LockEvent", ctx, tx, eventID).Return(confirmed, nil).Once()
+		mockEventRepo.On("UpsertEventException", ctx, tx, eventID, model.EventException{OccurrenceDate: "2025-07-28", Cancelled: true}).Return(nil).Once()
+
+		err := service.SetEventException(ctx, eventID, model.EventException{OccurrenceDate: "2025-07-28", Cancelled: true})
+		assert.NoError(t, err)
+		mockEventRepo.AssertExpectations(t)
+		mock.ExpectCommit()
+	})
+
+	t.Run("Function must store a moved occurrence in UTC", func(t *testing.T) {
+		kolkata, _ := time.LoadLocation("Asia/Kolkata")
+		start := time.Date(2025, 07, 22, 15, 30, 0, 0, kolkata)
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(recurring, nil).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("LockEvent", ctx, tx, eventID).Return(recurring, nil).Once()
+		mockEventRepo.On("UpsertEventException", ctx, tx, eventID, model.EventException{
+			OccurrenceDate: "2025-07-21",
+			StartTime:      time.Date(2025, 07, 22, 10, 0, 0, 0, time.UTC),
+			EndTime:        time.Date(2025, 07, 22, 11, 0, 0, 0, time.UTC),
+		}).Return(nil).Once()
+
+		err := service.SetEventException(ctx, eventID, model.EventException{OccurrenceDate: "2025-07-21", StartTime: start, EndTime: start.Add(time.Hour)})
+		assert.NoError(t, err)
+		mockEventRepo.AssertExpectations(t)
+		mock.ExpectCommit()
+	})
+}
+
+func TestRemoveEventException(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	mockEventRepo := new(mock_repository.MockEventRepository)
+	mockTransactionManager := new(mock_repository.MockTransactionManager)
+	service := NewEventService(mockTransactionManager, mockEventRepo, nil, nil)
+	ctx := context.Background()
+	eventID := int64(1)
+	mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{ID: eventID, OrganizerID: 5, Status: model.EventStatusPolling}, nil)
+
+	t.Run("Function must return a not found error when the occurrence has no exception", func(t *testing.T) {
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("LockEvent", ctx, tx, eventID).Return(model.Event{ID: eventID, OrganizerID: 5, Status: model.EventStatusPolling}, nil).Once()
+		mockEventRepo.On("DeleteEventException", ctx, tx, eventID, "2025-07-21").
+			Return(&model.NotFoundError{Resource: "exception for 2025-07-21 of event", ID: eventID}).Once()
+
+		err := service.RemoveEventException(ctx, eventID, "2025-07-21")
+		assert.ErrorIs(t, err, model.ErrNotFound)
+		mockEventRepo.AssertExpectations(t)
+		mock.ExpectRollback()
+	})
+
+	t.Run("Function must return nil when the delete operation is successful", func(t *testing.T) {
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("LockEvent", ctx, tx, eventID).Return(model.Event{ID: eventID, OrganizerID: 5, Status: model.EventStatusPolling}, nil).Once()
+		mockEventRepo.On("DeleteEventException", ctx, tx, eventID, "2025-07-28").Return(nil).Once()
+
+		err := service.RemoveEventException(ctx, eventID, "2025-07-28")
+		assert.NoError(t, err)
+		mockEventRepo.AssertExpectations(t)
+		mock.ExpectCommit()
+	})
+}
+
+func TestConfirmEvent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	mockEventRepo := new(mock_repository.MockEventRepository)
+	mockTransactionManager := new(mock_repository.MockTransactionManager)
+	mockRecommendationService := new(mock_service.MockRecommendationService)
+	// events require no resources unless a test builds its own resource repository
+	mockResourceRepo := new(mock_repository.MockResourceRepository)
+	mockResourceRepo.On("GetEventResources", ctx, testifyMock.Anything).Return([]model.ResourceRequirement{}, nil).Maybe()
+	service := NewEventService(mockTransactionManager, mockEventRepo, mockResourceRepo, mockRecommendationService)
+	eventID := int64(1)
+	event := model.Event{ID: eventID, OrganizerID: 5, DurationMinutes: 60, TimeZone: "Asia/Kolkata", Status: model.EventStatusPolling}
+	ten := time.Date(2025, 07, 14, 10, 0, 0, 0, time.UTC)
+	proposed := []model.EventSlot{{ID: 1, StartTime: ten, EndTime: ten.Add(2 * time.Hour)}}
+
+	t.Run("Function must return a conflict error when the event is cancelled", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{ID: eventID, Status: model.EventStatusCancelled}, nil).Once()
+		_, err := service.ConfirmEvent(ctx, eventID, nil)
+		assert.ErrorIs(t, err, model.ErrConflict)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return a conflict error when nothing can be recommended", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(event, nil).Once()
+		mockRecommendationService.On("GetRecommendedSlots", ctx, eventID, model.RecommendationOptions{Limit: 1}).Return([]model.SlotRecommendation{}, nil).Once()
+		_, err := service.ConfirmEvent(ctx, eventID, nil)
+		assert.ErrorIs(t, err, model.ErrConflict)
+		mockRecommendationService.AssertExpectations(t)
+	})
+
+	t.Run("Function must return a conflict error when the top recommendation misses a required attendee", func(t *testing.T) {
+		top := model.SlotRecommendation{Slot: model.EventSlot{StartTime: ten, EndTime: ten.Add(time.Hour)}, MissingRequired: []int64{7}}
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(event, nil).Once()
+		mockRecommendationService.On("GetRecommendedSlots", ctx, eventID, model.RecommendationOptions{Limit: 1}).Return([]model.SlotRecommendation{top}, nil).Once()
+		_, err := service.ConfirmEvent(ctx, eventID, nil)
+		assert.ErrorIs(t, err, model.ErrConflict)
+		mockRecommendationService.AssertExpectations(t)
+		mockTransactionManager.AssertNotCalled(t, "BeginTransaction", ctx)
+	})
+
+	t.Run("Function must return a validation error when the slot is outside the proposed slots", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(event, nil).Once()
+		mockEventRepo.On("GetEventSlots", ctx, eventID).Return(proposed, nil).Once()
+		_, err := service.ConfirmEvent(ctx, eventID, &model.EventSlot{StartTime: ten.Add(90 * time.Minute), EndTime: ten.Add(150 * time.Minute)})
+		var validationErr *utils.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must confirm the top recommendation when no slot is given", func(t *testing.T) {
+		kolkata, _ := time.LoadLocation("Asia/Kolkata")
+		top := model.EventSlot{StartTime: ten.In(kolkata), EndTime: ten.Add(time.Hour).In(kolkata), TimeZone: "Asia/Kolkata"}
+		confirmed := model.EventSlot{StartTime: ten, EndTime: ten.Add(time.Hour), TimeZone: "Asia/Kolkata"}
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(event, nil).Once()
+		mockRecommendationService.On("GetRecommendedSlots", ctx, eventID, model.RecommendationOptions{Limit: 1}).Return([]model.SlotRecommendation{{Slot: top, Feasible: true}}, nil).Once()
+		mockEventRepo.On("GetEventAttendees", ctx, eventID).Return([]model.Attendee{{UserID: 7}}, nil).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("LockEvent", ctx, tx, eventID).Return(event, nil).Once()
+		mockEventRepo.On("LockUsers", ctx, tx, []int64{5, 7}).Return(nil).Once()
+		mockEventRepo.On("LockUserEvents", ctx, tx, []int64{5, 7}, confirmed.StartTime, confirmed.EndTime).Return(map[int64][]model.Event{}, nil).Once()
+		mockEventRepo.On("ConfirmEvent", ctx, tx, eventID, confirmed).Return(nil).Once()
+
+		slot, err := service.ConfirmEvent(ctx, eventID, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, confirmed, slot)
+		mockEventRepo.AssertExpectations(t)
+		mock.ExpectCommit()
+	})
+
+	t.Run("Function must confirm the given slot in the zone of the event", func(t *testing.T) {
+		confirmed := model.EventSlot{StartTime: ten.Add(time.Hour), EndTime: ten.Add(2 * time.Hour), TimeZone: "Asia/Kolkata"}
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(event, nil).Once()
+		mockEventRepo.On("GetEventSlots", ctx, eventID).Return(proposed, nil).Once()
+		mockEventRepo.On("GetEventAttendees", ctx, eventID).Return([]model.Attendee{{UserID: 7}}, nil).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("LockEvent", ctx, tx, eventID).Return(event, nil).Once()
+		// the planning of user 7 ends when this slot starts
+		planning := model.Event{ID: 4, Title: "Planning", Status: model.EventStatusConfirmed, ConfirmedSlot: &model.EventSlot{StartTime: ten, EndTime: ten.Add(time.Hour)}}
+		mockEventRepo.On("LockUsers", ctx, tx, []int64{5, 7}).Return(nil).Once()
+		mockEventRepo.On("LockUserEvents", ctx, tx, []int64{5, 7}, confirmed.StartTime, confirmed.EndTime).Return(map[int64][]model.Event{7: {planning}}, nil).Once()
+		mockEventRepo.On("ConfirmEvent", ctx, tx, eventID, confirmed).Return(nil).Once()
+
+		slot, err := service.ConfirmEvent(ctx, eventID, &model.EventSlot{StartTime: ten.Add(time.Hour), EndTime: ten.Add(2 * time.Hour)})
+		assert.NoError(t, err)
+		assert.Equal(t, confirmed, slot)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return a conflict error listing the confirmed event that double-books an attendee", func(t *testing.T) {
+		confirmed := model.EventSlot{StartTime: ten.Add(time.Hour), EndTime: ten.Add(2 * time.Hour), TimeZone: "Asia/Kolkata"}
+		review := model.Event{ID: 4, Title: "Review", Status: model.EventStatusConfirmed, ConfirmedSlot: &model.EventSlot{StartTime: ten.Add(90 * time.Minute), EndTime: ten.Add(150 * time.Minute)}}
+		mockEventRepo := new(mock_repository.MockEventRepository)
+		service := NewEventService(mockTransactionManager, mockEventRepo, mockResourceRepo, mockRecommendationService)
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(event, nil).Once()
+		mockEventRepo.On("GetEventSlots", ctx, eventID).Return(proposed, nil).Once()
+		mockEventRepo.On("GetEventAttendees", ctx, eventID).Return([]model.Attendee{{UserID: 7}}, nil).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("LockEvent", ctx, tx, eventID).Return(event, nil).Once()
+		mockEventRepo.On("LockUsers", ctx, tx, []int64{5, 7}).Return(nil).Once()
+		mockEventRepo.On("LockUserEvents", ctx, tx, []int64{5, 7}, confirmed.StartTime, confirmed.EndTime).Return(map[int64][]model.Event{7: {review}}, nil).Once()
+
+		_, err := service.ConfirmEvent(ctx, eventID, &model.EventSlot{StartTime: ten.Add(time.Hour), EndTime: ten.Add(2 * time.Hour)})
+		var conflictErr *model.ConflictError
+		assert.ErrorAs(t, err, &conflictErr)
+		assert.Contains(t, conflictErr.Message, `event 4 "Review" of user 7`)
+		assert.Equal(t, []model.MeetingConflict{{
+			UserID:  7,
+			First:   model.Meeting{EventID: eventID, Slot: confirmed},
+			Second:  model.Meeting{EventID: 4, Title: "Review", Slot: *review.ConfirmedSlot},
+			Overlap: model.EventSlot{StartTime: ten.Add(90 * time.Minute), EndTime: ten.Add(2 * time.Hour), TimeZone: "Asia/Kolkata"},
+		}}, conflictErr.Conflicts)
+		mockEventRepo.AssertNotCalled(t, "ConfirmEvent", ctx, tx, eventID, confirmed)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return a conflict error when the event was confirmed while waiting for the lock", func(t *testing.T) {
+		confirmedMeanwhile := event
+		confirmedMeanwhile.Status = model.EventStatusConfirmed
+		mockEventRepo := new(mock_repository.MockEventRepository)
+		service := NewEventService(mockTransactionManager, mockEventRepo, mockResourceRepo, mockRecommendationService)
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(event, nil).Once()
+		mockEventRepo.On("GetEventSlots", ctx, eventID).Return(proposed, nil).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("LockEvent", ctx, tx, eventID).Return(confirmedMeanwhile, nil).Once()
+
+		_, err := service.ConfirmEvent(ctx, eventID, &model.EventSlot{StartTime: ten.Add(time.Hour), EndTime: ten.Add(2 * time.Hour)})
+		assert.ErrorIs(t, err, model.ErrConflict)
+		mockEventRepo.AssertNotCalled(t, "GetEventAttendees", ctx, eventID)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return a conflict error when the event is no longer open at the update", func(t *testing.T) {
+		confirmed := model.EventSlot{StartTime: ten.Add(time.Hour), EndTime: ten.Add(2 * time.Hour), TimeZone: "Asia/Kolkata"}
+		mockEventRepo := new(mock_repository.MockEventRepository)
+		service := NewEventService(mockTransactionManager, mockEventRepo, mockResourceRepo, mockRecommendationService)
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(event, nil).Once()
+		mockEventRepo.On("GetEventSlots", ctx, eventID).Return(proposed, nil).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("LockEvent", ctx, tx, eventID).Return(event, nil).Once()
+		mockEventRepo.On("GetEventAttendees", ctx, eventID).Return([]model.Attendee{}, nil).Once()
+		mockEventRepo.On("LockUsers", ctx, tx, []int64{5}).Return(nil).Once()
+		mockEventRepo.On("LockUserEvents", ctx, tx, []int64{5}, confirmed.StartTime, confirmed.EndTime).Return(map[int64][]model.Event{}, nil).Once()
+		mockEventRepo.On("ConfirmEvent", ctx, tx, eventID, confirmed).Return(&model.ConflictError{Message: "event 1 is no longer open and cannot be confirmed"}).Once()
+
+		_, err := service.ConfirmEvent(ctx, eventID, &model.EventSlot{StartTime: ten.Add(time.Hour), EndTime: ten.Add(2 * time.Hour)})
+		assert.ErrorIs(t, err, model.ErrConflict)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return a conflict error when a participant stays locked by another confirmation", func(t *testing.T) {
+		mockEventRepo := new(mock_repository.MockEventRepository)
+		service := NewEventService(mockTransactionManager, mockEventRepo, mockResourceRepo, mockRecommendationService)
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(event, nil).Once()
+		mockEventRepo.On("GetEventSlots", ctx, eventID).Return(proposed, nil).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("LockEvent", ctx, tx, eventID).Return(event, nil).Once()
+		mockEventRepo.On("GetEventAttendees", ctx, eventID).Return([]model.Attendee{{UserID: 7}}, nil).Once()
+		mockEventRepo.On("LockUsers", ctx, tx, []int64{5, 7}).Return(&model.ConflictError{Message: "another event of the same users is being confirmed, try again"}).Once()
+
+		_, err := service.ConfirmEvent(ctx, eventID, &model.EventSlot{StartTime: ten.Add(time.Hour), EndTime: ten.Add(2 * time.Hour)})
+		assert.ErrorIs(t, err, model.ErrConflict)
+		mockEventRepo.AssertNotCalled(t, "ConfirmEvent", ctx, tx, eventID, testifyMock.Anything)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must book the best fitting free room for a resource requirement", func(t *testing.T) {
+		confirmed := model.EventSlot{StartTime: ten.Add(time.Hour), EndTime: ten.Add(2 * time.Hour), TimeZone: "Asia/Kolkata"}
+		requirement := model.ResourceRequirement{ID: 3, Kind: model.ResourceKindRoom, MinCapacity: 4}
+		small := model.Resource{ID: 11, Name: "Huddle", Kind: model.ResourceKindRoom, Capacity: 4}
+		large := model.Resource{ID: 12, Name: "Board room", Kind: model.ResourceKindRoom, Capacity: 12}
+		// the huddle room is booked by the review of another team
+		review := model.Event{ID: 8, Title: "Review", Status: model.EventStatusConfirmed, ConfirmedSlot: &model.EventSlot{StartTime: ten.Add(90 * time.Minute), EndTime: ten.Add(150 * time.Minute)}}
+		mockEventRepo := new(mock_repository.MockEventRepository)
+		mockResourceRepo := new(mock_repository.MockResourceRepository)
+		service := NewEventService(mockTransactionManager, mockEventRepo, mockResourceRepo, mockRecommendationService)
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(event, nil).Once()
+		mockEventRepo.On("GetEventSlots", ctx, eventID).Return(proposed, nil).Once()
+		mockEventRepo.On("GetEventAttendees", ctx, eventID).Return([]model.Attendee{}, nil).Once()
+		mockResourceRepo.On("GetEventResources", ctx, eventID).Return([]model.ResourceRequirement{requirement}, nil).Once()
+		mockResourceRepo.On("ListResources", ctx, model.ResourceFilter{Kind: model.ResourceKindRoom, MinCapacity: 4}).Return([]model.Resource{small, large}, nil).Once()
+		mockResourceRepo.On("GetResourceAvailability", ctx, []int64{11, 12}).Return(map[int64][]model.EventSlot{}, nil).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("LockEvent", ctx, tx, eventID).Return(event, nil).Once()
+		mockEventRepo.On("LockUsers", ctx, tx, []int64{5}).Return(nil).Once()
+		mockEventRepo.On("LockUserEvents", ctx, tx, []int64{5}, confirmed.StartTime, confirmed.EndTime).Return(map[int64][]model.Event{}, nil).Once()
+		mockResourceRepo.On("LockResources", ctx, tx, []int64{11, 12}).Return(nil).Once()
+		mockResourceRepo.On("ListBookedEvents", ctx, []int64{11, 12}, confirmed.StartTime, confirmed.EndTime).Return(map[int64][]model.Event{11: {review}}, nil).Once()
+		mockResourceRepo.On("BookEventResource", ctx, tx, int64(3), int64(12)).Return(nil).Once()
+		mockEventRepo.On("ConfirmEvent", ctx, tx, eventID, confirmed).Return(nil).Once()
+
+		slot, err := service.ConfirmEvent(ctx, eventID, &model.EventSlot{StartTime: ten.Add(time.Hour), EndTime: ten.Add(2 * time.Hour)})
+		assert.NoError(t, err)
+		assert.Equal(t, confirmed, slot)
+		mockEventRepo.AssertExpectations(t)
+		mockResourceRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return a conflict error when no resource is free for a requirement", func(t *testing.T) {
+		confirmed := model.EventSlot{StartTime: ten.Add(time.Hour), EndTime: ten.Add(2 * time.Hour), TimeZone: "Asia/Kolkata"}
+		requirement := model.ResourceRequirement{ID: 3, ResourceID: 11}
+		review := model.Event{ID: 8, Title: "Review", Status: model.EventStatusConfirmed, ConfirmedSlot: &model.EventSlot{StartTime: ten.Add(90 * time.Minute), EndTime: ten.Add(150 * time.Minute)}}
+		mockEventRepo := new(mock_repository.MockEventRepository)
+		mockResourceRepo := new(mock_repository.MockResourceRepository)
+		service := NewEventService(mockTransactionManager, mockEventRepo, mockResourceRepo, mockRecommendationService)
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(event, nil).Once()
+		mockEventRepo.On("GetEventSlots", ctx, eventID).Return(proposed, nil).Once()
+		mockEventRepo.On("GetEventAttendees", ctx, eventID).Return([]model.Attendee{}, nil).Once()
+		mockResourceRepo.On("GetEventResources", ctx, eventID).Return([]model.ResourceRequirement{requirement}, nil).Once()
+		mockResourceRepo.On("GetResource", ctx, int64(11)).Return(model.Resource{ID: 11, Name: "Huddle", Kind: model.ResourceKindRoom, Capacity: 4}, nil).Once()
+		mockResourceRepo.On("GetResourceAvailability", ctx, []int64{11}).Return(map[int64][]model.EventSlot{}, nil).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("LockEvent", ctx, tx, eventID).Return(event, nil).Once()
+		mockEventRepo.On("LockUsers", ctx, tx, []int64{5}).Return(nil).Once()
+		mockEventRepo.On("LockUserEvents", ctx, tx, []int64{5}, confirmed.StartTime, confirmed.EndTime).Return(map[int64][]model.Event{}, nil).Once()
+		mockResourceRepo.On("LockResources", ctx, tx, []int64{11}).Return(nil).Once()
+		mockResourceRepo.On("ListBookedEvents", ctx, []int64{11}, confirmed.StartTime, confirmed.EndTime).Return(map[int64][]model.Event{11: {review}}, nil).Once()
+
+		_, err := service.ConfirmEvent(ctx, eventID, &model.EventSlot{StartTime: ten.Add(time.Hour), EndTime: ten.Add(2 * time.Hour)})
+		var conflictErr *model.ConflictError
+		assert.ErrorAs(t, err, &conflictErr)
+		assert.Contains(t, conflictErr.Message, "requirement 3")
+		mockResourceRepo.AssertNotCalled(t, "BookEventResource", ctx, tx, int64(3), int64(11))
+		mockEventRepo.AssertNotCalled(t, "ConfirmEvent", ctx, tx, eventID, confirmed)
+		mockResourceRepo.AssertExpectations(t)
+	})
+}
+
+// lockingEventRepo stands in for the user_lock rows, LockUsers holds a lock per user until the confirmation that took
+// it has returned, and ConfirmEvent makes the event visible to LockUserEvents of later confirmations
+type lockingEventRepo struct {
+	*mock_repository.MockEventRepository
+	participants map[int64][]int64
+	mu           sync.Mutex
+	users        map[int64]*sync.Mutex
+	held         map[context.Context][]*sync.Mutex
+	confirmed    map[int64][]model.Event
+}
+
+func (r *lockingEventRepo) LockUsers(ctx context.Context, tx *sql.Tx, userIDs []int64) error {
+	ids := slices.Clone(userIDs)
+	slices.Sort(ids)
+	for _, id := range ids {
+		r.mu.Lock()
+		lock, ok := r.users[id]
+		if !ok {
+			lock = &sync.Mutex{}
+			r.users[id] = lock
+		}
+		r.mu.Unlock()
+
+		lock.Lock()
+		r.mu.Lock()
+		r.held[ctx] = append(r.held[ctx], lock)
+		r.mu.Unlock()
+	}
+	return nil
+}
+
+func (r *lockingEventRepo) LockUserEvents(ctx context.Context, tx *sql.Tx, userIDs []int64, from time.Time, to time.Time) (map[int64][]model.Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	userEvents := make(map[int64][]model.Event)
+	for _, id := range userIDs {
+		if events := r.confirmed[id]; len(events) > 0 {
+			userEvents[id] = slices.Clone(events)
+		}
+	}
+	return userEvents, nil
+}
+
+func (r *lockingEventRepo) ConfirmEvent(ctx context.Context, tx *sql.Tx, eventID int64, slot model.EventSlot) error {
+	// leave the other confirmation time to read the events of the participants before this one is stored
+	time.Sleep(20 * time.Millisecond)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	event := model.Event{ID: eventID, Status: model.EventStatusConfirmed, ConfirmedSlot: &slot}
+	for _, id := range r.participants[eventID] {
+		r.confirmed[id] = append(r.confirmed[id], event)
+	}
+	return nil
+}
+
+// release ends the transaction of the confirmation running with ctx
+func (r *lockingEventRepo) release(ctx context.Context) {
+	r.mu.Lock()
+	locks := r.held[ctx]
+	delete(r.held, ctx)
+	r.mu.Unlock()
+	for _, lock := range locks {
+		lock.Unlock()
+	}
+}
+
+func TestConfirmEventConcurrently(t *testing.T) {
+	ten := time.Date(2025, 07, 14, 10, 0, 0, 0, time.UTC)
+	slot := model.EventSlot{StartTime: ten, EndTime: ten.Add(time.Hour)}
+	// two events of different organizers share attendee 7 and are confirmed for the same hour at the same time
+	events := []model.Event{
+		{ID: 1, OrganizerID: 5, DurationMinutes: 60, TimeZone: "UTC", Status: model.EventStatusPolling},
+		{ID: 2, OrganizerID: 6, DurationMinutes: 60, TimeZone: "UTC", Status: model.EventStatusPolling},
+	}
+	eventRepo := &lockingEventRepo{
+		MockEventRepository: new(mock_repository.MockEventRepository),
+		participants:        map[int64][]int64{1: {5, 7}, 2: {6, 7}},
+		users:               make(map[int64]*sync.Mutex),
+		held:                make(map[context.Context][]*sync.Mutex),
+		confirmed:           make(map[int64][]model.Event),
+	}
+	mockTransactionManager := new(mock_repository.MockTransactionManager)
+	mockResourceRepo := new(mock_repository.MockResourceRepository)
+	mockResourceRepo.On("GetEventResources", testifyMock.Anything, testifyMock.Anything).Return([]model.ResourceRequirement{}, nil)
+	for _, event := range events {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+		mock.ExpectBegin()
+		tx, err := db.Begin()
+		assert.NoError(t, err)
+
+		mockTransactionManager.On("BeginTransaction", testifyMock.Anything).Return(tx, nil).Once()
+		eventRepo.On("GetEvent", testifyMock.Anything, event.ID).Return(event, nil)
+		eventRepo.On("GetEventSlots", testifyMock.Anything, event.ID).Return([]model.EventSlot{slot}, nil)
+		eventRepo.On("LockEvent", testifyMock.Anything, testifyMock.Anything, event.ID).Return(event, nil)
+		eventRepo.On("GetEventAttendees", testifyMock.Anything, event.ID).Return([]model.Attendee{{UserID: 7}}, nil)
+	}
+	service := NewEventService(mockTransactionManager, eventRepo, mockResourceRepo, nil)
+
+	t.Run("Function must let only one of two overlapping events sharing an attendee be confirmed", func(t *testing.T) {
+		errs := make([]error, len(events))
+		var wg sync.WaitGroup
+		for i, event := range events {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ctx := context.WithValue(context.Background(), confirmationKey{}, i)
+				defer eventRepo.release(ctx)
+				_, errs[i] = service.ConfirmEvent(ctx, event.ID, &slot)
+			}()
+		}
+		wg.Wait()
+
+		confirmed := 0
+		for _, err := range errs {
+			if err == nil {
+				confirmed++
+				continue
+			}
+			var conflictErr *model.ConflictError
+			if assert.ErrorAs(t, err, &conflictErr) {
+				assert.Equal(t, int64(7), conflictErr.Conflicts[0].UserID)
+			}
+		}
+		assert.Equal(t, 1, confirmed)
+		assert.Len(t, eventRepo.confirmed[7], 1)
+	})
+}
+
+// confirmationKey tells the contexts of concurrent confirmations apart
+type confirmationKey struct{}
+
+func TestCancelEvent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	mockEventRepo := new(mock_repository.MockEventRepository)
+	mockTransactionManager := new(mock_repository.MockTransactionManager)
+	mockResourceRepo := new(mock_repository.MockResourceRepository)
+	service := NewEventService(mockTransactionManager, mockEventRepo, mockResourceRepo, nil)
+	ctx := context.Background()
+	eventID := int64(1)
+
+	t.Run("Function must return a conflict error when the event is already cancelled", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{ID: eventID, Status: model.EventStatusCancelled}, nil).Once()
+		err := service.CancelEvent(ctx, eventID)
+		assert.ErrorIs(t, err, model.ErrConflict)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must cancel a confirmed event and release its resources", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{ID: eventID, Status: model.EventStatusConfirmed}, nil).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("CancelEvent", ctx, tx, eventID).Return(nil).Once()
+		mockResourceRepo.On("ReleaseEventResources", ctx, tx, eventID).Return(nil).Once()
+
+		err := service.CancelEvent(ctx, eventID)
+		assert.NoError(t, err)
+		mockEventRepo.AssertExpectations(t)
+		mockResourceRepo.AssertExpectations(t)
+		mock.ExpectCommit()
+	})
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/rahulshewale153/meeting-scheduler-api/model"
 )
@@ -10,15 +11,58 @@ type EventServiceI interface {
 	InsertEvent(ctx context.Context, createEventReq model.EventRequest) (int64, error)
 	UpdateEvent(ctx context.Context, updateEventReq model.EventRequest) error
 	DeleteEvent(ctx context.Context, eventID int64) error
+	GetEvent(ctx context.Context, eventID int64) (model.EventDetail, error)
+	ListEvents(ctx context.Context, filter model.EventFilter) (model.EventList, error)
+	AddAttendee(ctx context.Context, eventID int64, attendee model.Attendee) error
+	RemoveAttendee(ctx context.Context, eventID int64, userID int64) error
+	ListAttendees(ctx context.Context, eventID int64) ([]model.Attendee, error)
+	SetEventException(ctx context.Context, eventID int64, exception model.EventException) error
+	RemoveEventException(ctx context.Context, eventID int64, occurrenceDate string) error
+	ConfirmEvent(ctx context.Context, eventID int64, slot *model.EventSlot) (model.EventSlot, error)
+	CancelEvent(ctx context.Context, eventID int64) error
 }
 
 type UserAvailabilityServiceI interface {
 	InsertUserAvailability(ctx context.Context, userAvailability model.UserAvailability) error
+	InsertUserAvailabilityBatch(ctx context.Context, eventID int64, items []model.UserAvailability) (model.AvailabilityBatchResult, error)
 	UpdateUserAvailability(ctx context.Context, userAvailability model.UserAvailability) error
 	DeleteUserAvailability(ctx context.Context, userID int64, eventID int64) error
 	GetUserAvailability(ctx context.Context, eventID int64, userID int64) ([]model.EventSlot, error)
+	ImportUserAvailability(ctx context.Context, eventID int64, userID int64, calendar []byte) (model.AvailabilityImport, error)
+	ListUserEventAvailability(ctx context.Context, userID int64, filter model.UserEventFilter) (model.UserEventAvailabilityList, error)
+}
+
+type UserServiceI interface {
+	GetUserProfile(ctx context.Context, userID int64) (model.UserProfile, error)
+	UpdateUserProfile(ctx context.Context, profile model.UserProfile) error
 }
 
 type RecommendationServiceI interface {
-	GetRecommendedSlots(ctx context.Context, eventID int64) ([]model.SlotRecommendation, error)
+	GetRecommendedSlots(ctx context.Context, eventID int64, options model.RecommendationOptions) ([]model.SlotRecommendation, error)
+}
+
+type CalendarServiceI interface {
+	ExportEvent(ctx context.Context, eventID int64) ([]byte, error)
+	ExportUserCalendar(ctx context.Context, userID int64) ([]byte, error)
+}
+
+type AvailabilitySyncServiceI interface {
+	SyncOpenEvents(ctx context.Context) error
+}
+
+type ConflictServiceI interface {
+	GetUserConflicts(ctx context.Context, userID int64, from time.Time, to time.Time) ([]model.MeetingConflict, error)
+}
+
+type ResourceServiceI interface {
+	InsertResource(ctx context.Context, resource model.Resource) (int64, error)
+	UpdateResource(ctx context.Context, resource model.Resource) error
+	DeleteResource(ctx context.Context, resourceID int64) error
+	GetResource(ctx context.Context, resourceID int64) (model.Resource, error)
+	ListResources(ctx context.Context, filter model.ResourceFilter) ([]model.Resource, error)
+	SetResourceAvailability(ctx context.Context, resourceID int64, windows []model.EventSlot) error
+	GetResourceAvailability(ctx context.Context, resourceID int64, from time.Time, to time.Time) (model.ResourceAvailability, error)
+	AddEventResource(ctx context.Context, eventID int64, requirement model.ResourceRequirement) (int64, error)
+	RemoveEventResource(ctx context.Context, eventID int64, requirementID int64) error
+	ListEventResources(ctx context.Context, eventID int64) ([]model.ResourceRequirement, error)
 }
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+)
+
+// userInterval is a merged block of availability belonging to a single user, bounds are unix nanoseconds
+type userInterval struct {
+	userID int64
+	start  int64
+	end    int64
+}
+
+// startedIntervals holds the intervals that have started, positioned by the rank of their end. The windows are swept
+// backwards, so intervals only ever leave it, and a removed rank points to the rank after it: following the pointers
+// from a rank leads to the first interval still started at or after it, in near constant amortized time
+type startedIntervals struct {
+	next []int
+}
+
+// newStartedIntervals holds every one of n ranks, rank n stands for the end of the list
+func newStartedIntervals(n int) *startedIntervals {
+	next := make([]int, n+1)
+	for i := range next {
+		next[i] = i
+	}
+	return &startedIntervals{next: next}
+}
+
+// remove takes the interval of the given rank out
+func (s *startedIntervals) remove(rank int) {
+	s.next[rank] = rank + 1
+}
+
+// first returns the lowest rank at or after rank still held, or n when there is none
+func (s *startedIntervals) first(rank int) int {
+	for s.next[rank] != rank {
+		// halve the path on the way, so that later lookups skip the removed ranks
+		s.next[rank] = s.next[s.next[rank]]
+		rank = s.next[rank]
+	}
+	return rank
+}
+
+// matchFreeUsers returns, for every window, the ids of the users whose availability fully contains it.
+// The result is indexed like windows and every list is sorted by user id.
+//
+// A user is free for a window exactly when one of their intervals started by the window start reaches the window end.
+// The windows are swept from the last start back, taking out the intervals that start after each window, and every
+// window looks up the first started interval ending at or after its end by rank and walks the started ones after it,
+// which are exactly the free users. For n intervals and m windows this costs O((n+m) log n) for sorting and finding
+// the ranks, plus one step and the sorting for each user listed in the result.
+func matchFreeUsers(windows []model.EventSlot, availability map[int64][]model.EventSlot) [][]int64 {
+	intervals := []userInterval{}
+	for userID, slots := range availability {
+		for _, slot := range mergeIntervals(slots) {
+			intervals = append(intervals, userInterval{userID: userID, start: slot.StartTime.UnixNano(), end: slot.EndTime.UnixNano()})
+		}
+	}
+	// the rank of an interval is its position in intervals once they are sorted by end
+	slices.SortFunc(intervals, func(a, b userInterval) int { return cmp.Compare(a.end, b.end) })
+	ends := make([]int64, len(intervals))
+	byStart := make([]int, len(intervals))
+	for rank, interval := range intervals {
+		ends[rank] = interval.end
+		byStart[rank] = rank
+	}
+	slices.SortFunc(byStart, func(a, b int) int { return cmp.Compare(intervals[a].start, intervals[b].start) })
+
+	order := make([]int, len(windows))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(a, b int) int { return windows[a].StartTime.Compare(windows[b].StartTime) })
+
+	free := make([][]int64, len(windows))
+	started := newStartedIntervals(len(intervals))
+	last := len(byStart)
+	for w := len(order) - 1; w >= 0; w-- {
+		idx := order[w]
+		windowStart, windowEnd := windows[idx].StartTime.UnixNano(), windows[idx].EndTime.UnixNano()
+		for last > 0 && intervals[byStart[last-1]].start > windowStart {
+			last--
+			started.remove(byStart[last])
+		}
+
+		users := []int64{}
+		from, _ := slices.BinarySearch(ends, windowEnd)
+		for rank := started.first(from); rank < len(intervals); rank = started.first(rank + 1) {
+			users = append(users, intervals[rank].userID)
+		}
+		slices.Sort(users)
+		free[idx] = users
+	}
+	return free
+}
+
+// mergeIntervals sorts the slots and joins the ones that overlap or touch, so that
+// availability submitted as 10:00-11:00 and 11:00-12:00 covers a meeting at 10:30
+func mergeIntervals(slots []model.EventSlot) []model.EventSlot {
+	sorted := make([]model.EventSlot, len(slots))
+	copy(sorted, slots)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].StartTime.Before(sorted[j].StartTime)
+	})
+
+	merged := []model.EventSlot{}
+	for _, slot := range sorted {
+		last := len(merged) - 1
+		if last >= 0 && !slot.StartTime.After(merged[last].EndTime) {
+			if slot.EndTime.After(merged[last].EndTime) {
+				merged[last].EndTime = slot.EndTime
+			}
+			continue
+		}
+		merged = append(merged, model.EventSlot{StartTime: slot.StartTime, EndTime: slot.EndTime})
+	}
+	return merged
+}
+
+// subtractIntervals returns the parts of the windows that no busy interval covers and that last at least minLength.
+// Both lists must be merged and sorted by start, as mergeIntervals returns them
+func subtractIntervals(windows []model.EventSlot, busy []model.EventSlot, minLength time.Duration) []model.EventSlot {
+	free := []model.EventSlot{}
+	keep := func(start, end time.Time) {
+		if end.Sub(start) >= minLength {
+			free = append(free, model.EventSlot{StartTime: start, EndTime: end})
+		}
+	}
+
+	next := 0
+	for _, window := range windows {
+		// busy intervals that end before this window also end before every later one
+		for next < len(busy) && !busy[next].EndTime.After(window.StartTime) {
+			next++
+		}
+		start := window.StartTime
+		for i := next; i < len(busy) && busy[i].StartTime.Before(window.EndTime); i++ {
+			if busy[i].StartTime.After(start) {
+				keep(start, busy[i].StartTime)
+			}
+			if busy[i].EndTime.After(start) {
+				start = busy[i].EndTime
+			}
+		}
+		if window.EndTime.After(start) {
+			keep(start, window.EndTime)
+		}
+	}
+	return free
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchFreeUsers(t *testing.T) {
+	base := time.Date(2025, 07, 13, 9, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return base.Add(time.Duration(minutes) * time.Minute) }
+	window := func(start, end int) model.EventSlot { return model.EventSlot{StartTime: at(start), EndTime: at(end)} }
+
+	t.Run("Function must return the users whose availability contains each window", func(t *testing.T) {
+		availability := map[int64][]model.EventSlot{
+			1: {window(0, 120)},
+			2: {window(60, 120)},
+			3: {window(0, 30)},
+		}
+		windows := []model.EventSlot{window(60, 120), window(0, 60), window(30, 90)}
+
+		free := matchFreeUsers(windows, availability)
+		assert.Equal(t, [][]int64{{1, 2}, {1}, {1}}, free)
+	})
+
+	t.Run("Function must join back to back and overlapping availability of the same user", func(t *testing.T) {
+		availability := map[int64][]model.EventSlot{
+			1: {window(60, 120), window(0, 60)},
+			2: {window(0, 45), window(30, 90)},
+		}
+
+		free := matchFreeUsers([]model.EventSlot{window(30, 90), window(60, 120)}, availability)
+		assert.Equal(t, [][]int64{{1, 2}, {1}}, free)
+	})
+
+	t.Run("Function must return an empty list for windows nobody can attend", func(t *testing.T) {
+		availability := map[int64][]model.EventSlot{
+			1: {window(0, 30), window(90, 120)},
+		}
+
+		free := matchFreeUsers([]model.EventSlot{window(30, 90)}, availability)
+		assert.Equal(t, [][]int64{{}}, free)
+	})
+
+	t.Run("Function must agree with a brute force containment check", func(t *testing.T) {
+		availability := benchmarkAvailability(50, 40)
+		windows := benchmarkWindows(100)
+
+		free := matchFreeUsers(windows, availability)
+		for i, w := range windows {
+			expected := []int64{}
+			for userID := int64(1); userID <= 50; userID++ {
+				for _, slot := range mergeIntervals(availability[userID]) {
+					if !slot.StartTime.After(w.StartTime) && !slot.EndTime.Before(w.EndTime) {
+						expected = append(expected, userID)
+						break
+					}
+				}
+			}
+			assert.Equal(t, expected, free[i])
+		}
+	})
+}
+
+// benchmarkAvailability gives every user slotsPerUser slots of one hour, shifted per user so that they partly overlap
+func benchmarkAvailability(users int, slotsPerUser int) map[int64][]model.EventSlot {
+	base := time.Date(2025, 07, 14, 0, 0, 0, 0, time.UTC)
+	availability := make(map[int64][]model.EventSlot, users)
+	for u := 0; u < users; u++ {
+		slots := make([]model.EventSlot, 0, slotsPerUser)
+		for s := 0; s < slotsPerUser; s++ {
+			start := base.Add(time.Duration(s*90+(u*15)%60) * time.Minute)
+			slots = append(slots, model.EventSlot{StartTime: start, EndTime: start.Add(time.Hour)})
+		}
+		availability[int64(u+1)] = slots
+	}
+	return availability
+}
+
+// benchmarkWindows returns 30 minute candidate windows every 15 minutes
+func benchmarkWindows(count int) []model.EventSlot {
+	base := time.Date(2025, 07, 14, 0, 0, 0, 0, time.UTC)
+	windows := make([]model.EventSlot, 0, count)
+	for i := 0; i < count; i++ {
+		start := base.Add(time.Duration(i*15) * time.Minute)
+		windows = append(windows, model.EventSlot{StartTime: start, EndTime: start.Add(30 * time.Minute)})
+	}
+	return windows
+}
+
+func TestSubtractIntervals(t *testing.T) {
+	base := time.Date(2025, 07, 13, 9, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return base.Add(time.Duration(minutes) * time.Minute) }
+	window := func(start, end int) model.EventSlot { return model.EventSlot{StartTime: at(start), EndTime: at(end)} }
+
+	t.Run("Function must return the windows untouched when nothing is busy", func(t *testing.T) {
+		free := subtractIntervals([]model.EventSlot{window(0, 60), window(120, 180)}, nil, 30*time.Minute)
+		assert.Equal(t, []model.EventSlot{window(0, 60), window(120, 180)}, free)
+	})
+
+	t.Run("Function must cut the busy intervals out of every window they overlap", func(t *testing.T) {
+		windows := []model.EventSlot{window(0, 120), window(180, 300)}
+		busy := []model.EventSlot{window(-30, 15), window(45, 60), window(100, 200), window(240, 260)}
+
+		free := subtractIntervals(windows, busy, 0)
+		assert.Equal(t, []model.EventSlot{window(15, 45), window(60, 100), window(200, 240), window(260, 300)}, free)
+	})
+
+	t.Run("Function must leave out free parts shorter than the minimum length", func(t *testing.T) {
+		free := subtractIntervals([]model.EventSlot{window(0, 120)}, []model.EventSlot{window(30, 60)}, 45*time.Minute)
+		assert.Equal(t, []model.EventSlot{window(60, 120)}, free)
+	})
+
+	t.Run("Function must return nothing for a window that is busy throughout", func(t *testing.T) {
+		free := subtractIntervals([]model.EventSlot{window(0, 60)}, []model.EventSlot{window(0, 60)}, 0)
+		assert.Empty(t, free)
+	})
+}
+
+func BenchmarkMatchFreeUsers(b *testing.B) {
+	// 1,000 users with 500 availability slots each, matched against 500 proposed slots
+	availability := benchmarkAvailability(1000, 500)
+	windows := benchmarkWindows(500)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		matchFreeUsers(windows, availability)
+	}
+}
+
+func BenchmarkMatchFreeUsersPerWindow(b *testing.B) {
+	// two hour windows every minute that nobody is free for, while most users have an interval running, so the cost
+	// of a window does not depend on the number of users
+	availability := benchmarkAvailability(1000, 500)
+	base := time.Date(2025, 07, 14, 0, 0, 0, 0, time.UTC)
+	for _, count := range []int{500, 5000, 50000} {
+		windows := make([]model.EventSlot, 0, count)
+		for i := 0; i < count; i++ {
+			start := base.Add(time.Duration(i) * time.Minute)
+			windows = append(windows, model.EventSlot{StartTime: start, EndTime: start.Add(2 * time.Hour)})
+		}
+		b.Run(fmt.Sprintf("%d windows", count), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				matchFreeUsers(windows, availability)
+			}
+		})
+	}
+}
+
+func BenchmarkGenerateAndMatchCandidates(b *testing.B) {
+	availability := benchmarkAvailability(1000, 500)
+	base := time.Date(2025, 07, 14, 0, 0, 0, 0, time.UTC)
+	proposed := make([]model.EventSlot, 0, 500)
+	for i := 0; i < 500; i++ {
+		start := base.Add(time.Duration(i*90) * time.Minute)
+		proposed = append(proposed, model.EventSlot{StartTime: start, EndTime: start.Add(2 * time.Hour)})
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		windows := []model.EventSlot{}
+		for _, slot := range proposed {
+			windows = append(windows, generateCandidateSlots(slot, 30*time.Minute, 15*time.Minute, time.UTC)...)
+		}
+		matchFreeUsers(uniqueWindows(windows), availability)
+	}
+}
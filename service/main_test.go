@@ -0,0 +1,13 @@
+package service
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	// pin the validation clock before the fixed dates used across the tests
+	now = func() time.Time { return time.Date(2025, 07, 1, 0, 0, 0, 0, time.UTC) }
+	os.Exit(m.Run())
+}
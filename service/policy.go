@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+)
+
+// The rules below decide who may change what. A context without an identity belongs to a request made while
+// authentication is disabled and is allowed everything, admins are allowed everything as well.
+
+// authorizeOrganizer allows organizers to create events, or take over an event, organized by themselves
+func authorizeOrganizer(ctx context.Context, organizerID int64) error {
+	caller, ok := model.IdentityFromContext(ctx)
+	if !ok || caller.HasRole(model.RoleAdmin) {
+		return nil
+	}
+	if !caller.HasRole(model.RoleOrganizer) {
+		return &model.ForbiddenError{Message: "only organizers can organize events"}
+	}
+	if caller.UserID != organizerID {
+		return &model.ForbiddenError{Message: fmt.Sprintf("organizers can only organize events themselves, not on behalf of user %d", organizerID)}
+	}
+	return nil
+}
+
+// authorizeEventChange allows the organizer of an event to change it
+func authorizeEventChange(ctx context.Context, event model.Event) error {
+	caller, ok := model.IdentityFromContext(ctx)
+	if !ok || caller.HasRole(model.RoleAdmin) || isOrganizer(caller, event) {
+		return nil
+	}
+	return &model.ForbiddenError{Message: fmt.Sprintf("only the organizer can change event %d", event.ID)}
+}
+
+// authorizeEventRead allows the organizer and the attendees of an event to read it
+func authorizeEventRead(ctx context.Context, event model.Event, attendees []model.Attendee) error {
+	caller, ok := model.IdentityFromContext(ctx)
+	if !ok || caller.HasRole(model.RoleAdmin) || isOrganizer(caller, event) {
+		return nil
+	}
+	for _, attendee := range attendees {
+		if isUser(caller, attendee.UserID) {
+			return nil
+		}
+	}
+	return &model.ForbiddenError{Message: fmt.Sprintf("only the organizer and the attendees can read event %d", event.ID)}
+}
+
+// authorizeEventList allows organizers to list their own events, a filter without an organizer is narrowed to the
+// caller's events
+func authorizeEventList(ctx context.Context, filter *model.EventFilter) error {
+	caller, ok := model.IdentityFromContext(ctx)
+	if !ok || caller.HasRole(model.RoleAdmin) {
+		return nil
+	}
+	if filter.OrganizerID == 0 && caller.UserID != 0 {
+		filter.OrganizerID = caller.UserID
+	}
+	if caller.UserID != 0 && filter.OrganizerID == caller.UserID {
+		return nil
+	}
+	return &model.ForbiddenError{Message: "only admins can list the events of other organizers"}
+}
+
+// authorizeRecommendations allows the organizer of an event to see the slots recommended for it
+func authorizeRecommendations(ctx context.Context, event model.Event) error {
+	caller, ok := model.IdentityFromContext(ctx)
+	if !ok || caller.HasRole(model.RoleAdmin) || isOrganizer(caller, event) {
+		return nil
+	}
+	return &model.ForbiddenError{Message: fmt.Sprintf("only the organizer can see the recommendations for event %d", event.ID)}
+}
+
+// authorizeUserCalendar allows users to read their own calendar and conflicts
+func authorizeUserCalendar(ctx context.Context, userID int64) error {
+	caller, ok := model.IdentityFromContext(ctx)
+	if !ok || caller.HasRole(model.RoleAdmin) || isUser(caller, userID) {
+		return nil
+	}
+	return &model.ForbiddenError{Message: fmt.Sprintf("only user %d can read their calendar", userID)}
+}
+
+// authorizeAttendeeRemoval allows the organizer to remove any attendee and attendees to leave an event
+func authorizeAttendeeRemoval(ctx context.Context, event model.Event, userID int64) error {
+	caller, ok := model.IdentityFromContext(ctx)
+	if !ok || caller.HasRole(model.RoleAdmin) || isOrganizer(caller, event) || isUser(caller, userID) {
+		return nil
+	}
+	return &model.ForbiddenError{Message: fmt.Sprintf("only the organizer can remove user %d from event %d", userID, event.ID)}
+}
+
+// authorizeAvailability allows users to manage their own availability and the organizer that of every attendee
+func authorizeAvailability(ctx context.Context, event model.Event, userID int64) error {
+	caller, ok := model.IdentityFromContext(ctx)
+	if !ok || caller.HasRole(model.RoleAdmin) || isOrganizer(caller, event) || isUser(caller, userID) {
+		return nil
+	}
+	return &model.ForbiddenError{Message: fmt.Sprintf("only user %d or the organizer can access their availability for event %d", userID, event.ID)}
+}
+
+// authorizeUserAvailability allows users to list what they offered across events
+func authorizeUserAvailability(ctx context.Context, userID int64) error {
+	caller, ok := model.IdentityFromContext(ctx)
+	if !ok || caller.HasRole(model.RoleAdmin) || isUser(caller, userID) {
+		return nil
+	}
+	return &model.ForbiddenError{Message: fmt.Sprintf("only user %d can list their availability", userID)}
+}
+
+// authorizeProfileChange allows users to change their own profile
+func authorizeProfileChange(ctx context.Context, userID int64) error {
+	caller, ok := model.IdentityFromContext(ctx)
+	if !ok || caller.HasRole(model.RoleAdmin) || isUser(caller, userID) {
+		return nil
+	}
+	return &model.ForbiddenError{Message: fmt.Sprintf("only user %d can change their profile", userID)}
+}
+
+// authorizeResourceChange allows admins to manage rooms and equipment
+func authorizeResourceChange(ctx context.Context) error {
+	caller, ok := model.IdentityFromContext(ctx)
+	if !ok || caller.HasRole(model.RoleAdmin) {
+		return nil
+	}
+	return &model.ForbiddenError{Message: "only admins can manage resources"}
+}
+
+func isOrganizer(caller model.Identity, event model.Event) bool {
+	return caller.UserID != 0 && caller.UserID == event.OrganizerID
+}
+
+// isUser reports whether the caller is the given user, acting as an attendee
+func isUser(caller model.Identity, userID int64) bool {
+	return caller.UserID != 0 && caller.UserID == userID && caller.HasRole(model.RoleAttendee)
+}
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// callers used by the policy tests, user 5 organizes the event
+var (
+	anonymous      = context.Background()
+	organizer      = model.WithIdentity(anonymous, model.Identity{UserID: 5, Subject: "5", Roles: []string{model.RoleOrganizer}})
+	otherOrganizer = model.WithIdentity(anonymous, model.Identity{UserID: 6, Subject: "6", Roles: []string{model.RoleOrganizer}})
+	attendee       = model.WithIdentity(anonymous, model.Identity{UserID: 7, Subject: "7"})
+	admin          = model.WithIdentity(anonymous, model.Identity{UserID: 8, Subject: "8", Roles: []string{model.RoleAdmin}})
+	serviceAccount = model.WithIdentity(anonymous, model.Identity{Subject: "reporting", ServiceAccount: true})
+	adminAccount   = model.WithIdentity(anonymous, model.Identity{Subject: "hr-sync", Roles: []string{model.RoleAdmin}, ServiceAccount: true})
+)
+
+func TestAuthorizeOrganizer(t *testing.T) {
+	testCases := []struct {
+		name      string
+		ctx       context.Context
+		forbidden bool
+	}{
+		{"Function must allow an anonymous caller", anonymous, false},
+		{"Function must allow an organizer organizing the event", organizer, false},
+		{"Function must allow an admin organizing for another user", admin, false},
+		{"Function must allow an admin service account", adminAccount, false},
+		{"Function must forbid an organizer organizing for another user", otherOrganizer, true},
+		{"Function must forbid an attendee", attendee, true},
+		{"Function must forbid a service account without roles", serviceAccount, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := authorizeOrganizer(tc.ctx, 5)
+			assert.Equal(t, tc.forbidden, err != nil)
+			if tc.forbidden {
+				assert.ErrorIs(t, err, model.ErrForbidden)
+			}
+		})
+	}
+}
+
+func TestAuthorizeEventChange(t *testing.T) {
+	event := model.Event{ID: 1, OrganizerID: 5}
+	testCases := []struct {
+		name      string
+		ctx       context.Context
+		forbidden bool
+	}{
+		{"Function must allow an anonymous caller", anonymous, false},
+		{"Function must allow the organizer of the event", organizer, false},
+		{"Function must allow an admin", admin, false},
+		{"Function must forbid the organizer of another event", otherOrganizer, true},
+		{"Function must forbid an attendee", attendee, true},
+		{"Function must forbid a service account without roles", serviceAccount, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := authorizeEventChange(tc.ctx, event)
+			assert.Equal(t, tc.forbidden, err != nil)
+			if tc.forbidden {
+				assert.ErrorIs(t, err, model.ErrForbidden)
+			}
+		})
+	}
+}
+
+func TestAuthorizeEventRead(t *testing.T) {
+	event := model.Event{ID: 1, OrganizerID: 5}
+	attendees := []model.Attendee{{UserID: 7}}
+	testCases := []struct {
+		name      string
+		ctx       context.Context
+		attendees []model.Attendee
+		forbidden bool
+	}{
+		{"Function must allow an anonymous caller", anonymous, attendees, false},
+		{"Function must allow the organizer of the event", organizer, attendees, false},
+		{"Function must allow an attendee of the event", attendee, attendees, false},
+		{"Function must allow an admin", admin, attendees, false},
+		{"Function must forbid a user not invited to the event", attendee, []model.Attendee{{UserID: 9}}, true},
+		{"Function must forbid the organizer of another event", otherOrganizer, attendees, true},
+		{"Function must forbid a service account without roles", serviceAccount, attendees, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := authorizeEventRead(tc.ctx, event, tc.attendees)
+			assert.Equal(t, tc.forbidden, err != nil)
+			if tc.forbidden {
+				assert.ErrorIs(t, err, model.ErrForbidden)
+			}
+		})
+	}
+}
+
+func TestAuthorizeEventList(t *testing.T) {
+	testCases := []struct {
+		name        string
+		ctx         context.Context
+		organizerID int64
+		forbidden   bool
+		expected    int64
+	}{
+		{"Function must allow an anonymous caller every organizer", anonymous, 0, false, 0},
+		{"Function must allow an admin every organizer", admin, 0, false, 0},
+		{"Function must allow an organizer their own events", organizer, 5, false, 5},
+		{"Function must narrow a filter without an organizer to the caller", organizer, 0, false, 5},
+		{"Function must forbid an organizer the events of another organizer", otherOrganizer, 5, true, 5},
+		{"Function must forbid a service account without roles", serviceAccount, 0, true, 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filter := model.EventFilter{OrganizerID: tc.organizerID}
+			err := authorizeEventList(tc.ctx, &filter)
+			assert.Equal(t, tc.forbidden, err != nil)
+			assert.Equal(t, tc.expected, filter.OrganizerID)
+		})
+	}
+}
+
+func TestAuthorizeRecommendations(t *testing.T) {
+	event := model.Event{ID: 1, OrganizerID: 5}
+	testCases := []struct {
+		name      string
+		ctx       context.Context
+		forbidden bool
+	}{
+		{"Function must allow an anonymous caller", anonymous, false},
+		{"Function must allow the organizer of the event", organizer, false},
+		{"Function must allow an admin service account", adminAccount, false},
+		{"Function must forbid an attendee", attendee, true},
+		{"Function must forbid the organizer of another event", otherOrganizer, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := authorizeRecommendations(tc.ctx, event)
+			assert.Equal(t, tc.forbidden, err != nil)
+		})
+	}
+}
+
+func TestAuthorizeUserCalendar(t *testing.T) {
+	testCases := []struct {
+		name      string
+		ctx       context.Context
+		userID    int64
+		forbidden bool
+	}{
+		{"Function must allow a user their own calendar", attendee, 7, false},
+		{"Function must allow an admin the calendar of any user", admin, 7, false},
+		{"Function must forbid a user the calendar of another user", organizer, 7, true},
+		{"Function must forbid a service account without roles", serviceAccount, 7, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := authorizeUserCalendar(tc.ctx, tc.userID)
+			assert.Equal(t, tc.forbidden, err != nil)
+		})
+	}
+}
+
+func TestAuthorizeAttendeeRemoval(t *testing.T) {
+	event := model.Event{ID: 1, OrganizerID: 5}
+	testCases := []struct {
+		name      string
+		ctx       context.Context
+		userID    int64
+		forbidden bool
+	}{
+		{"Function must allow the organizer to remove an attendee", organizer, 7, false},
+		{"Function must allow an attendee to leave the event", attendee, 7, false},
+		{"Function must allow an admin to remove an attendee", admin, 7, false},
+		{"Function must forbid an attendee to remove another attendee", attendee, 9, true},
+		{"Function must forbid the organizer of another event", otherOrganizer, 7, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := authorizeAttendeeRemoval(tc.ctx, event, tc.userID)
+			assert.Equal(t, tc.forbidden, err != nil)
+		})
+	}
+}
+
+func TestAuthorizeAvailability(t *testing.T) {
+	event := model.Event{ID: 1, OrganizerID: 5}
+	testCases := []struct {
+		name      string
+		ctx       context.Context
+		userID    int64
+		forbidden bool
+	}{
+		{"Function must allow an anonymous caller", anonymous, 7, false},
+		{"Function must allow a user their own availability", attendee, 7, false},
+		{"Function must allow the organizer the availability of an attendee", organizer, 7, false},
+		{"Function must allow an admin service account the availability of any user", adminAccount, 7, false},
+		{"Function must forbid a user the availability of another user", attendee, 9, true},
+		{"Function must forbid the organizer of another event", otherOrganizer, 7, true},
+		{"Function must forbid a service account without roles", serviceAccount, 7, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := authorizeAvailability(tc.ctx, event, tc.userID)
+			assert.Equal(t, tc.forbidden, err != nil)
+			if tc.forbidden {
+				assert.ErrorIs(t, err, model.ErrForbidden)
+			}
+		})
+	}
+}
+
+func TestAuthorizeUserAvailability(t *testing.T) {
+	testCases := []struct {
+		name      string
+		ctx       context.Context
+		userID    int64
+		forbidden bool
+	}{
+		{"Function must allow a user their own availability", attendee, 7, false},
+		{"Function must allow an admin the availability of any user", admin, 7, false},
+		{"Function must forbid the organizer of an event of the user", organizer, 7, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := authorizeUserAvailability(tc.ctx, tc.userID)
+			assert.Equal(t, tc.forbidden, err != nil)
+		})
+	}
+}
+
+func TestAuthorizeProfileChange(t *testing.T) {
+	testCases := []struct {
+		name      string
+		ctx       context.Context
+		userID    int64
+		forbidden bool
+	}{
+		{"Function must allow a user their own profile", attendee, 7, false},
+		{"Function must allow an admin any profile", admin, 7, false},
+		{"Function must forbid a user the profile of another user", organizer, 7, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := authorizeProfileChange(tc.ctx, tc.userID)
+			assert.Equal(t, tc.forbidden, err != nil)
+		})
+	}
+}
+
+func TestAuthorizeResourceChange(t *testing.T) {
+	testCases := []struct {
+		name      string
+		ctx       context.Context
+		forbidden bool
+	}{
+		{"Function must allow an anonymous caller", anonymous, false},
+		{"Function must allow an admin", admin, false},
+		{"Function must forbid an organizer", organizer, true},
+		{"Function must forbid a service account without roles", serviceAccount, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := authorizeResourceChange(tc.ctx)
+			assert.Equal(t, tc.forbidden, err != nil)
+		})
+	}
+}
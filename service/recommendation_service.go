@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"log"
 	"sort"
 	"time"
 
@@ -10,23 +11,45 @@ import (
 	"github.com/rahulshewale153/meeting-scheduler-api/utils"
 )
 
+// defaultRecommendationStep is the gap between two candidate start times when none is configured
+const defaultRecommendationStep = 15 * time.Minute
+
 type recommendationService struct {
 	eventRepo            repository.EventRepositoryI
 	userAvailabilityRepo repository.UserAvailabilityRepositoryI
+	userRepo             repository.UserRepositoryI
+	resourceRepo         repository.ResourceRepositoryI
+	step                 time.Duration
 }
 
-// NewRecommendationService creates a new instance of recommendationService
-func NewRecommendationService(eventRepo repository.EventRepositoryI, userAvailabilityRepo repository.UserAvailabilityRepositoryI) RecommendationServiceI {
-	return &recommendationService{eventRepo: eventRepo, userAvailabilityRepo: userAvailabilityRepo}
+// NewRecommendationService creates a new instance of recommendationService, candidate slots start every step
+func NewRecommendationService(eventRepo repository.EventRepositoryI, userAvailabilityRepo repository.UserAvailabilityRepositoryI, userRepo repository.UserRepositoryI, resourceRepo repository.ResourceRepositoryI, step time.Duration) RecommendationServiceI {
+	if step <= 0 {
+		step = defaultRecommendationStep
+	}
+	return &recommendationService{eventRepo: eventRepo, userAvailabilityRepo: userAvailabilityRepo, userRepo: userRepo, resourceRepo: resourceRepo, step: step}
 }
 
-func (s *recommendationService) GetRecommendedSlots(ctx context.Context, eventID int64) ([]model.SlotRecommendation, error) {
+// GetRecommendedSlots ranks the candidate windows of an event that pass the filters in options and returns
+// at most options.Limit of them. The order is total: slots free for every required attendee come first,
+// then higher scores, then the requested tie-break, then earlier start time. Attendees whose working hours do not
+// cover a slot are reported with it, and counted as unavailable when options.WorkingHours is exclude.
+// A recurring event is judged on options.Occurrences upcoming occurrences at each candidate time. When the event
+// requires resources, only slots where each requirement has a free resource are returned
+func (s *recommendationService) GetRecommendedSlots(ctx context.Context, eventID int64, options model.RecommendationOptions) ([]model.SlotRecommendation, error) {
 	results := []model.SlotRecommendation{}
+	if err := validateRecommendationOptions(options); err != nil {
+		return results, err
+	}
+
 	// Get the event details
 	event, err := s.eventRepo.GetEvent(ctx, eventID)
 	if err != nil {
 		return results, err
 	}
+	if err := authorizeRecommendations(ctx, event); err != nil {
+		return results, err
+	}
 
 	//Get the event slot
 	eventSlots, err := s.eventRepo.GetEventSlots(ctx, eventID)
@@ -39,72 +62,406 @@ func (s *recommendationService) GetRecommendedSlots(ctx context.Context, eventID
 	if err != nil {
 		return results, err
 	}
+	userRules, err := s.userAvailabilityRepo.GetAllEventRules(ctx, eventID)
+	if err != nil {
+		return results, err
+	}
+	// users with recurring availability have responded even when no occurrence falls on a candidate slot
+	for userID := range userRules {
+		if _, ok := userAvailability[userID]; !ok {
+			userAvailability[userID] = []model.EventSlot{}
+		}
+	}
 	// If no users are available, return an empty slice
 	if len(userAvailability) == 0 {
 		return results, nil
 	}
 
-	// Step 1: Normalize event slots into time-frame
-	eventSlotMap := make(map[string]model.EventSlot)
-	userSlotMap := make(map[string][]int64)
-
+	// Step 1: Slide a window of the meeting duration over every proposed slot, on the wall clock of the event
+	loc, err := utils.LoadTimeZone(event.TimeZone)
+	if err != nil {
+		log.Println("Error loading event time zone:", err)
+		loc = time.UTC
+	}
+	duration := time.Duration(event.DurationMinutes) * time.Minute
+	windows := []model.EventSlot{}
 	for _, es := range eventSlots {
-		timeFrames := breakIntoTimeFrames(es, time.Duration(event.DurationMinutes)*time.Minute)
-		for _, frame := range timeFrames {
-			key := utils.SlotKey(frame)
-			eventSlotMap[key] = frame
+		windows = append(windows, generateCandidateSlots(es, duration, s.step, loc)...)
+	}
+	windows = filterWindows(uniqueWindows(windows), options)
+
+	// Step 1b: A recurring event is judged on its next occurrences at each candidate time, series[i] holds the
+	// indexes into occurrenceWindows of the occurrences of windows[i]. A one-off meeting is its own only occurrence
+	occurrenceWindows := windows
+	series := make([][]int, len(windows))
+	for i := range windows {
+		series[i] = []int{i}
+	}
+	if event.RRule != "" {
+		occurrenceWindows, series, err = s.expandSeries(ctx, event, windows, loc, options.Occurrences)
+		if err != nil {
+			return results, err
 		}
 	}
 
-	// Step 2: Check user availability per time-frame
-	for userID, slots := range userAvailability {
-		for _, slot := range slots {
-			timeFrames := breakIntoTimeFrames(slot, time.Duration(event.DurationMinutes)*time.Minute)
-			for _, frame := range timeFrames {
-				key := utils.SlotKey(frame)
-				if _, ok := eventSlotMap[key]; ok {
-					userSlotMap[key] = append(userSlotMap[key], userID)
-				}
+	// Step 1c: Load the resources that can serve each resource requirement of the event, rooms must seat everyone
+	attendees, err := s.eventRepo.GetEventAttendees(ctx, eventID)
+	if err != nil {
+		return results, err
+	}
+	requirements, err := s.resourceRepo.GetEventResources(ctx, eventID)
+	if err != nil {
+		return results, err
+	}
+	var plan *resourcePlan
+	if len(requirements) > 0 {
+		if plan, err = newResourcePlan(ctx, s.resourceRepo, requirements, len(eventParticipants(event, attendees))); err != nil {
+			return results, err
+		}
+	}
+
+	// Add the occurrences of recurring availability around the candidate slots, then take out the time users spend
+	// in confirmed meetings of other events, and the time resources are booked by them
+	if from, to, ok := eventWindow(occurrenceWindows); ok {
+		for userID, rules := range userRules {
+			userAvailability[userID] = append(userAvailability[userID], expandRules(rules, from, to)...)
+		}
+		if err := s.removeConfirmedMeetings(ctx, event.ID, userAvailability, from, to); err != nil {
+			return results, err
+		}
+		if plan != nil {
+			if err := plan.loadBookings(ctx, s.resourceRepo, s.eventRepo, event.ID, from, to); err != nil {
+				return results, err
 			}
 		}
 	}
 
-	// Step 3: Build result
-	totalUsers := make(map[int64]bool)
+	// Step 2: Sweep the windows against every user's availability to find who is free in each
+	freeUsers := matchFreeUsers(occurrenceWindows, userAvailability)
+
+	// Step 3: Weigh attendees, users missing from the roster count as optional with the default weight
+	// users who submitted availability are either available or unavailable in a slot,
+	// invited attendees who never submitted anything are reported as no response
+	respondedUsers := make(map[int64]bool)
+	requiredUsers := make(map[int64]bool)
+	optionalWeights := make(map[int64]float64)
 	for userID := range userAvailability {
-		totalUsers[userID] = true
+		respondedUsers[userID] = true
+		optionalWeights[userID] = defaultAttendeeWeight
+	}
+	noResponse := []int64{}
+	for _, attendee := range attendees {
+		if !respondedUsers[attendee.UserID] {
+			noResponse = append(noResponse, attendee.UserID)
+		}
+		if attendee.Required {
+			requiredUsers[attendee.UserID] = true
+			delete(optionalWeights, attendee.UserID)
+			continue
+		}
+		optionalWeights[attendee.UserID] = attendee.Weight
+		if attendee.Weight == 0 {
+			optionalWeights[attendee.UserID] = defaultAttendeeWeight
+		}
+	}
+	sort.Slice(noResponse, func(i, j int) bool { return noResponse[i] < noResponse[j] })
+	totalOptionalWeight := 0.0
+	for _, weight := range optionalWeights {
+		totalOptionalWeight += weight
+	}
+	// share is the part of the optional weight that is free
+	share := func(free []int64) float64 {
+		if totalOptionalWeight == 0 {
+			return 0
+		}
+		weight := 0.0
+		for _, userID := range free {
+			weight += optionalWeights[userID]
+		}
+		return weight / totalOptionalWeight
+	}
+
+	// Step 3b: Load the working hours of everyone involved, users without any are never outside them
+	involvedUsers := make(map[int64]bool, len(respondedUsers)+len(attendees))
+	for userID := range respondedUsers {
+		involvedUsers[userID] = true
+	}
+	for _, attendee := range attendees {
+		involvedUsers[attendee.UserID] = true
+	}
+	involved := utils.Difference(involvedUsers, nil)
+	profiles, err := s.userRepo.GetUserProfiles(ctx, involved)
+	if err != nil {
+		return results, err
 	}
+	schedules := make(map[int64]workingSchedule)
+	for userID, profile := range profiles {
+		if schedule, ok := newWorkingSchedule(profile); ok {
+			schedules[userID] = schedule
+		}
+	}
+
+	// Step 4: Build result, a slot is available to the users free in every one of its occurrences
+	// and scores the mean share of the optional weight free in each occurrence
+	for i, slot := range windows {
+		if len(series[i]) == 0 {
+			continue
+		}
+		// a slot needs a resource for each requirement that is free in every one of its occurrences
+		var resources []int64
+		if plan != nil {
+			slotOccurrences := make([]model.EventSlot, 0, len(series[i]))
+			for _, o := range series[i] {
+				slotOccurrences = append(slotOccurrences, occurrenceWindows[o])
+			}
+			var served bool
+			if resources, served = plan.assign(slotOccurrences); !served {
+				continue
+			}
+		}
+		var available []int64
+		var occurrences []model.OccurrenceAvailability
+		outside := make(map[int64]bool)
+		anyFree := false
+		score := 0.0
+		for k, o := range series[i] {
+			occurrence := occurrenceWindows[o]
+			outsideHere := []int64{}
+			for _, userID := range involved {
+				if schedule, ok := schedules[userID]; ok && !schedule.covers(occurrence.StartTime, occurrence.EndTime) {
+					outsideHere = append(outsideHere, userID)
+					outside[userID] = true
+				}
+			}
+
+			free := freeUsers[o]
+			if options.WorkingHours == model.WorkingHoursExclude && len(outsideHere) > 0 {
+				free = withoutUsers(free, outsideHere)
+			}
+			anyFree = anyFree || len(free) > 0
+			if k == 0 {
+				available = free
+			} else {
+				available = intersectUsers(available, free)
+			}
+			score += share(free)
+			if event.RRule != "" {
+				occurrences = append(occurrences, model.OccurrenceAvailability{Slot: occurrence, Available: free})
+			}
+		}
+		score /= float64(len(series[i]))
 
-	for key, users := range userSlotMap {
-		slot := eventSlotMap[key]
+		if !anyFree || len(available) < options.MinAvailable || !containsAll(available, options.RequireUsers) {
+			continue
+		}
+		unavailable := utils.Difference(respondedUsers, available)
 
-		available := utils.Unique(users)
-		unavailable := utils.Difference(totalUsers, available)
+		missingRequired := []int64{}
+		for _, userID := range append(append([]int64{}, unavailable...), noResponse...) {
+			if requiredUsers[userID] {
+				missingRequired = append(missingRequired, userID)
+			}
+		}
+		sort.Slice(missingRequired, func(i, j int) bool { return missingRequired[i] < missingRequired[j] })
 
 		results = append(results, model.SlotRecommendation{
-			Slot:        slot,
-			Available:   available,
-			Unavailable: unavailable,
+			Slot:            slot,
+			Available:       available,
+			Unavailable:     unavailable,
+			NoResponse:      noResponse,
+			MissingRequired: missingRequired,
+			OutsideHours:    utils.Difference(outside, nil),
+			Occurrences:     occurrences,
+			Resources:       resources,
+			Feasible:        len(missingRequired) == 0,
+			Score:           score,
 		})
 	}
 
-	// Step 4: Sort by number of available users descending
-	sort.Slice(results, func(i, j int) bool {
-		return len(results[i].Available) > len(results[j].Available)
+	// Step 5: Order the slots
+	sort.SliceStable(results, func(i, j int) bool {
+		return recommendationLess(results[i], results[j], options.TieBreak)
 	})
+	if options.Limit > 0 && len(results) > options.Limit {
+		results = results[:options.Limit]
+	}
 
 	return results, nil
 }
 
-func breakIntoTimeFrames(slot model.EventSlot, duration time.Duration) []model.EventSlot {
-	var timeFrames []model.EventSlot
-	start := slot.StartTime
-	for start.Add(duration).Before(slot.EndTime) || start.Add(duration).Equal(slot.EndTime) {
-		timeFrames = append(timeFrames, model.EventSlot{
+// removeConfirmedMeetings makes every user busy during their confirmed meetings of events other than eventID
+func (s *recommendationService) removeConfirmedMeetings(ctx context.Context, eventID int64, userAvailability map[int64][]model.EventSlot, from time.Time, to time.Time) error {
+	userIDs := make([]int64, 0, len(userAvailability))
+	for userID := range userAvailability {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Slice(userIDs, func(i, j int) bool { return userIDs[i] < userIDs[j] })
+
+	meetings, err := confirmedMeetings(ctx, s.eventRepo, userIDs, from, to)
+	if err != nil {
+		return err
+	}
+	for userID, userMeetings := range meetings {
+		busy := []model.EventSlot{}
+		for _, meeting := range userMeetings {
+			if meeting.EventID != eventID {
+				busy = append(busy, meeting.Slot)
+			}
+		}
+		if len(busy) > 0 {
+			userAvailability[userID] = subtractIntervals(mergeIntervals(userAvailability[userID]), mergeIntervals(busy), 0)
+		}
+	}
+	return nil
+}
+
+// expandSeries returns the occurrences of a recurring event at every candidate window, skipping the dates that
+// have an exception, together with the indexes of the occurrences of each window
+func (s *recommendationService) expandSeries(ctx context.Context, event model.Event, windows []model.EventSlot, loc *time.Location, count int) ([]model.EventSlot, [][]int, error) {
+	rrule, err := utils.ParseRRule(event.RRule)
+	if err != nil {
+		log.Println("Error parsing event recurrence rule:", err)
+		return nil, nil, err
+	}
+	exceptions, err := s.eventRepo.GetEventExceptions(ctx, event.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	skipped := make(map[string]bool, len(exceptions))
+	for _, exception := range exceptions {
+		skipped[exception.OccurrenceDate] = true
+	}
+	if count <= 0 {
+		count = defaultSeriesOccurrences
+	}
+
+	occurrenceWindows := []model.EventSlot{}
+	series := make([][]int, len(windows))
+	for i, window := range windows {
+		for _, occurrence := range seriesOccurrences(window, rrule, loc, count, skipped) {
+			series[i] = append(series[i], len(occurrenceWindows))
+			occurrenceWindows = append(occurrenceWindows, occurrence)
+		}
+	}
+	return occurrenceWindows, series, nil
+}
+
+// filterWindows keeps the windows inside the requested time range, skipping Saturdays and Sundays when asked
+func filterWindows(windows []model.EventSlot, options model.RecommendationOptions) []model.EventSlot {
+	filtered := []model.EventSlot{}
+	for _, window := range windows {
+		if !options.From.IsZero() && window.StartTime.Before(options.From) {
+			continue
+		}
+		if !options.To.IsZero() && window.EndTime.After(options.To) {
+			continue
+		}
+		if options.ExcludeWeekends {
+			// windows are in the zone of the event, so the weekday is the organizer's
+			weekday := window.StartTime.Weekday()
+			if weekday == time.Saturday || weekday == time.Sunday {
+				continue
+			}
+		}
+		filtered = append(filtered, window)
+	}
+	return filtered
+}
+
+// withoutUsers returns the sorted ids that are not in the sorted removed ids, ids is left untouched
+func withoutUsers(ids []int64, removed []int64) []int64 {
+	kept := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if !containsAll(removed, []int64{id}) {
+			kept = append(kept, id)
+		}
+	}
+	return kept
+}
+
+// containsAll reports whether every id in required is in the sorted ids
+func containsAll(ids []int64, required []int64) bool {
+	for _, id := range required {
+		i := sort.Search(len(ids), func(i int) bool { return ids[i] >= id })
+		if i == len(ids) || ids[i] != id {
+			return false
+		}
+	}
+	return true
+}
+
+// recommendationLess reports whether a ranks before b, ties on every criterion fall back to the start and end time
+func recommendationLess(a, b model.SlotRecommendation, tieBreak string) bool {
+	if a.Feasible != b.Feasible {
+		return a.Feasible
+	}
+	if a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	switch tieBreak {
+	case model.TieBreakLatest:
+		if !a.Slot.StartTime.Equal(b.Slot.StartTime) {
+			return a.Slot.StartTime.After(b.Slot.StartTime)
+		}
+	case model.TieBreakMostAvailable:
+		if len(a.Available) != len(b.Available) {
+			return len(a.Available) > len(b.Available)
+		}
+	}
+	if !a.Slot.StartTime.Equal(b.Slot.StartTime) {
+		return a.Slot.StartTime.Before(b.Slot.StartTime)
+	}
+	return a.Slot.EndTime.Before(b.Slot.EndTime)
+}
+
+// generateCandidateSlots returns every window of the given duration inside the slot, starting at the slot start and
+// moving by step on the wall clock of loc. Across a DST change the starts stay on the same local times, starts that
+// fall into a skipped hour move past it and a repeated hour is only used once, the duration is always elapsed time.
+func generateCandidateSlots(slot model.EventSlot, duration time.Duration, step time.Duration, loc *time.Location) []model.EventSlot {
+	var candidates []model.EventSlot
+	if duration <= 0 || step <= 0 {
+		return candidates
+	}
+	origin := slot.StartTime.In(loc)
+	previous := time.Time{}
+	for i := 0; ; i++ {
+		offset := time.Duration(i) * step
+		start := time.Date(origin.Year(), origin.Month(), origin.Day(), origin.Hour(), origin.Minute(), origin.Second(), origin.Nanosecond()+int(offset), loc)
+		if start.Before(slot.StartTime) {
+			// the wall clock went back past the slot start during a fall-back transition
+			continue
+		}
+		if start.Add(duration).After(slot.EndTime) {
+			break
+		}
+		if start.Equal(previous) {
+			continue
+		}
+		previous = start
+		candidates = append(candidates, model.EventSlot{
 			StartTime: start,
 			EndTime:   start.Add(duration),
+			TimeZone:  loc.String(),
 		})
-		start = start.Add(duration)
 	}
-	return timeFrames
+	return candidates
+}
+
+// uniqueWindows drops windows repeated by overlapping proposed slots and keeps them in start order
+func uniqueWindows(windows []model.EventSlot) []model.EventSlot {
+	sort.Slice(windows, func(i, j int) bool {
+		if !windows[i].StartTime.Equal(windows[j].StartTime) {
+			return windows[i].StartTime.Before(windows[j].StartTime)
+		}
+		return windows[i].EndTime.Before(windows[j].EndTime)
+	})
+	unique := []model.EventSlot{}
+	for _, window := range windows {
+		last := len(unique) - 1
+		if last >= 0 && unique[last].StartTime.Equal(window.StartTime) && unique[last].EndTime.Equal(window.EndTime) {
+			continue
+		}
+		unique = append(unique, window)
+	}
+	return unique
 }
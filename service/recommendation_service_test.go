@@ -8,7 +8,9 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	mock_repository "github.com/rahulshewale153/meeting-scheduler-api/mock/repository"
 	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/rahulshewale153/meeting-scheduler-api/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestGetRecommendedSlots(t *testing.T) {
@@ -16,24 +18,41 @@ func TestGetRecommendedSlots(t *testing.T) {
 	assert.Nil(t, err)
 	defer db.Close()
 
+	ctx := context.Background()
 	mockUserAvailRepo := new(mock_repository.MockUserAvailabilityRepository)
+	// nobody has recurring availability unless a test builds its own availability repository
+	mockUserAvailRepo.On("GetAllEventRules", ctx, mock.Anything).Return(map[int64][]model.AvailabilityRule{}, nil).Maybe()
 	mockEventRepo := new(mock_repository.MockEventRepository)
-	recommendationService := NewRecommendationService(mockEventRepo, mockUserAvailRepo)
-	ctx := context.Background()
+	// nobody has confirmed meetings unless a test builds its own event repository
+	mockEventRepo.On("ListConfirmedUserEvents", ctx, mock.Anything, mock.Anything, mock.Anything).Return(map[int64][]model.Event{}, nil).Maybe()
+	// nobody has working hours unless a test builds its own user repository
+	mockUserRepo := new(mock_repository.MockUserRepository)
+	mockUserRepo.On("GetUserProfiles", ctx, mock.Anything).Return(map[int64]model.UserProfile{}, nil).Maybe()
+	// events require no resources unless a test builds its own resource repository
+	mockResourceRepo := new(mock_repository.MockResourceRepository)
+	mockResourceRepo.On("GetEventResources", ctx, mock.Anything).Return([]model.ResourceRequirement{}, nil).Maybe()
+	recommendationService := NewRecommendationService(mockEventRepo, mockUserAvailRepo, mockUserRepo, mockResourceRepo, 15*time.Minute)
 	eventID := int64(1)
 
 	t.Run("Function must return an error when the get event operation fails", func(t *testing.T) {
 		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{}, assert.AnError).Once()
-		_, err := recommendationService.GetRecommendedSlots(ctx, eventID)
+		_, err := recommendationService.GetRecommendedSlots(ctx, eventID, model.RecommendationOptions{})
 		assert.Error(t, err)
 		mockEventRepo.AssertExpectations(t)
 	})
 
+	t.Run("Function must return a forbidden error when the caller does not organize the event", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", otherOrganizer, eventID).Return(model.Event{ID: eventID, OrganizerID: 5}, nil).Once()
+		_, err := recommendationService.GetRecommendedSlots(otherOrganizer, eventID, model.RecommendationOptions{})
+		assert.ErrorIs(t, err, model.ErrForbidden)
+		mockEventRepo.AssertExpectations(t)
+	})
+
 	t.Run("Function must return an error when the get event slot operation fails", func(t *testing.T) {
 		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{}, nil).Once()
 		mockEventRepo.On("GetEventSlots", ctx, eventID).Return([]model.EventSlot{}, assert.AnError).Once()
 
-		_, err := recommendationService.GetRecommendedSlots(ctx, eventID)
+		_, err := recommendationService.GetRecommendedSlots(ctx, eventID, model.RecommendationOptions{})
 		assert.Error(t, err)
 		mockEventRepo.AssertExpectations(t)
 	})
@@ -44,7 +63,7 @@ func TestGetRecommendedSlots(t *testing.T) {
 		mockEventRepo.On("GetEventSlots", ctx, eventID).Return([]model.EventSlot{{StartTime: time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 13, 11, 0, 0, 0, time.UTC)}}, nil).Once()
 		mockUserAvailRepo.On("GetAllEventUsers", ctx, eventID).Return(eventUserMap, assert.AnError).Once()
 
-		_, err := recommendationService.GetRecommendedSlots(ctx, eventID)
+		_, err := recommendationService.GetRecommendedSlots(ctx, eventID, model.RecommendationOptions{})
 		assert.Error(t, err)
 		mockUserAvailRepo.AssertExpectations(t)
 	})
@@ -58,12 +77,573 @@ func TestGetRecommendedSlots(t *testing.T) {
 		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{OrganizerID: 1, DurationMinutes: 30}, nil).Once()
 		mockEventRepo.On("GetEventSlots", ctx, eventID).Return([]model.EventSlot{{StartTime: time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 13, 11, 0, 0, 0, time.UTC)}}, nil).Once()
 		mockUserAvailRepo.On("GetAllEventUsers", ctx, eventID).Return(eventUserMap, nil).Once()
+		mockEventRepo.On("GetEventAttendees", ctx, eventID).Return([]model.Attendee{}, nil).Once()
 
-		recommendedSlots, err := recommendationService.GetRecommendedSlots(ctx, eventID)
+		recommendedSlots, err := recommendationService.GetRecommendedSlots(ctx, eventID, model.RecommendationOptions{})
 		assert.NoError(t, err)
 		assert.NotEmpty(t, recommendedSlots)
 		mockEventRepo.AssertExpectations(t)
 		mockUserAvailRepo.AssertExpectations(t)
 	})
 
+	t.Run("Function must return an error when the get event attendees operation fails", func(t *testing.T) {
+		eventUserMap := map[int64][]model.EventSlot{
+			1: {{StartTime: time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 13, 11, 0, 0, 0, time.UTC)}},
+		}
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{OrganizerID: 1, DurationMinutes: 60}, nil).Once()
+		mockEventRepo.On("GetEventSlots", ctx, eventID).Return([]model.EventSlot{{StartTime: time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 13, 11, 0, 0, 0, time.UTC)}}, nil).Once()
+		mockUserAvailRepo.On("GetAllEventUsers", ctx, eventID).Return(eventUserMap, nil).Once()
+		mockEventRepo.On("GetEventAttendees", ctx, eventID).Return([]model.Attendee{}, assert.AnError).Once()
+
+		_, err := recommendationService.GetRecommendedSlots(ctx, eventID, model.RecommendationOptions{})
+		assert.Error(t, err)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must rank slots free for every required attendee first, then by weighted score", func(t *testing.T) {
+		nine := time.Date(2025, 07, 13, 9, 0, 0, 0, time.UTC)
+		ten := time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC)
+		eleven := time.Date(2025, 07, 13, 11, 0, 0, 0, time.UTC)
+		// user 1 is required and only free at 10, users 2 and 3 are optional and free at 9
+		eventUserMap := map[int64][]model.EventSlot{
+			1: {{StartTime: ten, EndTime: eleven}},
+			2: {{StartTime: nine, EndTime: eleven}},
+			3: {{StartTime: nine, EndTime: ten}},
+		}
+		attendees := []model.Attendee{
+			{UserID: 1, Required: true},
+			{UserID: 2, Weight: 3},
+			{UserID: 3, Weight: 1},
+		}
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{OrganizerID: 1, DurationMinutes: 60}, nil).Once()
+		mockEventRepo.On("GetEventSlots", ctx, eventID).Return([]model.EventSlot{{StartTime: nine, EndTime: eleven}}, nil).Once()
+		mockUserAvailRepo.On("GetAllEventUsers", ctx, eventID).Return(eventUserMap, nil).Once()
+		mockEventRepo.On("GetEventAttendees", ctx, eventID).Return(attendees, nil).Once()
+
+		// an hourly step keeps the candidates to the 9:00 and 10:00 windows
+		hourlyService := NewRecommendationService(mockEventRepo, mockUserAvailRepo, mockUserRepo, mockResourceRepo, time.Hour)
+		recommendedSlots, err := hourlyService.GetRecommendedSlots(ctx, eventID, model.RecommendationOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, recommendedSlots, 2)
+
+		assert.Equal(t, ten, recommendedSlots[0].Slot.StartTime)
+		assert.True(t, recommendedSlots[0].Feasible)
+		assert.Equal(t, 0.75, recommendedSlots[0].Score)
+		assert.Empty(t, recommendedSlots[0].MissingRequired)
+
+		assert.Equal(t, nine, recommendedSlots[1].Slot.StartTime)
+		assert.False(t, recommendedSlots[1].Feasible)
+		assert.Equal(t, 1.0, recommendedSlots[1].Score)
+		assert.Equal(t, []int64{1}, recommendedSlots[1].MissingRequired)
+		mockEventRepo.AssertExpectations(t)
+		mockUserAvailRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must report invited attendees without availability as no response", func(t *testing.T) {
+		ten := time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC)
+		eleven := time.Date(2025, 07, 13, 11, 0, 0, 0, time.UTC)
+		eventUserMap := map[int64][]model.EventSlot{
+			1: {{StartTime: ten, EndTime: eleven}},
+		}
+		attendees := []model.Attendee{
+			{UserID: 1, Weight: 1},
+			{UserID: 2, Required: true, Weight: 1},
+			{UserID: 3, Weight: 1},
+		}
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{OrganizerID: 1, DurationMinutes: 60}, nil).Once()
+		mockEventRepo.On("GetEventSlots", ctx, eventID).Return([]model.EventSlot{{StartTime: ten, EndTime: eleven}}, nil).Once()
+		mockUserAvailRepo.On("GetAllEventUsers", ctx, eventID).Return(eventUserMap, nil).Once()
+		mockEventRepo.On("GetEventAttendees", ctx, eventID).Return(attendees, nil).Once()
+
+		recommendedSlots, err := recommendationService.GetRecommendedSlots(ctx, eventID, model.RecommendationOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, recommendedSlots, 1)
+		assert.Equal(t, []int64{1}, recommendedSlots[0].Available)
+		assert.Empty(t, recommendedSlots[0].Unavailable)
+		assert.Equal(t, []int64{2, 3}, recommendedSlots[0].NoResponse)
+		assert.Equal(t, []int64{2}, recommendedSlots[0].MissingRequired)
+		assert.False(t, recommendedSlots[0].Feasible)
+		assert.Equal(t, 0.5, recommendedSlots[0].Score)
+	})
+
+	t.Run("Function must slide the meeting over a proposed slot using the configured step", func(t *testing.T) {
+		nine := time.Date(2025, 07, 13, 9, 0, 0, 0, time.UTC)
+		nineThirty := time.Date(2025, 07, 13, 9, 30, 0, 0, time.UTC)
+		tenThirty := time.Date(2025, 07, 13, 10, 30, 0, 0, time.UTC)
+		eleven := time.Date(2025, 07, 13, 11, 0, 0, 0, time.UTC)
+		eventUserMap := map[int64][]model.EventSlot{
+			1: {{StartTime: nineThirty, EndTime: tenThirty}},
+		}
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{OrganizerID: 1, DurationMinutes: 60}, nil).Once()
+		mockEventRepo.On("GetEventSlots", ctx, eventID).Return([]model.EventSlot{{StartTime: nine, EndTime: eleven}}, nil).Once()
+		mockUserAvailRepo.On("GetAllEventUsers", ctx, eventID).Return(eventUserMap, nil).Once()
+		mockEventRepo.On("GetEventAttendees", ctx, eventID).Return([]model.Attendee{}, nil).Once()
+
+		halfHourService := NewRecommendationService(mockEventRepo, mockUserAvailRepo, mockUserRepo, mockResourceRepo, 30*time.Minute)
+		recommendedSlots, err := halfHourService.GetRecommendedSlots(ctx, eventID, model.RecommendationOptions{})
+		assert.NoError(t, err)
+		// of the 9:00, 9:30 and 10:00 windows only 9:30 falls inside the user's availability
+		assert.Len(t, recommendedSlots, 1)
+		assert.Equal(t, nineThirty, recommendedSlots[0].Slot.StartTime)
+		assert.Equal(t, tenThirty, recommendedSlots[0].Slot.EndTime)
+		assert.Equal(t, []int64{1}, recommendedSlots[0].Available)
+	})
+
+	t.Run("Function must treat back to back availability as covering a slot that spans both", func(t *testing.T) {
+		ten := time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC)
+		tenThirty := time.Date(2025, 07, 13, 10, 30, 0, 0, time.UTC)
+		eleven := time.Date(2025, 07, 13, 11, 0, 0, 0, time.UTC)
+		elevenThirty := time.Date(2025, 07, 13, 11, 30, 0, 0, time.UTC)
+		twelve := time.Date(2025, 07, 13, 12, 0, 0, 0, time.UTC)
+		eventUserMap := map[int64][]model.EventSlot{
+			1: {{StartTime: eleven, EndTime: twelve}, {StartTime: ten, EndTime: eleven}},
+		}
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{OrganizerID: 1, DurationMinutes: 60}, nil).Once()
+		mockEventRepo.On("GetEventSlots", ctx, eventID).Return([]model.EventSlot{{StartTime: tenThirty, EndTime: elevenThirty}}, nil).Once()
+		mockUserAvailRepo.On("GetAllEventUsers", ctx, eventID).Return(eventUserMap, nil).Once()
+		mockEventRepo.On("GetEventAttendees", ctx, eventID).Return([]model.Attendee{}, nil).Once()
+
+		recommendedSlots, err := recommendationService.GetRecommendedSlots(ctx, eventID, model.RecommendationOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, recommendedSlots, 1)
+		assert.Equal(t, tenThirty, recommendedSlots[0].Slot.StartTime)
+		assert.Equal(t, []int64{1}, recommendedSlots[0].Available)
+		assert.Empty(t, recommendedSlots[0].Unavailable)
+	})
+
+	t.Run("Function must reject an unknown tie-break", func(t *testing.T) {
+		_, err := recommendationService.GetRecommendedSlots(ctx, eventID, model.RecommendationOptions{TieBreak: "random"})
+		var validationErr *utils.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+
+	t.Run("Function must order equal scores by start time and return sorted user ids", func(t *testing.T) {
+		nine := time.Date(2025, 07, 13, 9, 0, 0, 0, time.UTC)
+		ten := time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC)
+		eleven := time.Date(2025, 07, 13, 11, 0, 0, 0, time.UTC)
+		twelve := time.Date(2025, 07, 13, 12, 0, 0, 0, time.UTC)
+		// users 1 and 4 are free all morning, every other user is busy so each hour scores the same
+		eventUserMap := map[int64][]model.EventSlot{
+			4: {{StartTime: nine, EndTime: twelve}},
+			1: {{StartTime: nine, EndTime: twelve}},
+			9: {{StartTime: time.Date(2025, 07, 14, 9, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 14, 10, 0, 0, 0, time.UTC)}},
+			7: {{StartTime: time.Date(2025, 07, 14, 9, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 14, 10, 0, 0, 0, time.UTC)}},
+			5: {{StartTime: time.Date(2025, 07, 14, 9, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 14, 10, 0, 0, 0, time.UTC)}},
+		}
+		hourlyService := NewRecommendationService(mockEventRepo, mockUserAvailRepo, mockUserRepo, mockResourceRepo, time.Hour)
+
+		for i := 0; i < 5; i++ {
+			mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{OrganizerID: 1, DurationMinutes: 60}, nil).Once()
+			mockEventRepo.On("GetEventSlots", ctx, eventID).Return([]model.EventSlot{{StartTime: nine, EndTime: twelve}}, nil).Once()
+			mockUserAvailRepo.On("GetAllEventUsers", ctx, eventID).Return(eventUserMap, nil).Once()
+			mockEventRepo.On("GetEventAttendees", ctx, eventID).Return([]model.Attendee{}, nil).Once()
+
+			recommendedSlots, err := hourlyService.GetRecommendedSlots(ctx, eventID, model.RecommendationOptions{})
+			assert.NoError(t, err)
+			assert.Len(t, recommendedSlots, 3)
+			assert.Equal(t, nine, recommendedSlots[0].Slot.StartTime)
+			assert.Equal(t, ten, recommendedSlots[1].Slot.StartTime)
+			assert.Equal(t, eleven, recommendedSlots[2].Slot.StartTime)
+			for _, slot := range recommendedSlots {
+				assert.Equal(t, []int64{1, 4}, slot.Available)
+				assert.Equal(t, []int64{5, 7, 9}, slot.Unavailable)
+			}
+		}
+	})
+
+	t.Run("Function must apply the requested tie-break before the start time", func(t *testing.T) {
+		nine := time.Date(2025, 07, 13, 9, 0, 0, 0, time.UTC)
+		ten := time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC)
+		eleven := time.Date(2025, 07, 13, 11, 0, 0, 0, time.UTC)
+		// both users are free all morning so the slots only differ by start time
+		eventUserMap := map[int64][]model.EventSlot{
+			1: {{StartTime: nine, EndTime: eleven}},
+			2: {{StartTime: nine, EndTime: eleven}},
+		}
+		attendees := []model.Attendee{{UserID: 1, Required: true}}
+		hourlyService := NewRecommendationService(mockEventRepo, mockUserAvailRepo, mockUserRepo, mockResourceRepo, time.Hour)
+
+		tests := []struct {
+			tieBreak string
+			first    time.Time
+		}{
+			{model.TieBreakEarliest, nine},
+			{model.TieBreakLatest, ten},
+			{model.TieBreakMostAvailable, nine},
+		}
+		for _, tt := range tests {
+			mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{OrganizerID: 1, DurationMinutes: 60}, nil).Once()
+			mockEventRepo.On("GetEventSlots", ctx, eventID).Return([]model.EventSlot{{StartTime: nine, EndTime: eleven}}, nil).Once()
+			mockUserAvailRepo.On("GetAllEventUsers", ctx, eventID).Return(eventUserMap, nil).Once()
+			mockEventRepo.On("GetEventAttendees", ctx, eventID).Return(attendees, nil).Once()
+
+			recommendedSlots, err := hourlyService.GetRecommendedSlots(ctx, eventID, model.RecommendationOptions{TieBreak: tt.tieBreak})
+			assert.NoError(t, err)
+			assert.Len(t, recommendedSlots, 2)
+			assert.Equal(t, tt.first, recommendedSlots[0].Slot.StartTime, tt.tieBreak)
+		}
+	})
+
+	t.Run("Function must reject negative limits and an empty time range", func(t *testing.T) {
+		from := time.Date(2025, 07, 14, 0, 0, 0, 0, time.UTC)
+		options := model.RecommendationOptions{Limit: -1, MinAvailable: -1, From: from, To: from}
+		_, err := recommendationService.GetRecommendedSlots(ctx, eventID, options)
+		var validationErr *utils.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Len(t, validationErr.Errors.Field, 3)
+	})
+
+	t.Run("Function must apply the filters before returning the top slots", func(t *testing.T) {
+		// Friday 2025-07-18 and Saturday 2025-07-19
+		friday := time.Date(2025, 07, 18, 9, 0, 0, 0, time.UTC)
+		saturday := time.Date(2025, 07, 19, 9, 0, 0, 0, time.UTC)
+		eventUserMap := map[int64][]model.EventSlot{
+			1: {{StartTime: friday, EndTime: friday.Add(4 * time.Hour)}, {StartTime: saturday, EndTime: saturday.Add(4 * time.Hour)}},
+			2: {{StartTime: friday, EndTime: friday.Add(2 * time.Hour)}, {StartTime: saturday, EndTime: saturday.Add(4 * time.Hour)}},
+			3: {{StartTime: friday.Add(time.Hour), EndTime: friday.Add(4 * time.Hour)}},
+		}
+		proposed := []model.EventSlot{
+			{StartTime: friday, EndTime: friday.Add(4 * time.Hour)},
+			{StartTime: saturday, EndTime: saturday.Add(4 * time.Hour)},
+		}
+		hourlyService := NewRecommendationService(mockEventRepo, mockUserAvailRepo, mockUserRepo, mockResourceRepo, time.Hour)
+
+		tests := []struct {
+			name    string
+			options model.RecommendationOptions
+			starts  []time.Time
+		}{
+			{
+				name:    "exclude_weekends",
+				options: model.RecommendationOptions{ExcludeWeekends: true},
+				starts:  []time.Time{friday.Add(time.Hour), friday, friday.Add(2 * time.Hour), friday.Add(3 * time.Hour)},
+			},
+			{
+				name:    "min_available",
+				options: model.RecommendationOptions{MinAvailable: 3},
+				starts:  []time.Time{friday.Add(time.Hour)},
+			},
+			{
+				name:    "require_users",
+				options: model.RecommendationOptions{RequireUsers: []int64{2, 3}},
+				starts:  []time.Time{friday.Add(time.Hour)},
+			},
+			{
+				name:    "from and to",
+				options: model.RecommendationOptions{From: friday.Add(2 * time.Hour), To: saturday.Add(time.Hour)},
+				starts:  []time.Time{friday.Add(2 * time.Hour), friday.Add(3 * time.Hour), saturday},
+			},
+			{
+				name:    "limit",
+				options: model.RecommendationOptions{Limit: 2},
+				starts:  []time.Time{friday.Add(time.Hour), friday},
+			},
+		}
+		for _, tt := range tests {
+			mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{OrganizerID: 1, DurationMinutes: 60}, nil).Once()
+			mockEventRepo.On("GetEventSlots", ctx, eventID).Return(proposed, nil).Once()
+			mockUserAvailRepo.On("GetAllEventUsers", ctx, eventID).Return(eventUserMap, nil).Once()
+			mockEventRepo.On("GetEventAttendees", ctx, eventID).Return([]model.Attendee{}, nil).Once()
+
+			recommendedSlots, err := hourlyService.GetRecommendedSlots(ctx, eventID, tt.options)
+			assert.NoError(t, err, tt.name)
+			starts := []time.Time{}
+			for _, slot := range recommendedSlots {
+				starts = append(starts, slot.Slot.StartTime)
+			}
+			assert.Equal(t, tt.starts, starts, tt.name)
+		}
+	})
+
+	t.Run("Function must report attendees outside their working hours and drop them when asked to exclude", func(t *testing.T) {
+		// Monday 2025-07-14, 12:00 UTC is 17:30 in Kolkata and 08:00 in New York
+		noon := time.Date(2025, 07, 14, 12, 0, 0, 0, time.UTC)
+		one := noon.Add(time.Hour)
+		eventUserMap := map[int64][]model.EventSlot{
+			1: {{StartTime: noon, EndTime: noon.Add(2 * time.Hour)}},
+			2: {{StartTime: noon, EndTime: noon.Add(2 * time.Hour)}},
+			3: {{StartTime: noon, EndTime: noon.Add(2 * time.Hour)}},
+		}
+		profiles := map[int64]model.UserProfile{
+			1: {UserID: 1, TimeZone: "Asia/Kolkata", WorkingHours: []model.WorkingHours{{Weekday: time.Monday, Start: "09:00", End: "19:30"}}},
+			2: {UserID: 2, TimeZone: "America/New_York", WorkingHours: []model.WorkingHours{{Weekday: time.Monday, Start: "09:00", End: "17:00"}}},
+		}
+		workingHoursUserRepo := new(mock_repository.MockUserRepository)
+		workingHoursUserRepo.On("GetUserProfiles", ctx, []int64{1, 2, 3}).Return(profiles, nil)
+		hourlyService := NewRecommendationService(mockEventRepo, mockUserAvailRepo, workingHoursUserRepo, mockResourceRepo, time.Hour)
+
+		tests := []struct {
+			mode        string
+			starts      []time.Time
+			available   [][]int64
+			unavailable [][]int64
+		}{
+			{model.WorkingHoursMark, []time.Time{noon, one}, [][]int64{{1, 2, 3}, {1, 2, 3}}, [][]int64{{}, {}}},
+			{model.WorkingHoursExclude, []time.Time{one, noon}, [][]int64{{1, 2, 3}, {1, 3}}, [][]int64{{}, {2}}},
+		}
+		for _, tt := range tests {
+			mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{OrganizerID: 1, DurationMinutes: 60}, nil).Once()
+			mockEventRepo.On("GetEventSlots", ctx, eventID).Return([]model.EventSlot{{StartTime: noon, EndTime: noon.Add(2 * time.Hour)}}, nil).Once()
+			mockUserAvailRepo.On("GetAllEventUsers", ctx, eventID).Return(eventUserMap, nil).Once()
+			mockEventRepo.On("GetEventAttendees", ctx, eventID).Return([]model.Attendee{}, nil).Once()
+
+			recommendedSlots, err := hourlyService.GetRecommendedSlots(ctx, eventID, model.RecommendationOptions{WorkingHours: tt.mode})
+			assert.NoError(t, err, tt.mode)
+			assert.Len(t, recommendedSlots, 2, tt.mode)
+			for i, slot := range recommendedSlots {
+				assert.Equal(t, tt.starts[i], slot.Slot.StartTime, tt.mode)
+				assert.Equal(t, tt.available[i], slot.Available, tt.mode)
+				assert.Equal(t, tt.unavailable[i], slot.Unavailable, tt.mode)
+				if slot.Slot.StartTime.Equal(noon) {
+					assert.Equal(t, []int64{2}, slot.OutsideHours, tt.mode)
+				} else {
+					assert.Equal(t, []int64{}, slot.OutsideHours, tt.mode)
+				}
+			}
+		}
+		workingHoursUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must count the occurrences of recurring availability", func(t *testing.T) {
+		// user 1 submitted slots, user 2 only a daily rule from 10:00 to 11:00 UTC that started a week earlier
+		ten := time.Date(2025, 07, 14, 10, 0, 0, 0, time.UTC)
+		eventUserMap := map[int64][]model.EventSlot{
+			1: {{StartTime: ten.Add(-time.Hour), EndTime: ten.Add(2 * time.Hour)}},
+		}
+		rules := map[int64][]model.AvailabilityRule{
+			2: {{StartTime: ten.AddDate(0, 0, -7), EndTime: ten.AddDate(0, 0, -7).Add(time.Hour), TimeZone: "UTC", RRule: "FREQ=DAILY"}},
+		}
+		rulesAvailRepo := new(mock_repository.MockUserAvailabilityRepository)
+		rulesAvailRepo.On("GetAllEventUsers", ctx, eventID).Return(eventUserMap, nil).Once()
+		rulesAvailRepo.On("GetAllEventRules", ctx, eventID).Return(rules, nil).Once()
+		hourlyService := NewRecommendationService(mockEventRepo, rulesAvailRepo, mockUserRepo, mockResourceRepo, time.Hour)
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{OrganizerID: 1, DurationMinutes: 60}, nil).Once()
+		mockEventRepo.On("GetEventSlots", ctx, eventID).Return([]model.EventSlot{{StartTime: ten.Add(-time.Hour), EndTime: ten.Add(2 * time.Hour)}}, nil).Once()
+		mockEventRepo.On("GetEventAttendees", ctx, eventID).Return([]model.Attendee{}, nil).Once()
+
+		recommendedSlots, err := hourlyService.GetRecommendedSlots(ctx, eventID, model.RecommendationOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, recommendedSlots, 3)
+		assert.Equal(t, ten, recommendedSlots[0].Slot.StartTime)
+		assert.Equal(t, []int64{1, 2}, recommendedSlots[0].Available)
+		assert.Equal(t, []int64{2}, recommendedSlots[1].Unavailable)
+		rulesAvailRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must judge a recurring event on its next occurrences, skipping exceptions", func(t *testing.T) {
+		// weekly on Mondays, the occurrence of 2025-07-21 is cancelled so two occurrences are the 14th and the 28th
+		ten := time.Date(2025, 07, 14, 10, 0, 0, 0, time.UTC)
+		eleven := ten.Add(time.Hour)
+		lastTen := ten.AddDate(0, 0, 14)
+		eventUserMap := map[int64][]model.EventSlot{
+			1: {{StartTime: ten, EndTime: eleven}, {StartTime: lastTen, EndTime: lastTen.Add(time.Hour)}},
+			2: {{StartTime: ten, EndTime: ten.Add(2 * time.Hour)}, {StartTime: ten.AddDate(0, 0, 7), EndTime: ten.AddDate(0, 0, 7).Add(2 * time.Hour)}, {StartTime: lastTen.Add(time.Hour), EndTime: lastTen.Add(2 * time.Hour)}},
+		}
+		event := model.Event{ID: eventID, OrganizerID: 1, DurationMinutes: 60, RRule: "FREQ=WEEKLY"}
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(event, nil).Once()
+		mockEventRepo.On("GetEventSlots", ctx, eventID).Return([]model.EventSlot{{StartTime: ten, EndTime: ten.Add(2 * time.Hour)}}, nil).Once()
+		mockUserAvailRepo.On("GetAllEventUsers", ctx, eventID).Return(eventUserMap, nil).Once()
+		mockEventRepo.On("GetEventExceptions", ctx, eventID).Return([]model.EventException{{OccurrenceDate: "2025-07-21", Cancelled: true}}, nil).Once()
+		mockEventRepo.On("GetEventAttendees", ctx, eventID).Return([]model.Attendee{}, nil).Once()
+
+		hourlyService := NewRecommendationService(mockEventRepo, mockUserAvailRepo, mockUserRepo, mockResourceRepo, time.Hour)
+		recommendedSlots, err := hourlyService.GetRecommendedSlots(ctx, eventID, model.RecommendationOptions{Occurrences: 2})
+		assert.NoError(t, err)
+		assert.Len(t, recommendedSlots, 2)
+
+		assert.Equal(t, ten, recommendedSlots[0].Slot.StartTime)
+		assert.Equal(t, []int64{1}, recommendedSlots[0].Available)
+		assert.Equal(t, []int64{2}, recommendedSlots[0].Unavailable)
+		assert.Equal(t, 0.75, recommendedSlots[0].Score)
+		assert.Len(t, recommendedSlots[0].Occurrences, 2)
+		assert.Equal(t, lastTen, recommendedSlots[0].Occurrences[1].Slot.StartTime)
+		assert.Equal(t, []int64{1}, recommendedSlots[0].Occurrences[1].Available)
+
+		assert.Equal(t, eleven, recommendedSlots[1].Slot.StartTime)
+		assert.Equal(t, []int64{2}, recommendedSlots[1].Available)
+		assert.Equal(t, 0.5, recommendedSlots[1].Score)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must treat confirmed meetings of other events as busy", func(t *testing.T) {
+		ten := time.Date(2025, 07, 14, 10, 0, 0, 0, time.UTC)
+		eventUserMap := map[int64][]model.EventSlot{
+			1: {{StartTime: ten, EndTime: ten.Add(2 * time.Hour)}},
+			2: {{StartTime: ten, EndTime: ten.Add(2 * time.Hour)}},
+		}
+		// user 2 already meets from 10:30 to 11:00 for event 9, which leaves them only 11:00-12:00
+		meetingEventRepo := new(mock_repository.MockEventRepository)
+		meetingEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{ID: eventID, OrganizerID: 1, DurationMinutes: 60}, nil).Once()
+		meetingEventRepo.On("GetEventSlots", ctx, eventID).Return([]model.EventSlot{{StartTime: ten, EndTime: ten.Add(2 * time.Hour)}}, nil).Once()
+		mockUserAvailRepo.On("GetAllEventUsers", ctx, eventID).Return(eventUserMap, nil).Once()
+		meetingEventRepo.On("ListConfirmedUserEvents", ctx, []int64{1, 2}, ten, ten.Add(2*time.Hour)).Return(map[int64][]model.Event{
+			2: {{ID: 9, Status: model.EventStatusConfirmed, ConfirmedSlot: &model.EventSlot{StartTime: ten.Add(30 * time.Minute), EndTime: ten.Add(time.Hour)}}},
+		}, nil).Once()
+		meetingEventRepo.On("GetEventAttendees", ctx, eventID).Return([]model.Attendee{}, nil).Once()
+
+		hourlyService := NewRecommendationService(meetingEventRepo, mockUserAvailRepo, mockUserRepo, mockResourceRepo, time.Hour)
+		recommendedSlots, err := hourlyService.GetRecommendedSlots(ctx, eventID, model.RecommendationOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, recommendedSlots, 2)
+		assert.Equal(t, ten.Add(time.Hour), recommendedSlots[0].Slot.StartTime)
+		assert.Equal(t, []int64{1, 2}, recommendedSlots[0].Available)
+		assert.Equal(t, ten, recommendedSlots[1].Slot.StartTime)
+		assert.Equal(t, []int64{1}, recommendedSlots[1].Available)
+		assert.Equal(t, []int64{2}, recommendedSlots[1].Unavailable)
+		meetingEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must only recommend slots where a resource is free for each requirement", func(t *testing.T) {
+		ten := time.Date(2025, 07, 14, 10, 0, 0, 0, time.UTC)
+		eventUserMap := map[int64][]model.EventSlot{
+			1: {{StartTime: ten, EndTime: ten.Add(2 * time.Hour)}},
+			2: {{StartTime: ten, EndTime: ten.Add(2 * time.Hour)}},
+		}
+		// the only projector is booked by event 9 from 10:30 to 11:00, which leaves only 11:00-12:00
+		projector := model.Resource{ID: 21, Name: "Projector", Kind: model.ResourceKindEquipment}
+		requirement := model.ResourceRequirement{ID: 3, Kind: model.ResourceKindEquipment, Features: []string{"hdmi"}}
+		resourceEventRepo := new(mock_repository.MockEventRepository)
+		resourceEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{ID: eventID, OrganizerID: 1, DurationMinutes: 60}, nil).Once()
+		resourceEventRepo.On("GetEventSlots", ctx, eventID).Return([]model.EventSlot{{StartTime: ten, EndTime: ten.Add(2 * time.Hour)}}, nil).Once()
+		resourceEventRepo.On("ListConfirmedUserEvents", ctx, []int64{1, 2}, ten, ten.Add(2*time.Hour)).Return(map[int64][]model.Event{}, nil).Once()
+		resourceEventRepo.On("GetEventAttendees", ctx, eventID).Return([]model.Attendee{}, nil).Once()
+		mockUserAvailRepo.On("GetAllEventUsers", ctx, eventID).Return(eventUserMap, nil).Once()
+		resourceRepo := new(mock_repository.MockResourceRepository)
+		resourceRepo.On("GetEventResources", ctx, eventID).Return([]model.ResourceRequirement{requirement}, nil).Once()
+		resourceRepo.On("ListResources", ctx, model.ResourceFilter{Kind: model.ResourceKindEquipment, Features: []string{"hdmi"}}).Return([]model.Resource{projector}, nil).Once()
+		resourceRepo.On("GetResourceAvailability", ctx, []int64{21}).Return(map[int64][]model.EventSlot{}, nil).Once()
+		resourceRepo.On("ListBookedEvents", ctx, []int64{21}, ten, ten.Add(2*time.Hour)).Return(map[int64][]model.Event{
+			21: {{ID: 9, Status: model.EventStatusConfirmed, ConfirmedSlot: &model.EventSlot{StartTime: ten.Add(30 * time.Minute), EndTime: ten.Add(time.Hour)}}},
+		}, nil).Once()
+
+		hourlyService := NewRecommendationService(resourceEventRepo, mockUserAvailRepo, mockUserRepo, resourceRepo, time.Hour)
+		recommendedSlots, err := hourlyService.GetRecommendedSlots(ctx, eventID, model.RecommendationOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, recommendedSlots, 1)
+		assert.Equal(t, ten.Add(time.Hour), recommendedSlots[0].Slot.StartTime)
+		assert.Equal(t, []int64{21}, recommendedSlots[0].Resources)
+		resourceRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must reject more occurrences than the maximum", func(t *testing.T) {
+		_, err := recommendationService.GetRecommendedSlots(ctx, eventID, model.RecommendationOptions{Occurrences: maxSeriesOccurrences + 1})
+		var validationErr *utils.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "occurrences", validationErr.Errors.Field[0].Name)
+	})
+
+	t.Run("Function must reject negative occurrences", func(t *testing.T) {
+		_, err := recommendationService.GetRecommendedSlots(ctx, eventID, model.RecommendationOptions{Occurrences: -1})
+		var validationErr *utils.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "occurrences must be between 1 and 52, or 0 for the default of 4", validationErr.Errors.Field[0].ErrorMessage)
+	})
+
+	t.Run("Function must reject an unknown working hours mode", func(t *testing.T) {
+		_, err := recommendationService.GetRecommendedSlots(ctx, eventID, model.RecommendationOptions{WorkingHours: "ignore"})
+		var validationErr *utils.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "working_hours", validationErr.Errors.Field[0].Name)
+	})
+}
+
+func TestWorkingScheduleCovers(t *testing.T) {
+	schedule, ok := newWorkingSchedule(model.UserProfile{TimeZone: "America/New_York", WorkingHours: []model.WorkingHours{
+		{Weekday: time.Monday, Start: "09:00", End: "12:00"},
+		{Weekday: time.Monday, Start: "13:00", End: "24:00"},
+	}})
+	assert.True(t, ok)
+	_, ok = newWorkingSchedule(model.UserProfile{TimeZone: "America/New_York"})
+	assert.False(t, ok)
+
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	at := func(day, hour, minute int) time.Time { return time.Date(2025, 07, day, hour, minute, 0, 0, newYork) }
+	tests := []struct {
+		name       string
+		start, end time.Time
+		covers     bool
+	}{
+		{"inside the morning", at(14, 9, 0), at(14, 10, 0), true},
+		{"spanning the lunch break", at(14, 11, 30), at(14, 13, 30), false},
+		{"running to midnight", at(14, 23, 0), at(15, 0, 0), true},
+		{"crossing midnight", at(14, 23, 30), at(15, 0, 30), false},
+		{"on a day off", at(15, 9, 0), at(15, 10, 0), false},
+		{"given in another zone", at(14, 9, 0).UTC(), at(14, 10, 0).UTC(), true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.covers, schedule.covers(tt.start, tt.end), tt.name)
+	}
+}
+
+func TestRecommendationLess(t *testing.T) {
+	nine := model.EventSlot{StartTime: time.Date(2025, 07, 13, 9, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC)}
+	ten := model.EventSlot{StartTime: time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 13, 11, 0, 0, 0, time.UTC)}
+
+	t.Run("Function must rank a higher score first regardless of the tie-break", func(t *testing.T) {
+		a := model.SlotRecommendation{Slot: ten, Feasible: true, Score: 1}
+		b := model.SlotRecommendation{Slot: nine, Feasible: true, Score: 0.5}
+		assert.True(t, recommendationLess(a, b, model.TieBreakEarliest))
+		assert.False(t, recommendationLess(b, a, model.TieBreakEarliest))
+	})
+
+	t.Run("Function must prefer more available users when the most_available tie-break is requested", func(t *testing.T) {
+		a := model.SlotRecommendation{Slot: ten, Feasible: true, Score: 1, Available: []int64{1, 2, 3}}
+		b := model.SlotRecommendation{Slot: nine, Feasible: true, Score: 1, Available: []int64{1, 2}}
+		assert.True(t, recommendationLess(a, b, model.TieBreakMostAvailable))
+		assert.False(t, recommendationLess(a, b, model.TieBreakEarliest))
+	})
+}
+
+func TestGenerateCandidateSlotsAcrossDST(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	localHours := func(slots []model.EventSlot) []int {
+		hours := []int{}
+		for _, slot := range slots {
+			hours = append(hours, slot.StartTime.In(newYork).Hour())
+		}
+		return hours
+	}
+
+	t.Run("Function must keep starts on the local wall clock when the clocks go back", func(t *testing.T) {
+		// 2025-11-02 01:00-02:00 happens twice in New York, the slot covers 4 hours of elapsed time
+		slot := model.EventSlot{
+			StartTime: time.Date(2025, 11, 2, 0, 0, 0, 0, newYork),
+			EndTime:   time.Date(2025, 11, 2, 3, 0, 0, 0, newYork),
+		}
+		candidates := generateCandidateSlots(slot, time.Hour, time.Hour, newYork)
+		assert.Equal(t, []int{0, 1, 2}, localHours(candidates))
+		for _, candidate := range candidates {
+			assert.Equal(t, time.Hour, candidate.EndTime.Sub(candidate.StartTime))
+			assert.Equal(t, "America/New_York", candidate.TimeZone)
+		}
+	})
+
+	t.Run("Function must move starts that fall in the skipped hour when the clocks go forward", func(t *testing.T) {
+		// 2025-03-09 02:00-03:00 does not exist in New York
+		slot := model.EventSlot{
+			StartTime: time.Date(2025, 3, 9, 0, 30, 0, 0, newYork),
+			EndTime:   time.Date(2025, 3, 9, 5, 0, 0, 0, newYork),
+		}
+		candidates := generateCandidateSlots(slot, time.Hour, time.Hour, newYork)
+		// 02:30 moves to 03:30 EDT and the repeated 03:30 start is dropped
+		assert.Equal(t, []int{0, 1, 3}, localHours(candidates))
+		for i := 1; i < len(candidates); i++ {
+			assert.False(t, candidates[i].StartTime.Equal(candidates[i-1].StartTime))
+		}
+	})
+
+	t.Run("Function must judge weekends in the zone of the event", func(t *testing.T) {
+		kolkata, err := time.LoadLocation("Asia/Kolkata")
+		assert.NoError(t, err)
+		// Monday 2025-07-14 02:00 in Kolkata is still Sunday in UTC
+		slot := model.EventSlot{
+			StartTime: time.Date(2025, 7, 14, 2, 0, 0, 0, kolkata),
+			EndTime:   time.Date(2025, 7, 14, 3, 0, 0, 0, kolkata),
+		}
+		candidates := generateCandidateSlots(slot, time.Hour, time.Hour, kolkata)
+		assert.Len(t, filterWindows(candidates, model.RecommendationOptions{ExcludeWeekends: true}), 1)
+	})
 }
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/rahulshewale153/meeting-scheduler-api/repository"
+)
+
+// resourcePlan holds the resources that can serve each resource requirement of an event and when they can be booked
+type resourcePlan struct {
+	requirements []model.ResourceRequirement
+	// candidates holds the resources that can serve each requirement, best fit first
+	candidates [][]model.Resource
+	// windows holds the merged bookable windows of the candidates, a resource without any is bookable at any time
+	windows map[int64][]model.EventSlot
+	// busy holds the merged meetings of the confirmed events, other than the planned one, that booked each candidate
+	busy map[int64][]model.EventSlot
+}
+
+// newResourcePlan loads the candidates of every requirement and their bookable windows. A requirement of a specific
+// resource has only that resource as candidate, the others every resource matching their kind, capacity and features.
+// A room must also seat the headcount of the event, however small the capacity the requirement asks for
+func newResourcePlan(ctx context.Context, resourceRepo repository.ResourceRepositoryI, requirements []model.ResourceRequirement, headcount int) (*resourcePlan, error) {
+	plan := &resourcePlan{
+		requirements: requirements,
+		candidates:   make([][]model.Resource, len(requirements)),
+		busy:         make(map[int64][]model.EventSlot),
+	}
+	for i, requirement := range requirements {
+		if requirement.ResourceID != 0 {
+			resource, err := resourceRepo.GetResource(ctx, requirement.ResourceID)
+			if err != nil {
+				return nil, err
+			}
+			plan.candidates[i] = []model.Resource{resource}
+			continue
+		}
+		filter := model.ResourceFilter{Kind: requirement.Kind, MinCapacity: requirement.MinCapacity, Features: requirement.Features}
+		if requirement.Kind == model.ResourceKindRoom {
+			filter.MinCapacity = max(filter.MinCapacity, headcount)
+		}
+		resources, err := resourceRepo.ListResources(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		plan.candidates[i] = resources
+	}
+
+	windows, err := resourceRepo.GetResourceAvailability(ctx, plan.resourceIDs())
+	if err != nil {
+		return nil, err
+	}
+	plan.windows = make(map[int64][]model.EventSlot, len(windows))
+	for resourceID, resourceWindows := range windows {
+		plan.windows[resourceID] = mergeIntervals(resourceWindows)
+	}
+	return plan, nil
+}
+
+// resourceIDs returns the ids of every candidate in ascending order
+func (p *resourcePlan) resourceIDs() []int64 {
+	seen := make(map[int64]bool)
+	ids := []int64{}
+	for _, candidates := range p.candidates {
+		for _, resource := range candidates {
+			if !seen[resource.ID] {
+				seen[resource.ID] = true
+				ids = append(ids, resource.ID)
+			}
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// loadBookings makes every candidate busy during the meetings in [from, to) of the confirmed events other than
+// eventID that booked it
+func (p *resourcePlan) loadBookings(ctx context.Context, resourceRepo repository.ResourceRepositoryI, eventRepo repository.EventRepositoryI, eventID int64, from time.Time, to time.Time) error {
+	meetings, err := bookedMeetings(ctx, resourceRepo, eventRepo, p.resourceIDs(), from, to)
+	if err != nil {
+		return err
+	}
+	for resourceID, resourceMeetings := range meetings {
+		busy := []model.EventSlot{}
+		for _, meeting := range resourceMeetings {
+			if meeting.EventID != eventID {
+				busy = append(busy, meeting.Slot)
+			}
+		}
+		p.busy[resourceID] = mergeIntervals(busy)
+	}
+	return nil
+}
+
+// free reports whether the resource can be booked for every one of the occurrences
+func (p *resourcePlan) free(resourceID int64, occurrences []model.EventSlot) bool {
+	windows, limited := p.windows[resourceID]
+	for _, occurrence := range occurrences {
+		if limited && !coveredBy(windows, occurrence) {
+			return false
+		}
+		for _, busy := range p.busy[resourceID] {
+			if busy.StartTime.Before(occurrence.EndTime) && busy.EndTime.After(occurrence.StartTime) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// assign picks a resource free in every occurrence for each requirement and reports whether every requirement could
+// be served. A resource serves one requirement at most, so the requirements are matched to their free candidates along
+// augmenting paths: a requirement whose candidates are all taken moves the requirements holding them to other free
+// candidates, and a match for every requirement is found whenever one exists. Candidates are tried best fit first
+func (p *resourcePlan) assign(occurrences []model.EventSlot) ([]int64, bool) {
+	usable := make([][]int64, len(p.requirements))
+	freeByID := make(map[int64]bool)
+	for i, candidates := range p.candidates {
+		for _, resource := range candidates {
+			free, checked := freeByID[resource.ID]
+			if !checked {
+				free = p.free(resource.ID, occurrences)
+				freeByID[resource.ID] = free
+			}
+			if free {
+				usable[i] = append(usable[i], resource.ID)
+			}
+		}
+	}
+
+	assigned := make([]int64, len(p.requirements))
+	// servedBy holds the requirement each taken resource serves
+	servedBy := make(map[int64]int)
+	var augment func(i int, visited map[int64]bool) bool
+	augment = func(i int, visited map[int64]bool) bool {
+		for _, resourceID := range usable[i] {
+			if visited[resourceID] {
+				continue
+			}
+			visited[resourceID] = true
+			holder, taken := servedBy[resourceID]
+			if !taken || augment(holder, visited) {
+				servedBy[resourceID] = i
+				assigned[i] = resourceID
+				return true
+			}
+		}
+		return false
+	}
+	for i := range p.requirements {
+		if !augment(i, make(map[int64]bool)) {
+			return nil, false
+		}
+	}
+	return assigned, true
+}
+
+// unserved returns the requirements that cannot be served in the occurrences, each on its own
+func (p *resourcePlan) unserved(occurrences []model.EventSlot) []model.ResourceRequirement {
+	unserved := []model.ResourceRequirement{}
+	for i, requirement := range p.requirements {
+		served := false
+		for _, resource := range p.candidates[i] {
+			if p.free(resource.ID, occurrences) {
+				served = true
+				break
+			}
+		}
+		if !served {
+			unserved = append(unserved, requirement)
+		}
+	}
+	return unserved
+}
+
+// coveredBy reports whether one of the merged windows holds the whole slot
+func coveredBy(windows []model.EventSlot, slot model.EventSlot) bool {
+	for _, window := range windows {
+		if !window.StartTime.After(slot.StartTime) && !window.EndTime.Before(slot.EndTime) {
+			return true
+		}
+	}
+	return false
+}
+
+// bookedMeetings returns the occurrences in [from, to) of the confirmed events that booked each resource, ordered
+// by start
+func bookedMeetings(ctx context.Context, resourceRepo repository.ResourceRepositoryI, eventRepo repository.EventRepositoryI, resourceIDs []int64, from time.Time, to time.Time) (map[int64][]model.Meeting, error) {
+	resourceEvents, err := resourceRepo.ListBookedEvents(ctx, resourceIDs, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// an event booking several resources is expanded once
+	expanded := make(map[int64][]model.Meeting)
+	meetings := make(map[int64][]model.Meeting, len(resourceEvents))
+	for resourceID, events := range resourceEvents {
+		for _, event := range events {
+			occurrences, ok := expanded[event.ID]
+			if !ok {
+				if occurrences, err = eventMeetings(ctx, eventRepo, event, from, to); err != nil {
+					return nil, err
+				}
+				expanded[event.ID] = occurrences
+			}
+			meetings[resourceID] = append(meetings[resourceID], occurrences...)
+		}
+		sort.SliceStable(meetings[resourceID], func(i, j int) bool {
+			return meetings[resourceID][i].Slot.StartTime.Before(meetings[resourceID][j].Slot.StartTime)
+		})
+	}
+	return meetings, nil
+}
+
+// reserveResources books a free resource for every resource requirement of the event confirmed for slot, or
+// returns a ConflictError naming the requirements no resource is free for. The candidates are locked first, so it
+// must run in the transaction confirming the event, a concurrent confirmation booking one of them then waits for it
+func reserveResources(ctx context.Context, tx *sql.Tx, resourceRepo repository.ResourceRepositoryI, eventRepo repository.EventRepositoryI, plan *resourcePlan, event model.Event, slot model.EventSlot) error {
+	if plan == nil || len(plan.requirements) == 0 {
+		return nil
+	}
+	if err := resourceRepo.LockResources(ctx, tx, plan.resourceIDs()); err != nil {
+		return err
+	}
+	from, to, meetings, err := confirmedOccurrences(ctx, eventRepo, event, slot)
+	if err != nil {
+		return err
+	}
+	if err := plan.loadBookings(ctx, resourceRepo, eventRepo, event.ID, from, to); err != nil {
+		return err
+	}
+
+	occurrences := make([]model.EventSlot, 0, len(meetings))
+	for _, meeting := range meetings {
+		occurrences = append(occurrences, meeting.Slot)
+	}
+	assigned, ok := plan.assign(occurrences)
+	if !ok {
+		missing := []string{}
+		for _, requirement := range plan.unserved(occurrences) {
+			missing = append(missing, fmt.Sprintf("requirement %d", requirement.ID))
+		}
+		if len(missing) == 0 {
+			// each requirement has a free resource but not one of its own
+			return &model.ConflictError{Message: fmt.Sprintf("event %d needs more free resources than are available in the slot", event.ID)}
+		}
+		return &model.ConflictError{Message: fmt.Sprintf("no resource is free in the slot for %s of event %d", strings.Join(missing, ", "), event.ID)}
+	}
+	for i, requirement := range plan.requirements {
+		if err := resourceRepo.BookEventResource(ctx, tx, requirement.ID, assigned[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
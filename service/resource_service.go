@@ -0,0 +1,369 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/rahulshewale153/meeting-scheduler-api/repository"
+)
+
+type resourceService struct {
+	transactionManager repository.TransactionManagerI
+	resourceRepo       repository.ResourceRepositoryI
+	eventRepo          repository.EventRepositoryI
+}
+
+// NewResourceService creates a new instance of resourceService
+func NewResourceService(transactionManager repository.TransactionManagerI, resourceRepo repository.ResourceRepositoryI, eventRepo repository.EventRepositoryI) ResourceServiceI {
+	return &resourceService{
+		transactionManager: transactionManager,
+		resourceRepo:       resourceRepo,
+		eventRepo:          eventRepo,
+	}
+}
+
+// InsertResource adds a room or a piece of equipment
+func (s *resourceService) InsertResource(ctx context.Context, resource model.Resource) (int64, error) {
+	if err := authorizeResourceChange(ctx); err != nil {
+		return 0, err
+	}
+	if err := validateResource(resource); err != nil {
+		return 0, err
+	}
+
+	tx, err := s.transactionManager.BeginTransaction(ctx)
+	if err != nil {
+		return 0, err
+	}
+	// Ensure that the transaction is rolled back or committed properly
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				log.Println("Error rolling back transaction:", rollbackErr)
+			}
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			log.Println("Error committing transaction:", commitErr)
+			return
+		}
+	}()
+
+	resourceID, err := s.resourceRepo.InsertResource(ctx, tx, resource)
+	if err != nil {
+		log.Println("Error inserting resource:", err)
+		return 0, err
+	}
+	return resourceID, nil
+}
+
+// UpdateResource replaces the details of a resource, events that booked it keep their booking
+func (s *resourceService) UpdateResource(ctx context.Context, resource model.Resource) error {
+	if err := authorizeResourceChange(ctx); err != nil {
+		return err
+	}
+	if err := validateResource(resource); err != nil {
+		return err
+	}
+	// make sure the resource exists before updating it
+	if _, err := s.resourceRepo.GetResource(ctx, resource.ID); err != nil {
+		log.Println("Error getting resource:", err)
+		return err
+	}
+
+	tx, err := s.transactionManager.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	// Ensure that the transaction is rolled back or committed properly
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				log.Println("Error rolling back transaction:", rollbackErr)
+			}
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			log.Println("Error committing transaction:", commitErr)
+			return
+		}
+	}()
+
+	if err = s.resourceRepo.UpdateResource(ctx, tx, resource); err != nil {
+		log.Println("Error updating resource:", err)
+		return err
+	}
+	return nil
+}
+
+// DeleteResource removes a resource unless a confirmed event booked it for an upcoming meeting or a requirement of an
+// event names it. Bookings of past or cancelled events are released
+func (s *resourceService) DeleteResource(ctx context.Context, resourceID int64) error {
+	if err := authorizeResourceChange(ctx); err != nil {
+		return err
+	}
+
+	tx, err := s.transactionManager.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	// Ensure that the transaction is rolled back or committed properly
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				log.Println("Error rolling back transaction:", rollbackErr)
+			}
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			log.Println("Error committing transaction:", commitErr)
+			return
+		}
+	}()
+
+	// the lock makes a concurrent confirmation booking the resource wait until it is deleted
+	if err = s.resourceRepo.LockResources(ctx, tx, []int64{resourceID}); err != nil {
+		log.Println("Error locking resource:", err)
+		return err
+	}
+	var meetings map[int64][]model.Meeting
+	if meetings, err = bookedMeetings(ctx, s.resourceRepo, s.eventRepo, []int64{resourceID}, now(), now().Add(maxConflictRange)); err != nil {
+		log.Println("Error listing resource bookings:", err)
+		return err
+	}
+	if booked := meetings[resourceID]; len(booked) > 0 {
+		err = &model.ConflictError{Message: fmt.Sprintf("resource %d is booked by confirmed event %d %q from %s", resourceID, booked[0].EventID, booked[0].Title, booked[0].Slot.StartTime.Format(time.RFC3339))}
+		return err
+	}
+	if err = s.resourceRepo.DeleteResource(ctx, tx, resourceID); err != nil {
+		log.Println("Error deleting resource:", err)
+		return err
+	}
+	return nil
+}
+
+// GetResource returns a resource
+func (s *resourceService) GetResource(ctx context.Context, resourceID int64) (model.Resource, error) {
+	resource, err := s.resourceRepo.GetResource(ctx, resourceID)
+	if err != nil {
+		log.Println("Error getting resource:", err)
+		return model.Resource{}, err
+	}
+	return resource, nil
+}
+
+// ListResources returns the resources matching the filter, smallest capacity first
+func (s *resourceService) ListResources(ctx context.Context, filter model.ResourceFilter) ([]model.Resource, error) {
+	resources, err := s.resourceRepo.ListResources(ctx, filter)
+	if err != nil {
+		log.Println("Error listing resources:", err)
+		return nil, err
+	}
+	return resources, nil
+}
+
+// SetResourceAvailability replaces the windows a resource can be booked in, without windows it can be booked at
+// any time. Bookings outside the new windows are kept
+func (s *resourceService) SetResourceAvailability(ctx context.Context, resourceID int64, windows []model.EventSlot) error {
+	if err := authorizeResourceChange(ctx); err != nil {
+		return err
+	}
+	if err := validateResourceWindows(windows); err != nil {
+		return err
+	}
+	if _, err := s.resourceRepo.GetResource(ctx, resourceID); err != nil {
+		log.Println("Error getting resource:", err)
+		return err
+	}
+
+	tx, err := s.transactionManager.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	// Ensure that the transaction is rolled back or committed properly
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				log.Println("Error rolling back transaction:", rollbackErr)
+			}
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			log.Println("Error committing transaction:", commitErr)
+			return
+		}
+	}()
+
+	if err = s.resourceRepo.ReplaceResourceAvailability(ctx, tx, resourceID, windows); err != nil {
+		log.Println("Error replacing resource availability:", err)
+		return err
+	}
+	return nil
+}
+
+// GetResourceAvailability returns the windows of a resource that overlap [from, to), the meetings that booked it
+// in that range and the free time left. Zero bounds default as in GetUserConflicts
+func (s *resourceService) GetResourceAvailability(ctx context.Context, resourceID int64, from time.Time, to time.Time) (model.ResourceAvailability, error) {
+	from, to, err := lookAheadRange(from, to)
+	if err != nil {
+		return model.ResourceAvailability{}, err
+	}
+	if _, err := s.resourceRepo.GetResource(ctx, resourceID); err != nil {
+		log.Println("Error getting resource:", err)
+		return model.ResourceAvailability{}, err
+	}
+
+	windows, err := s.resourceRepo.GetResourceAvailability(ctx, []int64{resourceID})
+	if err != nil {
+		log.Println("Error getting resource availability:", err)
+		return model.ResourceAvailability{}, err
+	}
+	meetings, err := bookedMeetings(ctx, s.resourceRepo, s.eventRepo, []int64{resourceID}, from, to)
+	if err != nil {
+		log.Println("Error getting resource bookings:", err)
+		return model.ResourceAvailability{}, err
+	}
+
+	availability := model.ResourceAvailability{ResourceID: resourceID, Windows: []model.EventSlot{}, Booked: []model.Meeting{}}
+	bookable := []model.EventSlot{{StartTime: from, EndTime: to}}
+	if resourceWindows, ok := windows[resourceID]; ok {
+		bookable = []model.EventSlot{}
+		for _, window := range resourceWindows {
+			if window.StartTime.Before(to) && window.EndTime.After(from) {
+				availability.Windows = append(availability.Windows, window)
+				bookable = append(bookable, model.EventSlot{StartTime: maxTime(window.StartTime, from), EndTime: minTime(window.EndTime, to)})
+			}
+		}
+	}
+	busy := []model.EventSlot{}
+	for _, meeting := range meetings[resourceID] {
+		availability.Booked = append(availability.Booked, meeting)
+		busy = append(busy, meeting.Slot)
+	}
+	availability.Free = subtractIntervals(mergeIntervals(bookable), mergeIntervals(busy), 0)
+	return availability, nil
+}
+
+// AddEventResource attaches a resource requirement to an open event
+func (s *resourceService) AddEventResource(ctx context.Context, eventID int64, requirement model.ResourceRequirement) (int64, error) {
+	if err := validateResourceRequirement(requirement); err != nil {
+		return 0, err
+	}
+	event, err := s.eventRepo.GetEvent(ctx, eventID)
+	if err != nil {
+		log.Println("Error getting event:", err)
+		return 0, err
+	}
+	if err := authorizeEventChange(ctx, event); err != nil {
+		return 0, err
+	}
+	if err := checkEventOpen(event); err != nil {
+		return 0, err
+	}
+
+	tx, err := s.transactionManager.BeginTransaction(ctx)
+	if err != nil {
+		return 0, err
+	}
+	// Ensure that the transaction is rolled back or committed properly
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				log.Println("Error rolling back transaction:", rollbackErr)
+			}
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			log.Println("Error committing transaction:", commitErr)
+			return
+		}
+	}()
+
+	requirementID, err := s.resourceRepo.InsertEventResource(ctx, tx, eventID, requirement)
+	if err != nil {
+		log.Println("Error inserting event resource:", err)
+		return 0, err
+	}
+	return requirementID, nil
+}
+
+// RemoveEventResource detaches a resource requirement from an open event
+func (s *resourceService) RemoveEventResource(ctx context.Context, eventID int64, requirementID int64) error {
+	event, err := s.eventRepo.GetEvent(ctx, eventID)
+	if err != nil {
+		log.Println("Error getting event:", err)
+		return err
+	}
+	if err := authorizeEventChange(ctx, event); err != nil {
+		return err
+	}
+	if err := checkEventOpen(event); err != nil {
+		return err
+	}
+
+	tx, err := s.transactionManager.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	// Ensure that the transaction is rolled back or committed properly
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				log.Println("Error rolling back transaction:", rollbackErr)
+			}
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			log.Println("Error committing transaction:", commitErr)
+			return
+		}
+	}()
+
+	if err = s.resourceRepo.DeleteEventResource(ctx, tx, eventID, requirementID); err != nil {
+		log.Println("Error deleting event resource:", err)
+		return err
+	}
+	return nil
+}
+
+// ListEventResources returns the resource requirements of an event with the resources booked for them, to those who
+// may read the event
+func (s *resourceService) ListEventResources(ctx context.Context, eventID int64) ([]model.ResourceRequirement, error) {
+	event, err := s.eventRepo.GetEvent(ctx, eventID)
+	if err != nil {
+		log.Println("Error getting event:", err)
+		return nil, err
+	}
+	attendees, err := s.eventRepo.GetEventAttendees(ctx, eventID)
+	if err != nil {
+		log.Println("Error getting event attendees:", err)
+		return nil, err
+	}
+	if err := authorizeEventRead(ctx, event, attendees); err != nil {
+		return nil, err
+	}
+
+	requirements, err := s.resourceRepo.GetEventResources(ctx, eventID)
+	if err != nil {
+		log.Println("Error getting event resources:", err)
+		return nil, err
+	}
+	return requirements, nil
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
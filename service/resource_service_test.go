@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	mock_repository "github.com/rahulshewale153/meeting-scheduler-api/mock/repository"
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/rahulshewale153/meeting-scheduler-api/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertResource(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	mockResourceRepo := new(mock_repository.MockResourceRepository)
+	mockTransactionManager := new(mock_repository.MockTransactionManager)
+	service := NewResourceService(mockTransactionManager, mockResourceRepo, nil)
+	room := model.Resource{Name: "Board room", Kind: model.ResourceKindRoom, Capacity: 12, Features: []string{"projector"}}
+
+	t.Run("Function must forbid callers that are not admins", func(t *testing.T) {
+		_, err := service.InsertResource(organizer, room)
+		assert.ErrorIs(t, err, model.ErrForbidden)
+	})
+
+	t.Run("Function must reject a room without capacity", func(t *testing.T) {
+		_, err := service.InsertResource(admin, model.Resource{Name: "Huddle", Kind: model.ResourceKindRoom})
+		var validationErr *utils.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "capacity", validationErr.Errors.Field[0].Name)
+	})
+
+	t.Run("Function must return the id of the inserted resource", func(t *testing.T) {
+		mockTransactionManager.On("BeginTransaction", admin).Return(tx, nil).Once()
+		mockResourceRepo.On("InsertResource", admin, tx, room).Return(int64(4), nil).Once()
+
+		resourceID, err := service.InsertResource(admin, room)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), resourceID)
+		mockResourceRepo.AssertExpectations(t)
+		mock.ExpectCommit()
+	})
+}
+
+func TestDeleteResource(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	mockResourceRepo := new(mock_repository.MockResourceRepository)
+	mockTransactionManager := new(mock_repository.MockTransactionManager)
+	service := NewResourceService(mockTransactionManager, mockResourceRepo, nil)
+	ctx := context.Background()
+
+	t.Run("Function must return a conflict error while a confirmed event has booked the resource", func(t *testing.T) {
+		review := model.Event{ID: 8, Title: "Review", Status: model.EventStatusConfirmed, ConfirmedSlot: &model.EventSlot{StartTime: now().Add(time.Hour), EndTime: now().Add(2 * time.Hour)}}
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockResourceRepo.On("LockResources", ctx, tx, []int64{4}).Return(nil).Once()
+		mockResourceRepo.On("ListBookedEvents", ctx, []int64{4}, now(), now().Add(maxConflictRange)).Return(map[int64][]model.Event{4: {review}}, nil).Once()
+
+		err := service.DeleteResource(ctx, 4)
+		var conflictErr *model.ConflictError
+		assert.ErrorAs(t, err, &conflictErr)
+		assert.Contains(t, conflictErr.Message, `confirmed event 8 "Review"`)
+		mockResourceRepo.AssertNotCalled(t, "DeleteResource", ctx, tx, int64(4))
+		mockResourceRepo.AssertExpectations(t)
+		mock.ExpectRollback()
+	})
+
+	t.Run("Function must return a conflict error while a requirement of an event names the resource", func(t *testing.T) {
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockResourceRepo.On("LockResources", ctx, tx, []int64{4}).Return(nil).Once()
+		mockResourceRepo.On("ListBookedEvents", ctx, []int64{4}, now(), now().Add(maxConflictRange)).Return(map[int64][]model.Event{}, nil).Once()
+		mockResourceRepo.On("DeleteResource", ctx, tx, int64(4)).Return(&model.ConflictError{Message: "resource 4 is required by an event, remove the requirement first"}).Once()
+
+		err := service.DeleteResource(ctx, 4)
+		assert.ErrorIs(t, err, model.ErrConflict)
+		mockResourceRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must delete a resource without upcoming bookings", func(t *testing.T) {
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockResourceRepo.On("LockResources", ctx, tx, []int64{4}).Return(nil).Once()
+		mockResourceRepo.On("ListBookedEvents", ctx, []int64{4}, now(), now().Add(maxConflictRange)).Return(map[int64][]model.Event{}, nil).Once()
+		mockResourceRepo.On("DeleteResource", ctx, tx, int64(4)).Return(nil).Once()
+
+		err := service.DeleteResource(ctx, 4)
+		assert.NoError(t, err)
+		mockResourceRepo.AssertExpectations(t)
+	})
+}
+
+func TestGetResourceAvailability(t *testing.T) {
+	mockResourceRepo := new(mock_repository.MockResourceRepository)
+	mockEventRepo := new(mock_repository.MockEventRepository)
+	service := NewResourceService(nil, mockResourceRepo, mockEventRepo)
+	ctx := context.Background()
+	at := func(day, hour int) time.Time { return time.Date(2025, 07, day, hour, 0, 0, 0, time.UTC) }
+	room := model.Resource{ID: 4, Name: "Board room", Kind: model.ResourceKindRoom, Capacity: 12}
+
+	t.Run("Function must return a not found error for an unknown resource", func(t *testing.T) {
+		mockResourceRepo.On("GetResource", ctx, int64(9)).Return(model.Resource{}, &model.NotFoundError{Resource: "resource", ID: 9}).Once()
+
+		_, err := service.GetResourceAvailability(ctx, 9, at(14, 0), at(15, 0))
+		assert.ErrorIs(t, err, model.ErrNotFound)
+	})
+
+	t.Run("Function must return the free time left in the windows of the resource", func(t *testing.T) {
+		review := model.Event{ID: 8, Title: "Review", Status: model.EventStatusConfirmed, ConfirmedSlot: &model.EventSlot{StartTime: at(14, 10), EndTime: at(14, 11)}}
+		mockResourceRepo.On("GetResource", ctx, int64(4)).Return(room, nil).Once()
+		// the room can be booked from 09:00 to 17:00, the second window starts after the range
+		mockResourceRepo.On("GetResourceAvailability", ctx, []int64{4}).Return(map[int64][]model.EventSlot{4: {
+			{StartTime: at(14, 9), EndTime: at(14, 17)},
+			{StartTime: at(15, 9), EndTime: at(15, 17)},
+		}}, nil).Once()
+		mockResourceRepo.On("ListBookedEvents", ctx, []int64{4}, at(14, 0), at(15, 0)).Return(map[int64][]model.Event{4: {review}}, nil).Once()
+
+		availability, err := service.GetResourceAvailability(ctx, 4, at(14, 0), at(15, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, []model.EventSlot{{StartTime: at(14, 9), EndTime: at(14, 17)}}, availability.Windows)
+		assert.Equal(t, []model.Meeting{{EventID: 8, Title: "Review", Slot: *review.ConfirmedSlot}}, availability.Booked)
+		assert.Equal(t, []model.EventSlot{
+			{StartTime: at(14, 9), EndTime: at(14, 10)},
+			{StartTime: at(14, 11), EndTime: at(14, 17)},
+		}, availability.Free)
+		mockResourceRepo.AssertExpectations(t)
+	})
+}
+
+func TestAddEventResource(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	mockResourceRepo := new(mock_repository.MockResourceRepository)
+	mockEventRepo := new(mock_repository.MockEventRepository)
+	mockTransactionManager := new(mock_repository.MockTransactionManager)
+	service := NewResourceService(mockTransactionManager, mockResourceRepo, mockEventRepo)
+	ctx := context.Background()
+	eventID := int64(1)
+	requirement := model.ResourceRequirement{Kind: model.ResourceKindRoom, MinCapacity: 6}
+
+	t.Run("Function must return a conflict error when the event is confirmed", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{ID: eventID, Status: model.EventStatusConfirmed}, nil).Once()
+
+		_, err := service.AddEventResource(ctx, eventID, requirement)
+		assert.ErrorIs(t, err, model.ErrConflict)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return the id of the inserted requirement", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, eventID).Return(model.Event{ID: eventID, Status: model.EventStatusPolling}, nil).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockResourceRepo.On("InsertEventResource", ctx, tx, eventID, requirement).Return(int64(3), nil).Once()
+
+		requirementID, err := service.AddEventResource(ctx, eventID, requirement)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), requirementID)
+		mockResourceRepo.AssertExpectations(t)
+		mock.ExpectCommit()
+	})
+}
+
+func TestListEventResources(t *testing.T) {
+	mockResourceRepo := new(mock_repository.MockResourceRepository)
+	mockEventRepo := new(mock_repository.MockEventRepository)
+	service := NewResourceService(new(mock_repository.MockTransactionManager), mockResourceRepo, mockEventRepo)
+	eventID := int64(1)
+	event := model.Event{ID: eventID, OrganizerID: 5, Status: model.EventStatusPolling}
+
+	t.Run("Function must return a not found error when the event does not exist", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", organizer, eventID).Return(model.Event{}, &model.NotFoundError{Resource: "event", ID: eventID}).Once()
+
+		_, err := service.ListEventResources(organizer, eventID)
+		assert.ErrorIs(t, err, model.ErrNotFound)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return a forbidden error when the caller neither organizes nor attends the event", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", otherOrganizer, eventID).Return(event, nil).Once()
+		mockEventRepo.On("GetEventAttendees", otherOrganizer, eventID).Return([]model.Attendee{{UserID: 7}}, nil).Once()
+
+		_, err := service.ListEventResources(otherOrganizer, eventID)
+		assert.ErrorIs(t, err, model.ErrForbidden)
+		mockResourceRepo.AssertNotCalled(t, "GetEventResources", otherOrganizer, eventID)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return the requirements to an attendee of the event", func(t *testing.T) {
+		requirements := []model.ResourceRequirement{{ID: 3, Kind: model.ResourceKindRoom, MinCapacity: 4}}
+		mockEventRepo.On("GetEvent", attendee, eventID).Return(event, nil).Once()
+		mockEventRepo.On("GetEventAttendees", attendee, eventID).Return([]model.Attendee{{UserID: 7}}, nil).Once()
+		mockResourceRepo.On("GetEventResources", attendee, eventID).Return(requirements, nil).Once()
+
+		result, err := service.ListEventResources(attendee, eventID)
+		assert.NoError(t, err)
+		assert.Equal(t, requirements, result)
+		mockResourceRepo.AssertExpectations(t)
+	})
+}
+
+func TestNewResourcePlan(t *testing.T) {
+	mockResourceRepo := new(mock_repository.MockResourceRepository)
+	ctx := context.Background()
+	board := model.Resource{ID: 12, Kind: model.ResourceKindRoom, Capacity: 12}
+	projector := model.Resource{ID: 21, Kind: model.ResourceKindEquipment}
+
+	t.Run("Function must only consider rooms that seat every participant", func(t *testing.T) {
+		requirements := []model.ResourceRequirement{
+			{ID: 1, Kind: model.ResourceKindRoom, MinCapacity: 2, Features: []string{"whiteboard"}},
+			{ID: 2, Kind: model.ResourceKindEquipment, Features: []string{"projector"}},
+		}
+		mockResourceRepo.On("ListResources", ctx, model.ResourceFilter{Kind: model.ResourceKindRoom, MinCapacity: 6, Features: []string{"whiteboard"}}).Return([]model.Resource{board}, nil).Once()
+		mockResourceRepo.On("ListResources", ctx, model.ResourceFilter{Kind: model.ResourceKindEquipment, Features: []string{"projector"}}).Return([]model.Resource{projector}, nil).Once()
+		mockResourceRepo.On("GetResourceAvailability", ctx, []int64{12, 21}).Return(map[int64][]model.EventSlot{}, nil).Once()
+
+		plan, err := newResourcePlan(ctx, mockResourceRepo, requirements, 6)
+		assert.NoError(t, err)
+		assert.Equal(t, [][]model.Resource{{board}, {projector}}, plan.candidates)
+		mockResourceRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must keep a larger capacity the requirement asks for", func(t *testing.T) {
+		requirements := []model.ResourceRequirement{{ID: 1, Kind: model.ResourceKindRoom, MinCapacity: 10}}
+		mockResourceRepo.On("ListResources", ctx, model.ResourceFilter{Kind: model.ResourceKindRoom, MinCapacity: 10}).Return([]model.Resource{board}, nil).Once()
+		mockResourceRepo.On("GetResourceAvailability", ctx, []int64{12}).Return(map[int64][]model.EventSlot{}, nil).Once()
+
+		_, err := newResourcePlan(ctx, mockResourceRepo, requirements, 3)
+		assert.NoError(t, err)
+		mockResourceRepo.AssertExpectations(t)
+	})
+}
+
+func TestResourcePlanAssign(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2025, 07, 14, hour, 0, 0, 0, time.UTC) }
+	huddle := model.Resource{ID: 11, Kind: model.ResourceKindRoom, Capacity: 4}
+	board := model.Resource{ID: 12, Kind: model.ResourceKindRoom, Capacity: 12}
+	plan := &resourcePlan{
+		// any room, then the board room itself
+		requirements: []model.ResourceRequirement{{ID: 1, Kind: model.ResourceKindRoom}, {ID: 2, ResourceID: 12}},
+		candidates:   [][]model.Resource{{huddle, board}, {board}},
+		// the huddle room can only be booked in the morning
+		windows: map[int64][]model.EventSlot{11: {{StartTime: at(9), EndTime: at(12)}}},
+		busy:    map[int64][]model.EventSlot{},
+	}
+
+	t.Run("Function must leave a specific resource to the requirement naming it", func(t *testing.T) {
+		assigned, ok := plan.assign([]model.EventSlot{{StartTime: at(10), EndTime: at(11)}})
+		assert.True(t, ok)
+		assert.Equal(t, []int64{11, 12}, assigned)
+	})
+
+	t.Run("Function must fail when a resource would serve two requirements", func(t *testing.T) {
+		assigned, ok := plan.assign([]model.EventSlot{{StartTime: at(14), EndTime: at(15)}})
+		assert.False(t, ok)
+		assert.Nil(t, assigned)
+		// each requirement has a free resource on its own
+		assert.Empty(t, plan.unserved([]model.EventSlot{{StartTime: at(14), EndTime: at(15)}}))
+	})
+
+	t.Run("Function must fail when an occurrence falls outside the windows of the only candidate", func(t *testing.T) {
+		_, ok := plan.assign([]model.EventSlot{{StartTime: at(10), EndTime: at(11)}, {StartTime: at(11), EndTime: at(13)}})
+		assert.False(t, ok)
+	})
+
+	t.Run("Function must move an earlier requirement to another room when a later one needs its room", func(t *testing.T) {
+		projectorRoom := model.Resource{ID: 13, Kind: model.ResourceKindRoom, Capacity: 2, Features: []string{"projector"}}
+		plan := &resourcePlan{
+			// a room for two, then a room with a projector
+			requirements: []model.ResourceRequirement{
+				{ID: 1, Kind: model.ResourceKindRoom, MinCapacity: 2},
+				{ID: 2, Kind: model.ResourceKindRoom, Features: []string{"projector"}},
+			},
+			// the projector room fits two best and is the only one with a projector
+			candidates: [][]model.Resource{{projectorRoom, huddle, board}, {projectorRoom}},
+			windows:    map[int64][]model.EventSlot{},
+			busy:       map[int64][]model.EventSlot{},
+		}
+
+		assigned, ok := plan.assign([]model.EventSlot{{StartTime: at(10), EndTime: at(11)}})
+		assert.True(t, ok)
+		assert.Equal(t, []int64{11, 13}, assigned)
+	})
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"time"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/rahulshewale153/meeting-scheduler-api/utils"
+)
+
+const (
+	// defaultSeriesOccurrences is how many occurrences of a recurring event a slot is judged on when none are requested
+	defaultSeriesOccurrences = 4
+	maxSeriesOccurrences     = 52
+)
+
+// seriesOccurrences returns the first count occurrences of a recurring event held at window, repeating it on the
+// wall clock of loc. Occurrences on a date in skipped are left out and do not count towards count
+func seriesOccurrences(window model.EventSlot, rrule utils.RRule, loc *time.Location, count int, skipped map[string]bool) []model.EventSlot {
+	occurrences := []model.EventSlot{}
+	duration := window.EndTime.Sub(window.StartTime)
+	rrule.Each(window.StartTime.In(loc), func(start time.Time) bool {
+		if skipped[start.Format(time.DateOnly)] {
+			return true
+		}
+		occurrences = append(occurrences, model.EventSlot{
+			StartTime: start,
+			EndTime:   start.Add(duration),
+			TimeZone:  loc.String(),
+		})
+		return len(occurrences) < count
+	})
+	return occurrences
+}
+
+// intersectUsers returns the sorted ids present in both sorted lists
+func intersectUsers(a []int64, b []int64) []int64 {
+	both := make([]int64, 0, len(a))
+	for _, id := range a {
+		if containsAll(b, []int64{id}) {
+			both = append(both, id)
+		}
+	}
+	return both
+}
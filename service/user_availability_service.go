@@ -2,27 +2,101 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"log"
+	"sort"
+	"time"
 
 	"github.com/rahulshewale153/meeting-scheduler-api/model"
 	"github.com/rahulshewale153/meeting-scheduler-api/repository"
 	"github.com/rahulshewale153/meeting-scheduler-api/utils"
 )
 
+// maxAvailabilityBatchItems is how many users an availability batch may hold
+const maxAvailabilityBatchItems = 500
+
 type userAvailabilityService struct {
 	transactionManager   repository.TransactionManagerI
 	userAvailabilityRepo repository.UserAvailabilityRepositoryI
+	userRepo             repository.UserRepositoryI
+	eventRepo            repository.EventRepositoryI
 }
 
-func NewUserAvailabilityService(transactionManager repository.TransactionManagerI, userAvailabilityRepo repository.UserAvailabilityRepositoryI) UserAvailabilityServiceI {
+func NewUserAvailabilityService(transactionManager repository.TransactionManagerI, userAvailabilityRepo repository.UserAvailabilityRepositoryI, userRepo repository.UserRepositoryI, eventRepo repository.EventRepositoryI) UserAvailabilityServiceI {
 	return &userAvailabilityService{
 		transactionManager:   transactionManager,
 		userAvailabilityRepo: userAvailabilityRepo,
+		userRepo:             userRepo,
+		eventRepo:            eventRepo,
+	}
+}
+
+// resolveTimeZone returns the zone the availability was submitted in, falling back to the zone of the user profile
+func (s *userAvailabilityService) resolveTimeZone(ctx context.Context, userAvailability model.UserAvailability) (string, error) {
+	if userAvailability.TimeZone != "" {
+		return userAvailability.TimeZone, nil
+	}
+	return userTimeZone(ctx, s.userRepo, userAvailability.UserID)
+}
+
+// userTimeZone returns the zone of the user profile, DefaultTimeZone for users who never saved one
+func userTimeZone(ctx context.Context, userRepo repository.UserRepositoryI, userID int64) (string, error) {
+	profile, err := userRepo.GetUserProfile(ctx, userID)
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return utils.DefaultTimeZone, nil
+		}
+		log.Println("Error retrieving user profile:", err)
+		return "", err
+	}
+	return profile.TimeZone, nil
+}
+
+// openEvent returns the event the availability of userID belongs to, provided the caller may change it.
+// Availability of confirmed or cancelled events is locked
+func (s *userAvailabilityService) openEvent(ctx context.Context, eventID int64, userID int64) (model.Event, error) {
+	event, err := s.eventRepo.GetEvent(ctx, eventID)
+	if err != nil {
+		log.Println("Error getting event:", err)
+		return model.Event{}, err
 	}
+	if err := authorizeAvailability(ctx, event, userID); err != nil {
+		return model.Event{}, err
+	}
+	return event, checkEventOpen(event)
+}
+
+// startPolling moves a draft event to polling once the first availability arrives, event must have been read with
+// lockOpenEvent in the same transaction
+func startPolling(ctx context.Context, tx *sql.Tx, eventRepo repository.EventRepositoryI, event model.Event) error {
+	if event.Status != model.EventStatusDraft {
+		return nil
+	}
+	if err := eventRepo.StartPolling(ctx, tx, event.ID); err != nil {
+		log.Println("Error updating event status:", err)
+		return err
+	}
+	return nil
 }
 
-// InsertUserAvailability inserts a new user availability record into the database.
+// InsertUserAvailability inserts the slots and recurring rules a user submitted for an event.
 func (s *userAvailabilityService) InsertUserAvailability(ctx context.Context, userAvailability model.UserAvailability) error {
+	if err := validateUserAvailability(userAvailability); err != nil {
+		return err
+	}
+
+	event, err := s.openEvent(ctx, userAvailability.EventID, userAvailability.UserID)
+	if err != nil {
+		return err
+	}
+
+	timeZone, err := s.resolveTimeZone(ctx, userAvailability)
+	if err != nil {
+		return err
+	}
+
 	tx, err := s.transactionManager.BeginTransaction(ctx)
 	if err != nil {
 		return err
@@ -41,20 +115,189 @@ func (s *userAvailabilityService) InsertUserAvailability(ctx context.Context, us
 		}
 	}()
 
+	if event, err = lockOpenEvent(ctx, tx, s.eventRepo, userAvailability.EventID); err != nil {
+		return err
+	}
 	userID := userAvailability.UserID
 	eventID := userAvailability.EventID
 	for _, slot := range userAvailability.Availability {
-		_, err = s.userAvailabilityRepo.InsertUserAvailability(ctx, tx, userID, eventID, slot.StartTime, slot.EndTime)
+		if slot.TimeZone == "" {
+			slot.TimeZone = timeZone
+		}
+		_, err = s.userAvailabilityRepo.InsertUserAvailability(ctx, tx, userID, eventID, slot.StartTime.UTC(), slot.EndTime.UTC(), slot.TimeZone)
 		if err != nil {
 			return err
 		}
 	}
 
+	if err = s.insertAvailabilityRules(ctx, tx, userAvailability, timeZone); err != nil {
+		return err
+	}
+	err = startPolling(ctx, tx, s.eventRepo, event)
+	return err
+}
+
+// InsertUserAvailabilityBatch stores the availability many users submitted for an event in one transaction. Every
+// item is checked before anything is written, when one fails nothing is stored and the result tells why each failed
+// item was rejected. Items without a zone get the zone of the profile of their user
+func (s *userAvailabilityService) InsertUserAvailabilityBatch(ctx context.Context, eventID int64, items []model.UserAvailability) (model.AvailabilityBatchResult, error) {
+	if len(items) == 0 || len(items) > maxAvailabilityBatchItems {
+		validationErr := &utils.ValidationError{}
+		validationErr.Add("items", len(items), fmt.Sprintf("items must hold between 1 and %d users", maxAvailabilityBatchItems))
+		return model.AvailabilityBatchResult{}, validationErr
+	}
+	event, err := s.eventRepo.GetEvent(ctx, eventID)
+	if err != nil {
+		log.Println("Error getting event:", err)
+		return model.AvailabilityBatchResult{}, err
+	}
+	if err := checkEventOpen(event); err != nil {
+		return model.AvailabilityBatchResult{}, err
+	}
+
+	batch := make([]model.UserAvailability, len(items))
+	result := model.AvailabilityBatchResult{Items: make([]model.AvailabilityBatchItem, len(items))}
+	seen := make(map[int64]int, len(items))
+	for i, item := range items {
+		item.EventID = eventID
+		batch[i] = item
+		result.Items[i] = model.AvailabilityBatchItem{Index: i, UserID: item.UserID, Slots: len(item.Availability), Rules: len(item.Rules)}
+		itemErr := validateAvailabilityBatchItem(item, seen)
+		if itemErr == nil {
+			itemErr = authorizeAvailability(ctx, event, item.UserID)
+		}
+		if itemErr != nil {
+			result.Items[i].Status, result.Items[i].Err = model.BatchItemFailed, itemErr
+			result.Failed++
+		}
+		if _, ok := seen[item.UserID]; !ok {
+			seen[item.UserID] = i
+		}
+	}
+	if result.Failed > 0 {
+		for i := range result.Items {
+			if result.Items[i].Status == "" {
+				result.Items[i].Status = model.BatchItemNotStored
+			}
+		}
+		return result, nil
+	}
+
+	if err := s.resolveBatchTimeZones(ctx, batch); err != nil {
+		return model.AvailabilityBatchResult{}, err
+	}
+
+	tx, err := s.transactionManager.BeginTransaction(ctx)
+	if err != nil {
+		return model.AvailabilityBatchResult{}, err
+	}
+	// Ensure that the transaction is rolled back or committed properly
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				log.Println("Error rolling back transaction:", rollbackErr)
+			}
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			log.Println("Error committing transaction:", commitErr)
+			return
+		}
+	}()
+
+	if event, err = lockOpenEvent(ctx, tx, s.eventRepo, eventID); err != nil {
+		return model.AvailabilityBatchResult{}, err
+	}
+	if err = s.userAvailabilityRepo.InsertUserAvailabilityBatch(ctx, tx, eventID, batch); err != nil {
+		log.Println("Error inserting user availability batch:", err)
+		return model.AvailabilityBatchResult{}, err
+	}
+	if err = startPolling(ctx, tx, s.eventRepo, event); err != nil {
+		return model.AvailabilityBatchResult{}, err
+	}
+	for i := range result.Items {
+		result.Items[i].Status = model.BatchItemStored
+	}
+	result.Stored = len(result.Items)
+	return result, nil
+}
+
+// resolveBatchTimeZones fills in the zone of every slot and rule of the batch, reading the profiles of the users
+// whose items have no zone at once
+func (s *userAvailabilityService) resolveBatchTimeZones(ctx context.Context, batch []model.UserAvailability) error {
+	userIDs := []int64{}
+	for _, item := range batch {
+		if item.TimeZone == "" {
+			userIDs = append(userIDs, item.UserID)
+		}
+	}
+	profiles := map[int64]model.UserProfile{}
+	if len(userIDs) > 0 {
+		var err error
+		if profiles, err = s.userRepo.GetUserProfiles(ctx, userIDs); err != nil {
+			log.Println("Error retrieving user profiles:", err)
+			return err
+		}
+	}
+
+	for i, item := range batch {
+		timeZone := item.TimeZone
+		if timeZone == "" {
+			timeZone = utils.DefaultTimeZone
+			if profile, ok := profiles[item.UserID]; ok {
+				timeZone = profile.TimeZone
+			}
+		}
+		slots := make([]model.EventSlot, len(item.Availability))
+		for j, slot := range item.Availability {
+			if slot.TimeZone == "" {
+				slot.TimeZone = timeZone
+			}
+			slot.StartTime, slot.EndTime = slot.StartTime.UTC(), slot.EndTime.UTC()
+			slots[j] = slot
+		}
+		rules := make([]model.AvailabilityRule, len(item.Rules))
+		for j, rule := range item.Rules {
+			if rule.TimeZone == "" {
+				rule.TimeZone = timeZone
+			}
+			rules[j] = rule
+		}
+		batch[i].Availability, batch[i].Rules = slots, rules
+	}
 	return nil
 }
 
-// UpdateUserAvailability updates the availability of a user for a specific event.
+// insertAvailabilityRules stores the recurring rules of the availability, rules without a zone get timeZone
+func (s *userAvailabilityService) insertAvailabilityRules(ctx context.Context, tx *sql.Tx, userAvailability model.UserAvailability, timeZone string) error {
+	for _, rule := range userAvailability.Rules {
+		if rule.TimeZone == "" {
+			rule.TimeZone = timeZone
+		}
+		if _, err := s.userAvailabilityRepo.InsertAvailabilityRule(ctx, tx, userAvailability.UserID, userAvailability.EventID, rule); err != nil {
+			log.Println("Error inserting availability rule:", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateUserAvailability updates the availability of a user for a specific event, the recurring rules are replaced as a whole.
 func (s *userAvailabilityService) UpdateUserAvailability(ctx context.Context, userAvailability model.UserAvailability) error {
+	if err := validateUserAvailability(userAvailability); err != nil {
+		return err
+	}
+
+	event, err := s.openEvent(ctx, userAvailability.EventID, userAvailability.UserID)
+	if err != nil {
+		return err
+	}
+
+	timeZone, err := s.resolveTimeZone(ctx, userAvailability)
+	if err != nil {
+		return err
+	}
+
 	tx, err := s.transactionManager.BeginTransaction(ctx)
 	if err != nil {
 		return err
@@ -73,11 +316,15 @@ func (s *userAvailabilityService) UpdateUserAvailability(ctx context.Context, us
 		}
 	}()
 
+	if event, err = lockOpenEvent(ctx, tx, s.eventRepo, userAvailability.EventID); err != nil {
+		return err
+	}
 	existingMap := make(map[string]model.EventSlot)
 	incomingMap := make(map[string]model.EventSlot)
 
-	//Get existing user availability
-	existingUserAvailability, err := s.userAvailabilityRepo.GetUserAvailability(ctx, userAvailability.EventID, userAvailability.UserID)
+	// only the submitted slots are replaced, a slot synced from the calendar of the user is left to the sync. A
+	// submitted slot equal to a synced one is stored as well, so that it outlives the synced one
+	existingUserAvailability, err := s.userAvailabilityRepo.GetSubmittedAvailability(ctx, userAvailability.EventID, userAvailability.UserID)
 	if err != nil {
 		log.Println("Error retrieving user availability:", err)
 		return err
@@ -92,6 +339,9 @@ func (s *userAvailabilityService) UpdateUserAvailability(ctx context.Context, us
 		key := utils.SlotKey(slot)
 		incomingMap[key] = slot
 		if _, ok := existingMap[key]; !ok {
+			if slot.TimeZone == "" {
+				slot.TimeZone = timeZone
+			}
 			slot.StartTime, err = utils.ConvertTimeToUTC(ctx, slot.StartTime)
 			if err != nil {
 				log.Println("Error converting start time to UTC:", err)
@@ -103,7 +353,7 @@ func (s *userAvailabilityService) UpdateUserAvailability(ctx context.Context, us
 				log.Println("Error converting end time to UTC:", err)
 				return err
 			}
-			_, err = s.userAvailabilityRepo.InsertUserAvailability(ctx, tx, userAvailability.UserID, userAvailability.EventID, slot.StartTime, slot.EndTime)
+			_, err = s.userAvailabilityRepo.InsertUserAvailability(ctx, tx, userAvailability.UserID, userAvailability.EventID, slot.StartTime, slot.EndTime, slot.TimeZone)
 			if err != nil {
 				log.Println("Error inserting user availability:", err)
 				return err
@@ -114,7 +364,7 @@ func (s *userAvailabilityService) UpdateUserAvailability(ctx context.Context, us
 	// Delete slots that are in existing but not in incoming
 	for key, oldSlot := range existingMap {
 		if _, ok := incomingMap[key]; !ok {
-			err = s.userAvailabilityRepo.DeleteUserAvailability(ctx, tx, userAvailability.UserID, oldSlot.ID)
+			err = s.userAvailabilityRepo.DeleteUserAvailabilitySlot(ctx, tx, userAvailability.UserID, userAvailability.EventID, oldSlot.ID)
 			if err != nil {
 				log.Println("Error deleting user availability:", err)
 				return err
@@ -122,11 +372,123 @@ func (s *userAvailabilityService) UpdateUserAvailability(ctx context.Context, us
 		}
 	}
 
-	return nil
+	if err = s.userAvailabilityRepo.DeleteAvailabilityRules(ctx, tx, userAvailability.UserID, userAvailability.EventID); err != nil {
+		log.Println("Error deleting availability rules:", err)
+		return err
+	}
+	if err = s.insertAvailabilityRules(ctx, tx, userAvailability, timeZone); err != nil {
+		return err
+	}
+	err = startPolling(ctx, tx, s.eventRepo, event)
+	return err
+}
+
+// ImportUserAvailability reads the busy time of a user from an iCalendar object and stores the free parts of the
+// proposed slots of the event as availability. Parts shorter than the event and slots the user already submitted
+// are left out, times without a zone in the calendar are read in the zone of the user profile
+func (s *userAvailabilityService) ImportUserAvailability(ctx context.Context, eventID int64, userID int64, calendar []byte) (model.AvailabilityImport, error) {
+	event, err := s.openEvent(ctx, eventID, userID)
+	if err != nil {
+		return model.AvailabilityImport{}, err
+	}
+
+	timeZone, err := s.resolveTimeZone(ctx, model.UserAvailability{UserID: userID})
+	if err != nil {
+		return model.AvailabilityImport{}, err
+	}
+	loc, err := utils.LoadTimeZone(timeZone)
+	if err != nil {
+		log.Println("Error loading user time zone:", err)
+		return model.AvailabilityImport{}, err
+	}
+
+	proposedSlots, err := s.eventRepo.GetEventSlots(ctx, eventID)
+	if err != nil {
+		log.Println("Error retrieving event slots:", err)
+		return model.AvailabilityImport{}, err
+	}
+	result := model.AvailabilityImport{UserID: userID, EventID: eventID, Busy: []model.EventSlot{}, Imported: []model.EventSlot{}}
+	from, to, ok := eventWindow(proposedSlots)
+	if !ok {
+		return result, nil
+	}
+
+	busy, err := utils.ParseICalendarBusy(calendar, loc, from, to)
+	if err != nil {
+		validationErr := &utils.ValidationError{}
+		validationErr.Add("calendar", nil, "calendar is invalid: "+err.Error())
+		return model.AvailabilityImport{}, validationErr
+	}
+	busy = mergeIntervals(busy)
+	result.Busy = busy
+
+	existing, err := s.userAvailabilityRepo.GetSubmittedAvailability(ctx, eventID, userID)
+	if err != nil {
+		log.Println("Error retrieving user availability:", err)
+		return model.AvailabilityImport{}, err
+	}
+	submitted := make(map[string]bool)
+	for _, slot := range existing {
+		submitted[utils.SlotKey(slot)] = true
+	}
+
+	duration := time.Duration(event.DurationMinutes) * time.Minute
+	for _, slot := range subtractIntervals(mergeIntervals(proposedSlots), busy, duration) {
+		if !submitted[utils.SlotKey(slot)] {
+			slot.TimeZone = timeZone
+			result.Imported = append(result.Imported, slot)
+		}
+	}
+	if len(result.Imported) == 0 {
+		return result, nil
+	}
+
+	tx, err := s.transactionManager.BeginTransaction(ctx)
+	if err != nil {
+		return model.AvailabilityImport{}, err
+	}
+	// Ensure that the transaction is rolled back or committed properly
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				log.Println("Error rolling back transaction:", rollbackErr)
+			}
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			log.Println("Error committing transaction:", commitErr)
+			return
+		}
+	}()
+
+	if event, err = lockOpenEvent(ctx, tx, s.eventRepo, eventID); err != nil {
+		return model.AvailabilityImport{}, err
+	}
+	for i, slot := range result.Imported {
+		result.Imported[i].ID, err = s.userAvailabilityRepo.InsertUserAvailability(ctx, tx, userID, eventID, slot.StartTime.UTC(), slot.EndTime.UTC(), slot.TimeZone)
+		if err != nil {
+			log.Println("Error inserting user availability:", err)
+			return model.AvailabilityImport{}, err
+		}
+	}
+
+	if err = startPolling(ctx, tx, s.eventRepo, event); err != nil {
+		return model.AvailabilityImport{}, err
+	}
+	return result, nil
 }
 
 // DeleteUserAvailability deletes a user availability record from the database.
 func (s *userAvailabilityService) DeleteUserAvailability(ctx context.Context, userID int64, eventID int64) error {
+	if _, err := s.openEvent(ctx, eventID, userID); err != nil {
+		return err
+	}
+
+	// make sure the user has submitted availability for the event before deleting it
+	if _, err := s.getUserAvailability(ctx, eventID, userID); err != nil {
+		return err
+	}
+
 	tx, err := s.transactionManager.BeginTransaction(ctx)
 	if err != nil {
 		return err
@@ -145,21 +507,101 @@ func (s *userAvailabilityService) DeleteUserAvailability(ctx context.Context, us
 		}
 	}()
 
+	if _, err = lockOpenEvent(ctx, tx, s.eventRepo, eventID); err != nil {
+		return err
+	}
 	err = s.userAvailabilityRepo.DeleteUserAvailability(ctx, tx, userID, eventID)
 	if err != nil {
 		log.Println("Error deleting user availability:", err)
 		return err
 	}
 
+	err = s.userAvailabilityRepo.DeleteAvailabilityRules(ctx, tx, userID, eventID)
+	if err != nil {
+		log.Println("Error deleting availability rules:", err)
+		return err
+	}
+
 	return nil
 }
 
-// GetUserAvailability retrieves the availability of a specific user for a specific event.
+// GetUserAvailability retrieves the availability of a specific user for a specific event,
+// recurring rules are expanded over the span of the proposed slots of the event.
 func (s *userAvailabilityService) GetUserAvailability(ctx context.Context, eventID int64, userID int64) ([]model.EventSlot, error) {
+	event, err := s.eventRepo.GetEvent(ctx, eventID)
+	if err != nil {
+		log.Println("Error getting event:", err)
+		return nil, err
+	}
+	if err := authorizeAvailability(ctx, event, userID); err != nil {
+		return nil, err
+	}
+	return s.getUserAvailability(ctx, eventID, userID)
+}
+
+// ListUserEventAvailability retrieves a page of the events a user is invited to or responded to, with the slots and
+// rules they submitted for each. Rules are returned as submitted rather than expanded
+func (s *userAvailabilityService) ListUserEventAvailability(ctx context.Context, userID int64, filter model.UserEventFilter) (model.UserEventAvailabilityList, error) {
+	if err := authorizeUserAvailability(ctx, userID); err != nil {
+		return model.UserEventAvailabilityList{}, err
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultEventPageSize
+	}
+	if filter.Limit > maxEventPageSize {
+		filter.Limit = maxEventPageSize
+	}
+	pageSize := filter.Limit
+
+	// fetch one extra row to find out whether another page exists
+	filter.Limit = pageSize + 1
+	userEvents, err := s.userAvailabilityRepo.ListUserEventAvailability(ctx, userID, filter)
+	if err != nil {
+		log.Println("Error listing user event availability:", err)
+		return model.UserEventAvailabilityList{}, err
+	}
+
+	result := model.UserEventAvailabilityList{Events: userEvents}
+	if len(userEvents) > pageSize {
+		result.Events = userEvents[:pageSize]
+		result.NextCursor = utils.EncodeCursor(result.Events[pageSize-1].Event.ID)
+	}
+	for i := range result.Events {
+		result.Events[i].ResponseStatus = model.ResponseStatusPending
+		if len(result.Events[i].Availability) > 0 || len(result.Events[i].Rules) > 0 {
+			result.Events[i].ResponseStatus = model.ResponseStatusResponded
+		}
+	}
+	return result, nil
+}
+
+func (s *userAvailabilityService) getUserAvailability(ctx context.Context, eventID int64, userID int64) ([]model.EventSlot, error) {
 	slots, err := s.userAvailabilityRepo.GetUserAvailability(ctx, eventID, userID)
 	if err != nil {
 		log.Println("Error retrieving user availability:", err)
 		return nil, err
 	}
+
+	rules, err := s.userAvailabilityRepo.GetAvailabilityRules(ctx, eventID, userID)
+	if err != nil {
+		log.Println("Error retrieving availability rules:", err)
+		return nil, err
+	}
+	if len(slots) == 0 && len(rules) == 0 {
+		return nil, &model.NotFoundError{Resource: "user availability", ID: userID}
+	}
+	if len(rules) == 0 {
+		return slots, nil
+	}
+
+	eventSlots, err := s.eventRepo.GetEventSlots(ctx, eventID)
+	if err != nil {
+		log.Println("Error retrieving event slots:", err)
+		return nil, err
+	}
+	if from, to, ok := eventWindow(eventSlots); ok {
+		slots = append(slots, expandRules(rules, from, to)...)
+		sort.SliceStable(slots, func(i, j int) bool { return slots[i].StartTime.Before(slots[j].StartTime) })
+	}
 	return slots, nil
 }
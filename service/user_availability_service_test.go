@@ -2,16 +2,26 @@ package service
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	mock_repository "github.com/rahulshewale153/meeting-scheduler-api/mock/repository"
 	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/rahulshewale153/meeting-scheduler-api/utils"
 	"github.com/stretchr/testify/assert"
 	testifyMock "github.com/stretchr/testify/mock"
 )
 
+// pollingEventRepo returns an event repository where every event is polling and accepts availability
+func pollingEventRepo() *mock_repository.MockEventRepository {
+	mockEventRepo := new(mock_repository.MockEventRepository)
+	mockEventRepo.On("GetEvent", testifyMock.Anything, testifyMock.Anything).Return(model.Event{ID: 1, Status: model.EventStatusPolling}, nil).Maybe()
+	mockEventRepo.On("LockEvent", testifyMock.Anything, testifyMock.Anything, testifyMock.Anything).Return(model.Event{ID: 1, Status: model.EventStatusPolling}, nil).Maybe()
+	return mockEventRepo
+}
+
 func TestInsertUserAvailability(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.Nil(t, err)
@@ -24,11 +34,12 @@ func TestInsertUserAvailability(t *testing.T) {
 
 	mockUserAvailRepo := new(mock_repository.MockUserAvailabilityRepository)
 	mockTransactionManager := new(mock_repository.MockTransactionManager)
-	userAvailabilityService := NewUserAvailabilityService(mockTransactionManager, mockUserAvailRepo)
+	userAvailabilityService := NewUserAvailabilityService(mockTransactionManager, mockUserAvailRepo, new(mock_repository.MockUserRepository), pollingEventRepo())
 	ctx := context.Background()
 	userAvailability := model.UserAvailability{
-		UserID:  1,
-		EventID: 1,
+		UserID:   1,
+		EventID:  1,
+		TimeZone: "Asia/Kolkata",
 		Availability: []model.EventSlot{
 			{
 				StartTime: time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC),
@@ -37,6 +48,12 @@ func TestInsertUserAvailability(t *testing.T) {
 		},
 	}
 
+	t.Run("Function must return a forbidden error when the caller submits for another user", func(t *testing.T) {
+		err := userAvailabilityService.InsertUserAvailability(attendee, userAvailability)
+		assert.ErrorIs(t, err, model.ErrForbidden)
+		mockTransactionManager.AssertExpectations(t)
+	})
+
 	t.Run("Function must return an error when the transaction cannot be started", func(t *testing.T) {
 		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, assert.AnError).Once()
 		err := userAvailabilityService.InsertUserAvailability(ctx, userAvailability)
@@ -46,7 +63,7 @@ func TestInsertUserAvailability(t *testing.T) {
 
 	t.Run("Function must return an error when the write operation fails", func(t *testing.T) {
 		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
-		mockUserAvailRepo.On("InsertUserAvailability", ctx, tx, userAvailability.UserID, userAvailability.EventID, userAvailability.Availability[0].StartTime, userAvailability.Availability[0].EndTime).
+		mockUserAvailRepo.On("InsertUserAvailability", ctx, tx, userAvailability.UserID, userAvailability.EventID, userAvailability.Availability[0].StartTime, userAvailability.Availability[0].EndTime, "Asia/Kolkata").
 			Return(int64(0), assert.AnError).Once()
 
 		err := userAvailabilityService.InsertUserAvailability(ctx, userAvailability)
@@ -58,7 +75,7 @@ func TestInsertUserAvailability(t *testing.T) {
 
 	t.Run("Function must return nil when the insert operation is successful", func(t *testing.T) {
 		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
-		mockUserAvailRepo.On("InsertUserAvailability", ctx, tx, userAvailability.UserID, userAvailability.EventID, userAvailability.Availability[0].StartTime, userAvailability.Availability[0].EndTime).
+		mockUserAvailRepo.On("InsertUserAvailability", ctx, tx, userAvailability.UserID, userAvailability.EventID, userAvailability.Availability[0].StartTime, userAvailability.Availability[0].EndTime, "Asia/Kolkata").
 			Return(int64(1), nil).Once()
 
 		err := userAvailabilityService.InsertUserAvailability(ctx, userAvailability)
@@ -69,6 +86,73 @@ func TestInsertUserAvailability(t *testing.T) {
 
 	})
 
+	t.Run("Function must store slots submitted with an offset in UTC", func(t *testing.T) {
+		kolkata := time.FixedZone("+05:30", 5*60*60+30*60)
+		withOffset := userAvailability
+		withOffset.Availability = []model.EventSlot{{
+			StartTime: time.Date(2025, 07, 13, 15, 30, 0, 0, kolkata),
+			EndTime:   time.Date(2025, 07, 13, 16, 30, 0, 0, kolkata),
+		}}
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockUserAvailRepo.On("InsertUserAvailability", ctx, tx, withOffset.UserID, withOffset.EventID, time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC), time.Date(2025, 07, 13, 11, 0, 0, 0, time.UTC), "Asia/Kolkata").
+			Return(int64(2), nil).Once()
+
+		err := userAvailabilityService.InsertUserAvailability(ctx, withOffset)
+		assert.NoError(t, err)
+		mockUserAvailRepo.AssertExpectations(t)
+	})
+}
+
+func TestInsertUserAvailabilityTimeZone(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	mockUserAvailRepo := new(mock_repository.MockUserAvailabilityRepository)
+	mockUserRepo := new(mock_repository.MockUserRepository)
+	mockTransactionManager := new(mock_repository.MockTransactionManager)
+	userAvailabilityService := NewUserAvailabilityService(mockTransactionManager, mockUserAvailRepo, mockUserRepo, pollingEventRepo())
+	ctx := context.Background()
+	start := time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 07, 13, 11, 0, 0, 0, time.UTC)
+	userAvailability := model.UserAvailability{
+		UserID:       1,
+		EventID:      1,
+		Availability: []model.EventSlot{{StartTime: start, EndTime: end}},
+	}
+
+	t.Run("Function must return an error when the user profile cannot be read", func(t *testing.T) {
+		mockUserRepo.On("GetUserProfile", ctx, int64(1)).Return(model.UserProfile{}, assert.AnError).Once()
+
+		err := userAvailabilityService.InsertUserAvailability(ctx, userAvailability)
+		assert.Error(t, err)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must store the zone of the user profile when the request has none", func(t *testing.T) {
+		mockUserRepo.On("GetUserProfile", ctx, int64(1)).Return(model.UserProfile{UserID: 1, TimeZone: "America/New_York"}, nil).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockUserAvailRepo.On("InsertUserAvailability", ctx, tx, int64(1), int64(1), start, end, "America/New_York").Return(int64(1), nil).Once()
+
+		err := userAvailabilityService.InsertUserAvailability(ctx, userAvailability)
+		assert.NoError(t, err)
+		mockUserAvailRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must store UTC when the user has no profile", func(t *testing.T) {
+		mockUserRepo.On("GetUserProfile", ctx, int64(1)).Return(model.UserProfile{}, &model.NotFoundError{Resource: "user", ID: 1}).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockUserAvailRepo.On("InsertUserAvailability", ctx, tx, int64(1), int64(1), start, end, "UTC").Return(int64(1), nil).Once()
+
+		err := userAvailabilityService.InsertUserAvailability(ctx, userAvailability)
+		assert.NoError(t, err)
+		mockUserAvailRepo.AssertExpectations(t)
+	})
 }
 
 func TestUpdateUserAvailability(t *testing.T) {
@@ -83,11 +167,12 @@ func TestUpdateUserAvailability(t *testing.T) {
 
 	mockUserAvailRepo := new(mock_repository.MockUserAvailabilityRepository)
 	mockTransactionManager := new(mock_repository.MockTransactionManager)
-	userAvailabilityService := NewUserAvailabilityService(mockTransactionManager, mockUserAvailRepo)
+	userAvailabilityService := NewUserAvailabilityService(mockTransactionManager, mockUserAvailRepo, new(mock_repository.MockUserRepository), pollingEventRepo())
 	ctx := context.Background()
 	userAvailability := model.UserAvailability{
-		UserID:  1,
-		EventID: 1,
+		UserID:   1,
+		EventID:  2,
+		TimeZone: "Asia/Kolkata",
 		Availability: []model.EventSlot{
 			{
 				StartTime: time.Date(2025, 07, 12, 12, 0, 0, 0, time.UTC),
@@ -103,9 +188,9 @@ func TestUpdateUserAvailability(t *testing.T) {
 		mockTransactionManager.AssertExpectations(t)
 	})
 
-	t.Run("Function must return an error when GetUserAvailability operation fails", func(t *testing.T) {
+	t.Run("Function must return an error when the submitted availability cannot be read", func(t *testing.T) {
 		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
-		mockUserAvailRepo.On("GetUserAvailability", ctx, userAvailability.EventID, userAvailability.UserID).
+		mockUserAvailRepo.On("GetSubmittedAvailability", ctx, userAvailability.EventID, userAvailability.UserID).
 			Return(nil, assert.AnError).Once()
 		err := userAvailabilityService.UpdateUserAvailability(ctx, userAvailability)
 		assert.Error(t, err)
@@ -117,8 +202,8 @@ func TestUpdateUserAvailability(t *testing.T) {
 	t.Run("Function must return an error when the GetUserAvailability operation is successful", func(t *testing.T) {
 		t.Run("Function must return an error when the update operation fails", func(t *testing.T) {
 			mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
-			mockUserAvailRepo.On("GetUserAvailability", ctx, userAvailability.EventID, userAvailability.UserID).Return([]model.EventSlot{model.EventSlot{ID: 1, StartTime: time.Date(2025, 07, 12, 10, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 12, 11, 0, 0, 0, time.UTC)}}, nil).Once()
-			mockUserAvailRepo.On("InsertUserAvailability", ctx, tx, userAvailability.UserID, userAvailability.EventID, testifyMock.Anything, testifyMock.Anything).
+			mockUserAvailRepo.On("GetSubmittedAvailability", ctx, userAvailability.EventID, userAvailability.UserID).Return([]model.EventSlot{model.EventSlot{ID: 1, StartTime: time.Date(2025, 07, 12, 10, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 12, 11, 0, 0, 0, time.UTC)}}, nil).Once()
+			mockUserAvailRepo.On("InsertUserAvailability", ctx, tx, userAvailability.UserID, userAvailability.EventID, testifyMock.Anything, testifyMock.Anything, "Asia/Kolkata").
 				Return(int64(0), assert.AnError).Once()
 
 			err := userAvailabilityService.UpdateUserAvailability(ctx, userAvailability)
@@ -130,10 +215,12 @@ func TestUpdateUserAvailability(t *testing.T) {
 
 		t.Run("Function must return nil when the update operation is successful", func(t *testing.T) {
 			mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
-			mockUserAvailRepo.On("GetUserAvailability", ctx, userAvailability.EventID, userAvailability.UserID).Return([]model.EventSlot{model.EventSlot{ID: 1, StartTime: time.Date(2025, 07, 12, 10, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 12, 11, 0, 0, 0, time.UTC)}}, nil).Once()
-			mockUserAvailRepo.On("InsertUserAvailability", ctx, tx, userAvailability.UserID, userAvailability.EventID, testifyMock.Anything, testifyMock.Anything).
+			mockUserAvailRepo.On("GetSubmittedAvailability", ctx, userAvailability.EventID, userAvailability.UserID).Return([]model.EventSlot{model.EventSlot{ID: 1, StartTime: time.Date(2025, 07, 12, 10, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 12, 11, 0, 0, 0, time.UTC)}}, nil).Once()
+			mockUserAvailRepo.On("InsertUserAvailability", ctx, tx, userAvailability.UserID, userAvailability.EventID, testifyMock.Anything, testifyMock.Anything, "Asia/Kolkata").
 				Return(int64(1), nil).Once()
-			mockUserAvailRepo.On("DeleteUserAvailability", ctx, tx, userAvailability.UserID, int64(1)).
+			mockUserAvailRepo.On("DeleteUserAvailabilitySlot", ctx, tx, userAvailability.UserID, userAvailability.EventID, int64(1)).
+				Return(nil).Once()
+			mockUserAvailRepo.On("DeleteAvailabilityRules", ctx, tx, userAvailability.UserID, userAvailability.EventID).
 				Return(nil).Once()
 			err := userAvailabilityService.UpdateUserAvailability(ctx, userAvailability)
 			assert.NoError(t, err)
@@ -156,12 +243,24 @@ func TestDeleteUserAvailability(t *testing.T) {
 
 	mockUserAvailRepo := new(mock_repository.MockUserAvailabilityRepository)
 	mockTransactionManager := new(mock_repository.MockTransactionManager)
-	userAvailabilityService := NewUserAvailabilityService(mockTransactionManager, mockUserAvailRepo)
+	userAvailabilityService := NewUserAvailabilityService(mockTransactionManager, mockUserAvailRepo, new(mock_repository.MockUserRepository), pollingEventRepo())
 	ctx := context.Background()
 	userID := int64(1)
 	eventID := int64(1)
+	existingSlots := []model.EventSlot{
+		{ID: 1, StartTime: time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 13, 11, 0, 0, 0, time.UTC)},
+	}
+	mockUserAvailRepo.On("GetAvailabilityRules", ctx, eventID, userID).Return([]model.AvailabilityRule{}, nil).Maybe()
+
+	t.Run("Function must return a not found error when the user has no availability", func(t *testing.T) {
+		mockUserAvailRepo.On("GetUserAvailability", ctx, eventID, userID).Return([]model.EventSlot{}, nil).Once()
+		err := userAvailabilityService.DeleteUserAvailability(ctx, userID, eventID)
+		assert.ErrorIs(t, err, model.ErrNotFound)
+		mockUserAvailRepo.AssertExpectations(t)
+	})
 
 	t.Run("Function must return an error when the transaction cannot be started", func(t *testing.T) {
+		mockUserAvailRepo.On("GetUserAvailability", ctx, eventID, userID).Return(existingSlots, nil).Once()
 		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, assert.AnError).Once()
 		err := userAvailabilityService.DeleteUserAvailability(ctx, userID, eventID)
 		assert.Error(t, err)
@@ -169,6 +268,7 @@ func TestDeleteUserAvailability(t *testing.T) {
 	})
 
 	t.Run("Function must return an error when the delete operation fails", func(t *testing.T) {
+		mockUserAvailRepo.On("GetUserAvailability", ctx, eventID, userID).Return(existingSlots, nil).Once()
 		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
 		mockUserAvailRepo.On("DeleteUserAvailability", ctx, tx, userID, eventID).
 			Return(assert.AnError).Once()
@@ -181,9 +281,12 @@ func TestDeleteUserAvailability(t *testing.T) {
 	})
 
 	t.Run("Function must return nil when the delete operation is successful", func(t *testing.T) {
+		mockUserAvailRepo.On("GetUserAvailability", ctx, eventID, userID).Return(existingSlots, nil).Once()
 		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
 		mockUserAvailRepo.On("DeleteUserAvailability", ctx, tx, userID, eventID).
 			Return(nil).Once()
+		mockUserAvailRepo.On("DeleteAvailabilityRules", ctx, tx, userID, eventID).
+			Return(nil).Once()
 
 		err := userAvailabilityService.DeleteUserAvailability(ctx, userID, eventID)
 		assert.NoError(t, err)
@@ -200,7 +303,7 @@ func TestGetUserAvailability(t *testing.T) {
 	defer db.Close()
 
 	mockUserAvailRepo := new(mock_repository.MockUserAvailabilityRepository)
-	userAvailabilityService := NewUserAvailabilityService(nil, mockUserAvailRepo)
+	userAvailabilityService := NewUserAvailabilityService(nil, mockUserAvailRepo, nil, pollingEventRepo())
 	ctx := context.Background()
 	eventID := int64(1)
 	userID := int64(1)
@@ -220,10 +323,431 @@ func TestGetUserAvailability(t *testing.T) {
 		}
 		mockUserAvailRepo.On("GetUserAvailability", ctx, eventID, userID).
 			Return(expectedSlots, nil).Once()
+		mockUserAvailRepo.On("GetAvailabilityRules", ctx, eventID, userID).Return([]model.AvailabilityRule{}, nil).Once()
 
 		slots, err := userAvailabilityService.GetUserAvailability(ctx, eventID, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, expectedSlots, slots)
 		mockUserAvailRepo.AssertExpectations(t)
 	})
+
+	t.Run("Function must return a not found error when the user has no availability", func(t *testing.T) {
+		mockUserAvailRepo.On("GetUserAvailability", ctx, eventID, userID).
+			Return([]model.EventSlot{}, nil).Once()
+		mockUserAvailRepo.On("GetAvailabilityRules", ctx, eventID, userID).Return([]model.AvailabilityRule{}, nil).Once()
+
+		_, err := userAvailabilityService.GetUserAvailability(ctx, eventID, userID)
+		assert.ErrorIs(t, err, model.ErrNotFound)
+		mockUserAvailRepo.AssertExpectations(t)
+	})
+}
+
+func TestListUserEventAvailability(t *testing.T) {
+	mockUserAvailRepo := new(mock_repository.MockUserAvailabilityRepository)
+	userAvailabilityService := NewUserAvailabilityService(nil, mockUserAvailRepo, nil, nil)
+	ten := time.Date(2025, 07, 14, 10, 0, 0, 0, time.UTC)
+
+	t.Run("Function must forbid listing the availability of another user", func(t *testing.T) {
+		_, err := userAvailabilityService.ListUserEventAvailability(organizer, 7, model.UserEventFilter{})
+		assert.ErrorIs(t, err, model.ErrForbidden)
+	})
+
+	t.Run("Function must return an error when the read operation fails", func(t *testing.T) {
+		mockUserAvailRepo.On("ListUserEventAvailability", attendee, int64(7), model.UserEventFilter{Limit: defaultEventPageSize + 1}).
+			Return([]model.UserEventAvailability{}, assert.AnError).Once()
+
+		_, err := userAvailabilityService.ListUserEventAvailability(attendee, 7, model.UserEventFilter{})
+		assert.ErrorIs(t, err, assert.AnError)
+		mockUserAvailRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must report the response status and a cursor when another page exists", func(t *testing.T) {
+		mockUserAvailRepo.On("ListUserEventAvailability", attendee, int64(7), model.UserEventFilter{Status: model.EventStatusPolling, Limit: 3}).
+			Return([]model.UserEventAvailability{
+				{Event: model.Event{ID: 2}, Invited: true, Availability: []model.EventSlot{}, Rules: []model.AvailabilityRule{}},
+				{Event: model.Event{ID: 4}, Availability: []model.EventSlot{{StartTime: ten, EndTime: ten.Add(time.Hour)}}, Rules: []model.AvailabilityRule{}},
+				{Event: model.Event{ID: 6}, Invited: true, Availability: []model.EventSlot{}, Rules: []model.AvailabilityRule{}},
+			}, nil).Once()
+
+		result, err := userAvailabilityService.ListUserEventAvailability(attendee, 7, model.UserEventFilter{Status: model.EventStatusPolling, Limit: 2})
+		assert.NoError(t, err)
+		assert.Len(t, result.Events, 2)
+		assert.Equal(t, model.ResponseStatusPending, result.Events[0].ResponseStatus)
+		assert.Equal(t, model.ResponseStatusResponded, result.Events[1].ResponseStatus)
+		assert.Equal(t, utils.EncodeCursor(4), result.NextCursor)
+		mockUserAvailRepo.AssertExpectations(t)
+	})
+}
+
+func TestUserAvailabilityRules(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	mockUserAvailRepo := new(mock_repository.MockUserAvailabilityRepository)
+	mockEventRepo := pollingEventRepo()
+	mockTransactionManager := new(mock_repository.MockTransactionManager)
+	userAvailabilityService := NewUserAvailabilityService(mockTransactionManager, mockUserAvailRepo, new(mock_repository.MockUserRepository), mockEventRepo)
+	ctx := context.Background()
+	rule := model.AvailabilityRule{
+		StartTime: time.Date(2025, 07, 07, 4, 30, 0, 0, time.UTC),
+		EndTime:   time.Date(2025, 07, 07, 6, 30, 0, 0, time.UTC),
+		RRule:     "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+	}
+
+	t.Run("Function must store rules in the zone of the request when they have none", func(t *testing.T) {
+		storedRule := rule
+		storedRule.TimeZone = "Asia/Kolkata"
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockUserAvailRepo.On("InsertAvailabilityRule", ctx, tx, int64(1), int64(2), storedRule).Return(int64(1), nil).Once()
+
+		err := userAvailabilityService.InsertUserAvailability(ctx, model.UserAvailability{UserID: 1, EventID: 2, TimeZone: "Asia/Kolkata", Rules: []model.AvailabilityRule{rule}})
+		assert.NoError(t, err)
+		mockUserAvailRepo.AssertExpectations(t)
+		mock.ExpectCommit()
+	})
+
+	t.Run("Function must return an error when the rule cannot be stored", func(t *testing.T) {
+		storedRule := rule
+		storedRule.TimeZone = "Asia/Kolkata"
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockUserAvailRepo.On("InsertAvailabilityRule", ctx, tx, int64(1), int64(2), storedRule).Return(int64(0), assert.AnError).Once()
+
+		err := userAvailabilityService.InsertUserAvailability(ctx, model.UserAvailability{UserID: 1, EventID: 2, TimeZone: "Asia/Kolkata", Rules: []model.AvailabilityRule{rule}})
+		assert.Error(t, err)
+		mockUserAvailRepo.AssertExpectations(t)
+		mock.ExpectRollback()
+	})
+
+	t.Run("Function must expand the rules over the proposed slots of the event", func(t *testing.T) {
+		rule := rule
+		rule.TimeZone = "Asia/Kolkata"
+		concrete := model.EventSlot{ID: 3, StartTime: time.Date(2025, 07, 15, 10, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 15, 11, 0, 0, 0, time.UTC), TimeZone: "UTC"}
+		mockUserAvailRepo.On("GetUserAvailability", ctx, int64(2), int64(1)).Return([]model.EventSlot{concrete}, nil).Once()
+		mockUserAvailRepo.On("GetAvailabilityRules", ctx, int64(2), int64(1)).Return([]model.AvailabilityRule{rule}, nil).Once()
+		mockEventRepo.On("GetEventSlots", ctx, int64(2)).Return([]model.EventSlot{
+			{StartTime: time.Date(2025, 07, 14, 0, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 15, 12, 0, 0, 0, time.UTC)},
+		}, nil).Once()
+
+		slots, err := userAvailabilityService.GetUserAvailability(ctx, 2, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []model.EventSlot{
+			{StartTime: time.Date(2025, 07, 14, 4, 30, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 14, 6, 30, 0, 0, time.UTC), TimeZone: "Asia/Kolkata"},
+			{StartTime: time.Date(2025, 07, 15, 4, 30, 0, 0, time.UTC), EndTime: time.Date(2025, 07, 15, 6, 30, 0, 0, time.UTC), TimeZone: "Asia/Kolkata"},
+			concrete,
+		}, slots)
+		mockUserAvailRepo.AssertExpectations(t)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must replace the rules on update", func(t *testing.T) {
+		rule := rule
+		rule.TimeZone = "UTC"
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockUserAvailRepo.On("GetSubmittedAvailability", ctx, int64(2), int64(1)).Return([]model.EventSlot{}, nil).Once()
+		mockUserAvailRepo.On("DeleteAvailabilityRules", ctx, tx, int64(1), int64(2)).Return(nil).Once()
+		mockUserAvailRepo.On("InsertAvailabilityRule", ctx, tx, int64(1), int64(2), rule).Return(int64(2), nil).Once()
+
+		err := userAvailabilityService.UpdateUserAvailability(ctx, model.UserAvailability{UserID: 1, EventID: 2, TimeZone: "UTC", Rules: []model.AvailabilityRule{rule}})
+		assert.NoError(t, err)
+		mockUserAvailRepo.AssertExpectations(t)
+		mock.ExpectCommit()
+	})
+}
+
+func TestUserAvailabilityEventStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	mockUserAvailRepo := new(mock_repository.MockUserAvailabilityRepository)
+	mockEventRepo := new(mock_repository.MockEventRepository)
+	mockTransactionManager := new(mock_repository.MockTransactionManager)
+	userAvailabilityService := NewUserAvailabilityService(mockTransactionManager, mockUserAvailRepo, new(mock_repository.MockUserRepository), mockEventRepo)
+	ctx := context.Background()
+	start := time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC)
+	userAvailability := model.UserAvailability{
+		UserID:       1,
+		EventID:      2,
+		TimeZone:     "UTC",
+		Availability: []model.EventSlot{{StartTime: start, EndTime: start.Add(time.Hour)}},
+	}
+
+	t.Run("Function must return a not found error when the event does not exist", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, int64(2)).Return(model.Event{}, &model.NotFoundError{Resource: "event", ID: 2}).Once()
+		err := userAvailabilityService.InsertUserAvailability(ctx, userAvailability)
+		assert.ErrorIs(t, err, model.ErrNotFound)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must reject availability changes once the event is confirmed or cancelled", func(t *testing.T) {
+		for _, status := range []string{model.EventStatusConfirmed, model.EventStatusCancelled} {
+			mockEventRepo.On("GetEvent", ctx, int64(2)).Return(model.Event{ID: 2, Status: status}, nil).Times(3)
+			assert.ErrorIs(t, userAvailabilityService.InsertUserAvailability(ctx, userAvailability), model.ErrConflict, status)
+			assert.ErrorIs(t, userAvailabilityService.UpdateUserAvailability(ctx, userAvailability), model.ErrConflict, status)
+			assert.ErrorIs(t, userAvailabilityService.DeleteUserAvailability(ctx, 1, 2), model.ErrConflict, status)
+		}
+		mockEventRepo.AssertExpectations(t)
+		mockTransactionManager.AssertExpectations(t)
+	})
+
+	t.Run("Function must not store availability when the event is confirmed while waiting for the lock", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, int64(2)).Return(model.Event{ID: 2, Status: model.EventStatusDraft}, nil).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("LockEvent", ctx, tx, int64(2)).Return(model.Event{ID: 2, Status: model.EventStatusConfirmed}, nil).Once()
+
+		err := userAvailabilityService.InsertUserAvailability(ctx, userAvailability)
+		assert.ErrorIs(t, err, model.ErrConflict)
+		mockEventRepo.AssertExpectations(t)
+		mockUserAvailRepo.AssertExpectations(t)
+		mock.ExpectRollback()
+	})
+
+	t.Run("Function must start polling a draft event on the first availability", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, int64(2)).Return(model.Event{ID: 2, Status: model.EventStatusDraft}, nil).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("LockEvent", ctx, tx, int64(2)).Return(model.Event{ID: 2, Status: model.EventStatusDraft}, nil).Once()
+		mockUserAvailRepo.On("InsertUserAvailability", ctx, tx, int64(1), int64(2), start, start.Add(time.Hour), "UTC").Return(int64(1), nil).Once()
+		mockEventRepo.On("StartPolling", ctx, tx, int64(2)).Return(nil).Once()
+
+		err := userAvailabilityService.InsertUserAvailability(ctx, userAvailability)
+		assert.NoError(t, err)
+		mockEventRepo.AssertExpectations(t)
+		mockUserAvailRepo.AssertExpectations(t)
+		mock.ExpectCommit()
+	})
+}
+
+func TestImportUserAvailability(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	mockUserAvailRepo := new(mock_repository.MockUserAvailabilityRepository)
+	mockEventRepo := new(mock_repository.MockEventRepository)
+	mockUserRepo := new(mock_repository.MockUserRepository)
+	mockTransactionManager := new(mock_repository.MockTransactionManager)
+	userAvailabilityService := NewUserAvailabilityService(mockTransactionManager, mockUserAvailRepo, mockUserRepo, mockEventRepo)
+	ctx := context.Background()
+	at := func(hour, minute int) time.Time { return time.Date(2025, 07, 14, hour, minute, 0, 0, time.UTC) }
+	proposedSlots := []model.EventSlot{{ID: 1, StartTime: at(9, 0), EndTime: at(12, 0), TimeZone: "UTC"}}
+	mockUserRepo.On("GetUserProfile", ctx, int64(1)).Return(model.UserProfile{UserID: 1, TimeZone: "Europe/Berlin"}, nil)
+
+	t.Run("Function must reject an import once the event is confirmed", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, int64(2)).Return(model.Event{ID: 2, Status: model.EventStatusConfirmed}, nil).Once()
+
+		_, err := userAvailabilityService.ImportUserAvailability(ctx, 2, 1, []byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"))
+		assert.ErrorIs(t, err, model.ErrConflict)
+	})
+
+	t.Run("Function must return a validation error for a calendar that cannot be read", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, int64(2)).Return(model.Event{ID: 2, DurationMinutes: 30, Status: model.EventStatusPolling}, nil).Once()
+		mockEventRepo.On("GetEventSlots", ctx, int64(2)).Return(proposedSlots, nil).Once()
+
+		_, err := userAvailabilityService.ImportUserAvailability(ctx, 2, 1, []byte("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART:tomorrow\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"))
+		var validationErr *utils.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "calendar", validationErr.Errors.Field[0].Name)
+	})
+
+	t.Run("Function must store the free parts of the proposed slots that can hold the event", func(t *testing.T) {
+		calendar := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"VERSION:2.0",
+			"BEGIN:VEVENT",
+			"UID:standup",
+			"DTSTART:20250707T090000Z",
+			"DTEND:20250707T091500Z",
+			"RRULE:FREQ=WEEKLY",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"UID:review",
+			"DTSTART;TZID=Europe/Berlin:20250714T113000",
+			"DTEND;TZID=Europe/Berlin:20250714T120000",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"UID:floating",
+			"DTSTART:20250714T120000",
+			"DURATION:PT15M",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"UID:lunch-reminder",
+			"DTSTART:20250714T103000Z",
+			"DTEND:20250714T110000Z",
+			"TRANSP:TRANSPARENT",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"UID:cancelled",
+			"DTSTART:20250714T101500Z",
+			"DTEND:20250714T103000Z",
+			"STATUS:CANCELLED",
+			"END:VEVENT",
+			"BEGIN:VFREEBUSY",
+			"FREEBUSY;FBTYPE=FREE:20250714T093000Z/20250714T120000Z",
+			"FREEBUSY:20250714T110000Z/PT30M",
+			"END:VFREEBUSY",
+			"END:VCALENDAR",
+		}, "\r\n")
+		mockEventRepo.On("GetEvent", ctx, int64(2)).Return(model.Event{ID: 2, DurationMinutes: 30, Status: model.EventStatusDraft}, nil).Once()
+		mockEventRepo.On("GetEventSlots", ctx, int64(2)).Return(proposedSlots, nil).Once()
+		// 11:30-12:00 was submitted before and is not stored twice
+		mockUserAvailRepo.On("GetSubmittedAvailability", ctx, int64(2), int64(1)).Return([]model.EventSlot{{ID: 3, StartTime: at(11, 30), EndTime: at(12, 0)}}, nil).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("LockEvent", ctx, tx, int64(2)).Return(model.Event{ID: 2, DurationMinutes: 30, Status: model.EventStatusDraft}, nil).Once()
+		mockUserAvailRepo.On("InsertUserAvailability", ctx, tx, int64(1), int64(2), at(10, 15), at(11, 0), "Europe/Berlin").Return(int64(7), nil).Once()
+		mockEventRepo.On("StartPolling", ctx, tx, int64(2)).Return(nil).Once()
+
+		result, err := userAvailabilityService.ImportUserAvailability(ctx, 2, 1, []byte(calendar))
+		assert.NoError(t, err)
+		busy := []string{}
+		for _, slot := range result.Busy {
+			busy = append(busy, utils.SlotKey(slot))
+		}
+		// 09:15-09:30 is free but too short for the event
+		assert.Equal(t, []string{
+			utils.SlotKey(model.EventSlot{StartTime: at(9, 0), EndTime: at(9, 15)}),
+			utils.SlotKey(model.EventSlot{StartTime: at(9, 30), EndTime: at(10, 15)}),
+			utils.SlotKey(model.EventSlot{StartTime: at(11, 0), EndTime: at(11, 30)}),
+		}, busy)
+		assert.Len(t, result.Imported, 1)
+		assert.Equal(t, int64(7), result.Imported[0].ID)
+		assert.Equal(t, "Europe/Berlin", result.Imported[0].TimeZone)
+		mockUserAvailRepo.AssertExpectations(t)
+		mockEventRepo.AssertExpectations(t)
+		mock.ExpectCommit()
+	})
+
+	t.Run("Function must not open a transaction when nothing is free", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, int64(2)).Return(model.Event{ID: 2, DurationMinutes: 30, Status: model.EventStatusPolling}, nil).Once()
+		mockEventRepo.On("GetEventSlots", ctx, int64(2)).Return(proposedSlots, nil).Once()
+		mockUserAvailRepo.On("GetSubmittedAvailability", ctx, int64(2), int64(1)).Return([]model.EventSlot{}, nil).Once()
+
+		result, err := userAvailabilityService.ImportUserAvailability(ctx, 2, 1, []byte("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20250714\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"))
+		assert.NoError(t, err)
+		assert.Len(t, result.Busy, 1)
+		assert.Empty(t, result.Imported)
+		mockTransactionManager.AssertExpectations(t)
+	})
+}
+
+func TestInsertUserAvailabilityBatch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	mockUserAvailRepo := new(mock_repository.MockUserAvailabilityRepository)
+	mockEventRepo := new(mock_repository.MockEventRepository)
+	mockUserRepo := new(mock_repository.MockUserRepository)
+	mockTransactionManager := new(mock_repository.MockTransactionManager)
+	userAvailabilityService := NewUserAvailabilityService(mockTransactionManager, mockUserAvailRepo, mockUserRepo, mockEventRepo)
+	ctx := context.Background()
+	start := time.Date(2025, 07, 13, 10, 0, 0, 0, time.UTC)
+	slots := []model.EventSlot{{StartTime: start, EndTime: start.Add(time.Hour)}}
+	items := []model.UserAvailability{
+		{UserID: 7, TimeZone: "UTC", Availability: slots},
+		{UserID: 8, Availability: slots},
+		{UserID: 9, Availability: slots},
+	}
+
+	t.Run("Function must return a validation error for an empty batch", func(t *testing.T) {
+		_, err := userAvailabilityService.InsertUserAvailabilityBatch(ctx, 2, nil)
+		var validationErr *utils.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "items", validationErr.Errors.Field[0].Name)
+	})
+
+	t.Run("Function must reject a batch once the event is confirmed", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, int64(2)).Return(model.Event{ID: 2, Status: model.EventStatusConfirmed}, nil).Once()
+		_, err := userAvailabilityService.InsertUserAvailabilityBatch(ctx, 2, items)
+		assert.ErrorIs(t, err, model.ErrConflict)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must store nothing and report every item when an item is a duplicate", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, int64(2)).Return(model.Event{ID: 2, Status: model.EventStatusPolling}, nil).Once()
+		duplicated := append(append([]model.UserAvailability{}, items...), model.UserAvailability{UserID: 8, Availability: slots})
+
+		result, err := userAvailabilityService.InsertUserAvailabilityBatch(ctx, 2, duplicated)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, result.Stored)
+		assert.Equal(t, 1, result.Failed)
+		assert.Equal(t, model.BatchItemNotStored, result.Items[1].Status)
+		assert.Equal(t, model.BatchItemFailed, result.Items[3].Status)
+		var validationErr *utils.ValidationError
+		assert.ErrorAs(t, result.Items[3].Err, &validationErr)
+		assert.Equal(t, "user_id 8 is already in items[1]", validationErr.Errors.Field[0].ErrorMessage)
+		mockTransactionManager.AssertExpectations(t)
+		mockUserAvailRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must report the items a caller cannot submit for", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", attendee, int64(2)).Return(model.Event{ID: 2, OrganizerID: 5, Status: model.EventStatusPolling}, nil).Once()
+
+		result, err := userAvailabilityService.InsertUserAvailabilityBatch(attendee, 2, items)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.Failed)
+		assert.Equal(t, model.BatchItemNotStored, result.Items[0].Status)
+		assert.ErrorIs(t, result.Items[1].Err, model.ErrForbidden)
+		assert.ErrorIs(t, result.Items[2].Err, model.ErrForbidden)
+		mockTransactionManager.AssertExpectations(t)
+	})
+
+	t.Run("Function must return an error when the write operation fails", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, int64(2)).Return(model.Event{ID: 2, Status: model.EventStatusPolling}, nil).Once()
+		mockUserRepo.On("GetUserProfiles", ctx, []int64{8, 9}).Return(map[int64]model.UserProfile{}, nil).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("LockEvent", ctx, tx, int64(2)).Return(model.Event{ID: 2, Status: model.EventStatusPolling}, nil).Once()
+		mockUserAvailRepo.On("InsertUserAvailabilityBatch", ctx, tx, int64(2), testifyMock.Anything).Return(assert.AnError).Once()
+
+		_, err := userAvailabilityService.InsertUserAvailabilityBatch(ctx, 2, items)
+		assert.Error(t, err)
+		mockUserAvailRepo.AssertExpectations(t)
+		mock.ExpectRollback()
+	})
+
+	t.Run("Function must store every item in the zone of its user and start polling a draft event", func(t *testing.T) {
+		mockEventRepo.On("GetEvent", ctx, int64(2)).Return(model.Event{ID: 2, Status: model.EventStatusDraft}, nil).Once()
+		mockUserRepo.On("GetUserProfiles", ctx, []int64{8, 9}).Return(map[int64]model.UserProfile{8: {UserID: 8, TimeZone: "Europe/Berlin"}}, nil).Once()
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockEventRepo.On("LockEvent", ctx, tx, int64(2)).Return(model.Event{ID: 2, Status: model.EventStatusDraft}, nil).Once()
+		mockUserAvailRepo.On("InsertUserAvailabilityBatch", ctx, tx, int64(2), testifyMock.MatchedBy(func(batch []model.UserAvailability) bool {
+			return len(batch) == 3 && batch[0].EventID == 2 &&
+				batch[0].Availability[0].TimeZone == "UTC" &&
+				batch[1].Availability[0].TimeZone == "Europe/Berlin" &&
+				batch[2].Availability[0].TimeZone == utils.DefaultTimeZone
+		})).Return(nil).Once()
+		mockEventRepo.On("StartPolling", ctx, tx, int64(2)).Return(nil).Once()
+
+		result, err := userAvailabilityService.InsertUserAvailabilityBatch(ctx, 2, items)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, result.Stored)
+		assert.Equal(t, 0, result.Failed)
+		for _, item := range result.Items {
+			assert.Equal(t, model.BatchItemStored, item.Status)
+		}
+		mockEventRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+		mockUserAvailRepo.AssertExpectations(t)
+		mock.ExpectCommit()
+	})
 }
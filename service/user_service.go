@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/rahulshewale153/meeting-scheduler-api/repository"
+	"github.com/rahulshewale153/meeting-scheduler-api/utils"
+)
+
+type userService struct {
+	transactionManager repository.TransactionManagerI
+	userRepo           repository.UserRepositoryI
+}
+
+func NewUserService(transactionManager repository.TransactionManagerI, userRepo repository.UserRepositoryI) UserServiceI {
+	return &userService{
+		transactionManager: transactionManager,
+		userRepo:           userRepo,
+	}
+}
+
+// GetUserProfile returns the profile of a user, users who never saved one get the default time zone
+func (s *userService) GetUserProfile(ctx context.Context, userID int64) (model.UserProfile, error) {
+	profile, err := s.userRepo.GetUserProfile(ctx, userID)
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return model.UserProfile{UserID: userID, TimeZone: utils.DefaultTimeZone}, nil
+		}
+		log.Println("Error retrieving user profile:", err)
+		return model.UserProfile{}, err
+	}
+	return profile, nil
+}
+
+// UpdateUserProfile creates or replaces the profile of a user together with their working hours
+func (s *userService) UpdateUserProfile(ctx context.Context, profile model.UserProfile) error {
+	if err := validateUserProfile(profile); err != nil {
+		return err
+	}
+	if err := authorizeProfileChange(ctx, profile.UserID); err != nil {
+		return err
+	}
+
+	tx, err := s.transactionManager.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	// Ensure that the transaction is rolled back or committed properly
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				log.Println("Error rolling back transaction:", rollbackErr)
+			}
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			log.Println("Error committing transaction:", commitErr)
+			return
+		}
+	}()
+
+	if err = s.userRepo.UpsertUserProfile(ctx, tx, profile); err != nil {
+		log.Println("Error updating user profile:", err)
+		return err
+	}
+	// the profile is replaced as a whole, leaving out working_hours clears them
+	if err = s.userRepo.ReplaceWorkingHours(ctx, tx, profile.UserID, profile.WorkingHours); err != nil {
+		log.Println("Error updating working hours:", err)
+		return err
+	}
+	return nil
+}
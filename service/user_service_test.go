@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	mock_repository "github.com/rahulshewale153/meeting-scheduler-api/mock/repository"
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/rahulshewale153/meeting-scheduler-api/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUserProfile(t *testing.T) {
+	mockUserRepo := new(mock_repository.MockUserRepository)
+	userService := NewUserService(nil, mockUserRepo)
+	ctx := context.Background()
+	userID := int64(1)
+
+	t.Run("Function must return an error when the read operation fails", func(t *testing.T) {
+		mockUserRepo.On("GetUserProfile", ctx, userID).Return(model.UserProfile{}, assert.AnError).Once()
+
+		_, err := userService.GetUserProfile(ctx, userID)
+		assert.Error(t, err)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("Function must return the default time zone when the user has no profile", func(t *testing.T) {
+		mockUserRepo.On("GetUserProfile", ctx, userID).Return(model.UserProfile{}, &model.NotFoundError{Resource: "user", ID: userID}).Once()
+
+		profile, err := userService.GetUserProfile(ctx, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, model.UserProfile{UserID: userID, TimeZone: "UTC"}, profile)
+	})
+
+	t.Run("Function must return the stored profile", func(t *testing.T) {
+		mockUserRepo.On("GetUserProfile", ctx, userID).Return(model.UserProfile{UserID: userID, TimeZone: "Asia/Kolkata"}, nil).Once()
+
+		profile, err := userService.GetUserProfile(ctx, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Asia/Kolkata", profile.TimeZone)
+	})
+}
+
+func TestUpdateUserProfile(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// Begin a mock transaction
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	mockUserRepo := new(mock_repository.MockUserRepository)
+	mockTransactionManager := new(mock_repository.MockTransactionManager)
+	userService := NewUserService(mockTransactionManager, mockUserRepo)
+	ctx := context.Background()
+	profile := model.UserProfile{
+		UserID:       1,
+		TimeZone:     "Asia/Kolkata",
+		WorkingHours: []model.WorkingHours{{Weekday: time.Monday, Start: "09:00", End: "17:00"}},
+	}
+
+	t.Run("Function must return a validation error for an unknown time zone", func(t *testing.T) {
+		err := userService.UpdateUserProfile(ctx, model.UserProfile{UserID: 1, TimeZone: "Asia/Atlantis"})
+		var validationErr *utils.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+
+	t.Run("Function must return a forbidden error when the caller changes the profile of another user", func(t *testing.T) {
+		err := userService.UpdateUserProfile(attendee, profile)
+		assert.ErrorIs(t, err, model.ErrForbidden)
+		mockTransactionManager.AssertExpectations(t)
+	})
+
+	t.Run("Function must return an error when the transaction cannot be started", func(t *testing.T) {
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, assert.AnError).Once()
+		err := userService.UpdateUserProfile(ctx, profile)
+		assert.Error(t, err)
+		mockTransactionManager.AssertExpectations(t)
+	})
+
+	t.Run("Function must return an error when the write operation fails", func(t *testing.T) {
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockUserRepo.On("UpsertUserProfile", ctx, tx, profile).Return(assert.AnError).Once()
+
+		err := userService.UpdateUserProfile(ctx, profile)
+		assert.Error(t, err)
+		mockUserRepo.AssertExpectations(t)
+		mock.ExpectRollback()
+	})
+
+	t.Run("Function must return an error when the working hours cannot be stored", func(t *testing.T) {
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockUserRepo.On("UpsertUserProfile", ctx, tx, profile).Return(nil).Once()
+		mockUserRepo.On("ReplaceWorkingHours", ctx, tx, profile.UserID, profile.WorkingHours).Return(assert.AnError).Once()
+
+		err := userService.UpdateUserProfile(ctx, profile)
+		assert.Error(t, err)
+		mockUserRepo.AssertExpectations(t)
+		mock.ExpectRollback()
+	})
+
+	t.Run("Function must return nil when the write operation is successful", func(t *testing.T) {
+		mockTransactionManager.On("BeginTransaction", ctx).Return(tx, nil).Once()
+		mockUserRepo.On("UpsertUserProfile", ctx, tx, profile).Return(nil).Once()
+		mockUserRepo.On("ReplaceWorkingHours", ctx, tx, profile.UserID, profile.WorkingHours).Return(nil).Once()
+
+		err := userService.UpdateUserProfile(ctx, profile)
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+		mock.ExpectCommit()
+	})
+}
@@ -0,0 +1,302 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/rahulshewale153/meeting-scheduler-api/utils"
+)
+
+// now is the clock used by the validation rules, replaced in tests
+var now = time.Now
+
+// validateEventRequest checks the rules that struct tags cannot express:
+// a positive duration, well ordered slots long enough for the meeting, no duplicate or
+// overlapping slots, no slot starting in the past and no attendee listed twice.
+func validateEventRequest(req model.EventRequest) error {
+	validationErr := &utils.ValidationError{}
+	if req.DurationMinutes <= 0 {
+		validationErr.Add("duration_minutes", req.DurationMinutes, "duration_minutes must be greater than zero")
+	}
+	validateTimeZone(validationErr, "time_zone", req.TimeZone)
+	if req.RRule != "" {
+		if _, err := utils.ParseRRule(req.RRule); err != nil {
+			validationErr.Add("rrule", req.RRule, "rrule is invalid: "+err.Error())
+		}
+	}
+
+	duration := time.Duration(req.DurationMinutes) * time.Minute
+	validateSlots(validationErr, "proposed_slots", req.ProposedSlots, duration)
+
+	seen := make(map[int64]int)
+	for i, attendee := range req.Attendees {
+		name := fmt.Sprintf("attendees[%d]", i)
+		if first, ok := seen[attendee.UserID]; ok {
+			validationErr.Add(name+".user_id", attendee.UserID, fmt.Sprintf("%s.user_id duplicates attendees[%d]", name, first))
+			continue
+		}
+		seen[attendee.UserID] = i
+		if attendee.Weight < 0 {
+			validationErr.Add(name+".weight", attendee.Weight, name+".weight must not be negative")
+		}
+	}
+	return validationErr.OrNil()
+}
+
+// validateEventException checks that the event recurs, that the occurrence is named by a YYYY-MM-DD date and that
+// a moved occurrence ends after it starts and does not start in the past
+func validateEventException(event model.Event, exception model.EventException) error {
+	validationErr := &utils.ValidationError{}
+	if event.RRule == "" {
+		validationErr.Add("rrule", event.RRule, "event must have an rrule to have exceptions")
+	}
+	if _, err := time.Parse(time.DateOnly, exception.OccurrenceDate); err != nil {
+		validationErr.Add("occurrence_date", exception.OccurrenceDate, "occurrence_date must be a date such as 2025-07-21")
+	}
+	if !exception.Cancelled {
+		if !exception.EndTime.After(exception.StartTime) {
+			validationErr.Add("end_time", exception.EndTime, "end_time must be after start_time")
+		} else if exception.StartTime.Before(now()) {
+			validationErr.Add("start_time", exception.StartTime, "start_time must not be in the past")
+		}
+	}
+	return validationErr.OrNil()
+}
+
+// validateConfirmedSlot checks that a slot chosen by the organizer lasts exactly the duration of the event,
+// lies inside one of the proposed slots and does not start in the past
+func validateConfirmedSlot(event model.Event, proposedSlots []model.EventSlot, slot model.EventSlot) error {
+	validationErr := &utils.ValidationError{}
+	validateTimeZone(validationErr, "time_zone", slot.TimeZone)
+	duration := time.Duration(event.DurationMinutes) * time.Minute
+	if slot.EndTime.Sub(slot.StartTime) != duration {
+		validationErr.Add("end_time", slot.EndTime, fmt.Sprintf("slot must last %d minutes", event.DurationMinutes))
+		return validationErr
+	}
+	if slot.StartTime.Before(now()) {
+		validationErr.Add("start_time", slot.StartTime, "start_time must not be in the past")
+	}
+	inside := false
+	for _, proposed := range proposedSlots {
+		if !slot.StartTime.Before(proposed.StartTime) && !slot.EndTime.After(proposed.EndTime) {
+			inside = true
+			break
+		}
+	}
+	if !inside {
+		validationErr.Add("start_time", slot.StartTime, "slot must lie inside one of the proposed slots")
+	}
+	return validationErr.OrNil()
+}
+
+// validateUserAvailability applies the slot rules to the availability submitted by a user, recurring rules must
+// parse and have a first occurrence that ends after it starts, it may lie in the past
+func validateUserAvailability(userAvailability model.UserAvailability) error {
+	validationErr := &utils.ValidationError{}
+	validateTimeZone(validationErr, "time_zone", userAvailability.TimeZone)
+	validateSlots(validationErr, "availability", userAvailability.Availability, 0)
+	for i, rule := range userAvailability.Rules {
+		name := fmt.Sprintf("rules[%d]", i)
+		validateTimeZone(validationErr, name+".time_zone", rule.TimeZone)
+		if !rule.EndTime.After(rule.StartTime) {
+			validationErr.Add(name+".end_time", rule.EndTime, name+".end_time must be after start_time")
+		}
+		if _, err := utils.ParseRRule(rule.RRule); err != nil {
+			validationErr.Add(name+".rrule", rule.RRule, name+".rrule is invalid: "+err.Error())
+		}
+	}
+	return validationErr.OrNil()
+}
+
+// validateAvailabilityBatchItem checks an item of an availability batch like a single submission and that its user
+// did not appear in an earlier item, seen holds the index of the first item of each user
+func validateAvailabilityBatchItem(item model.UserAvailability, seen map[int64]int) error {
+	if errs, ok := utils.IsValid(item); !ok {
+		return &utils.ValidationError{Errors: errs}
+	}
+	if first, ok := seen[item.UserID]; ok {
+		validationErr := &utils.ValidationError{}
+		validationErr.Add("user_id", item.UserID, fmt.Sprintf("user_id %d is already in items[%d]", item.UserID, first))
+		return validationErr
+	}
+	return validateUserAvailability(item)
+}
+
+// validateUserProfile checks that the zone of the user is a known IANA zone and that the working hours
+// are well formed spans of a weekday that do not overlap
+func validateUserProfile(profile model.UserProfile) error {
+	validationErr := &utils.ValidationError{}
+	validateTimeZone(validationErr, "time_zone", profile.TimeZone)
+
+	spans := make(map[time.Weekday][][3]int)
+	for i, hours := range profile.WorkingHours {
+		name := fmt.Sprintf("working_hours[%d]", i)
+		if hours.Weekday < time.Sunday || hours.Weekday > time.Saturday {
+			validationErr.Add(name+".weekday", hours.Weekday, name+".weekday must be between 0 (Sunday) and 6 (Saturday)")
+			continue
+		}
+		start, err := utils.ParseClock(hours.Start)
+		if err != nil || start >= 24*60 {
+			validationErr.Add(name+".start", hours.Start, name+".start must be a time between 00:00 and 23:59")
+			continue
+		}
+		end, err := utils.ParseClock(hours.End)
+		if err != nil {
+			validationErr.Add(name+".end", hours.End, name+".end must be a time between 00:00 and 24:00")
+			continue
+		}
+		if end <= start {
+			validationErr.Add(name+".end", hours.End, name+".end must be after start")
+			continue
+		}
+		for _, span := range spans[hours.Weekday] {
+			if start < span[1] && span[0] < end {
+				validationErr.Add(name, hours, fmt.Sprintf("%s overlaps working_hours[%d]", name, span[2]))
+				break
+			}
+		}
+		spans[hours.Weekday] = append(spans[hours.Weekday], [3]int{start, end, i})
+	}
+	return validationErr.OrNil()
+}
+
+// validateResource checks that a room holds someone and that features are named and listed once
+func validateResource(resource model.Resource) error {
+	validationErr := &utils.ValidationError{}
+	if resource.Kind == model.ResourceKindRoom && resource.Capacity <= 0 {
+		validationErr.Add("capacity", resource.Capacity, "capacity of a room must be greater than zero")
+	}
+	validateFeatures(validationErr, resource.Features)
+	return validationErr.OrNil()
+}
+
+// validateResourceRequirement checks that a requirement names either a resource or the kind of resource it needs
+func validateResourceRequirement(requirement model.ResourceRequirement) error {
+	validationErr := &utils.ValidationError{}
+	switch {
+	case requirement.ResourceID == 0 && requirement.Kind == "":
+		validationErr.Add("kind", requirement.Kind, "resource_id or kind is required")
+	case requirement.ResourceID != 0 && (requirement.Kind != "" || requirement.MinCapacity != 0 || len(requirement.Features) > 0):
+		validationErr.Add("resource_id", requirement.ResourceID, "resource_id cannot be combined with kind, min_capacity or features")
+	}
+	if requirement.MinCapacity < 0 {
+		validationErr.Add("min_capacity", requirement.MinCapacity, "min_capacity must not be negative")
+	}
+	validateFeatures(validationErr, requirement.Features)
+	return validationErr.OrNil()
+}
+
+// validateResourceWindows checks the bookable windows of a resource, unlike proposed slots they may overlap, they
+// are merged, and may have started already
+func validateResourceWindows(windows []model.EventSlot) error {
+	validationErr := &utils.ValidationError{}
+	for i, window := range windows {
+		name := fmt.Sprintf("windows[%d]", i)
+		validateTimeZone(validationErr, name+".time_zone", window.TimeZone)
+		if !window.EndTime.After(window.StartTime) {
+			validationErr.Add(name+".end_time", window.EndTime, name+".end_time must be after start_time")
+		}
+	}
+	return validationErr.OrNil()
+}
+
+func validateFeatures(validationErr *utils.ValidationError, features []string) {
+	seen := make(map[string]int)
+	for i, feature := range features {
+		name := fmt.Sprintf("features[%d]", i)
+		if feature == "" {
+			validationErr.Add(name, feature, name+" must not be empty")
+			continue
+		}
+		if first, ok := seen[feature]; ok {
+			validationErr.Add(name, feature, fmt.Sprintf("%s duplicates features[%d]", name, first))
+			continue
+		}
+		seen[feature] = i
+	}
+}
+
+// validateTimeZone records an error when name is not a known IANA zone, empty names fall back to the default
+func validateTimeZone(validationErr *utils.ValidationError, fieldName string, name string) {
+	if _, err := utils.LoadTimeZone(name); err != nil {
+		validationErr.Add(fieldName, name, fieldName+" must be a valid IANA time zone")
+	}
+}
+
+func validateSlots(validationErr *utils.ValidationError, fieldName string, slots []model.EventSlot, minDuration time.Duration) {
+	current := now()
+	for i, slot := range slots {
+		name := fmt.Sprintf("%s[%d]", fieldName, i)
+		validateTimeZone(validationErr, name+".time_zone", slot.TimeZone)
+		if !slot.EndTime.After(slot.StartTime) {
+			validationErr.Add(name+".end_time", slot.EndTime, name+".end_time must be after start_time")
+			continue
+		}
+		if minDuration > 0 && slot.EndTime.Sub(slot.StartTime) < minDuration {
+			validationErr.Add(name, slot, fmt.Sprintf("%s must be at least %d minutes long", name, int(minDuration.Minutes())))
+		}
+		if slot.StartTime.Before(current) {
+			validationErr.Add(name+".start_time", slot.StartTime, name+".start_time must not be in the past")
+		}
+	}
+
+	// walk the slots by start time, a slot overlaps when it starts before the latest end seen so far
+	order := make([]int, 0, len(slots))
+	for i, slot := range slots {
+		if slot.EndTime.After(slot.StartTime) {
+			order = append(order, i)
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return slots[order[a]].StartTime.Before(slots[order[b]].StartTime)
+	})
+	if len(order) == 0 {
+		return
+	}
+	latest := order[0]
+	for _, i := range order[1:] {
+		prev, cur := slots[latest], slots[i]
+		if cur.StartTime.Before(prev.EndTime) {
+			name := fmt.Sprintf("%s[%d]", fieldName, i)
+			if cur.StartTime.Equal(prev.StartTime) && cur.EndTime.Equal(prev.EndTime) {
+				validationErr.Add(name, cur, fmt.Sprintf("%s duplicates %s[%d]", name, fieldName, latest))
+			} else {
+				validationErr.Add(name, cur, fmt.Sprintf("%s overlaps %s[%d]", name, fieldName, latest))
+			}
+		}
+		if cur.EndTime.After(prev.EndTime) {
+			latest = i
+		}
+	}
+}
+
+// validateRecommendationOptions checks the requested tie-break, empty means earliest, and the filter bounds
+func validateRecommendationOptions(options model.RecommendationOptions) error {
+	validationErr := &utils.ValidationError{}
+	switch options.TieBreak {
+	case "", model.TieBreakEarliest, model.TieBreakLatest, model.TieBreakMostAvailable:
+	default:
+		validationErr.Add("tie_break", options.TieBreak, "tie_break must be one of earliest, latest, most_available")
+	}
+	if options.Limit < 0 {
+		validationErr.Add("limit", options.Limit, "limit must not be negative")
+	}
+	if options.MinAvailable < 0 {
+		validationErr.Add("min_available", options.MinAvailable, "min_available must not be negative")
+	}
+	if !options.From.IsZero() && !options.To.IsZero() && !options.To.After(options.From) {
+		validationErr.Add("to", options.To, "to must be after from")
+	}
+	// no occurrences judges a recurring event on the default number
+	if options.Occurrences < 0 || options.Occurrences > maxSeriesOccurrences {
+		validationErr.Add("occurrences", options.Occurrences, fmt.Sprintf("occurrences must be between 1 and %d, or 0 for the default of %d", maxSeriesOccurrences, defaultSeriesOccurrences))
+	}
+	switch options.WorkingHours {
+	case "", model.WorkingHoursMark, model.WorkingHoursExclude:
+	default:
+		validationErr.Add("working_hours", options.WorkingHours, "working_hours must be one of mark, exclude")
+	}
+	return validationErr.OrNil()
+}
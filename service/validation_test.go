@@ -0,0 +1,250 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/rahulshewale153/meeting-scheduler-api/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func slotAt(startHour, startMinute, endHour, endMinute int) model.EventSlot {
+	return model.EventSlot{
+		StartTime: time.Date(2025, 07, 12, startHour, startMinute, 0, 0, time.UTC),
+		EndTime:   time.Date(2025, 07, 12, endHour, endMinute, 0, 0, time.UTC),
+	}
+}
+
+func TestValidateEventRequest(t *testing.T) {
+	testCases := []struct {
+		name          string
+		duration      int
+		slots         []model.EventSlot
+		invalidFields []string
+	}{
+		{"valid request", 60, []model.EventSlot{slotAt(10, 0, 11, 0), slotAt(11, 0, 12, 30)}, nil},
+		{"negative duration", -30, []model.EventSlot{slotAt(10, 0, 11, 0)}, []string{"duration_minutes"}},
+		{"end before start", 30, []model.EventSlot{slotAt(11, 0, 10, 0)}, []string{"proposed_slots[0].end_time"}},
+		{"slot shorter than duration", 90, []model.EventSlot{slotAt(10, 0, 11, 0)}, []string{"proposed_slots[0]"}},
+		{"duplicate slots", 30, []model.EventSlot{slotAt(10, 0, 11, 0), slotAt(10, 0, 11, 0)}, []string{"proposed_slots[1]"}},
+		{"overlapping slots", 30, []model.EventSlot{slotAt(10, 30, 11, 30), slotAt(10, 0, 11, 0)}, []string{"proposed_slots[0]"}},
+		{"slot overlapping an earlier long slot", 30, []model.EventSlot{slotAt(9, 0, 12, 0), slotAt(9, 30, 10, 0), slotAt(11, 0, 11, 30)}, []string{"proposed_slots[1]", "proposed_slots[2]"}},
+		{"slot in the past", 30, []model.EventSlot{{StartTime: time.Date(2025, 06, 30, 10, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 06, 30, 11, 0, 0, 0, time.UTC)}}, []string{"proposed_slots[0].start_time"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateEventRequest(model.EventRequest{
+				Event:         model.Event{Title: "Test Event", OrganizerID: 1, DurationMinutes: tc.duration},
+				ProposedSlots: tc.slots,
+			})
+			if tc.invalidFields == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			var validationErr *utils.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			fieldNames := []string{}
+			for _, field := range validationErr.Errors.Field {
+				fieldNames = append(fieldNames, field.Name)
+			}
+			assert.Equal(t, tc.invalidFields, fieldNames)
+			assert.Len(t, validationErr.Errors.InputValue, len(tc.invalidFields))
+		})
+	}
+}
+
+func TestValidateEventAttendees(t *testing.T) {
+	err := validateEventRequest(model.EventRequest{
+		Event:         model.Event{Title: "Test Event", OrganizerID: 1, DurationMinutes: 30},
+		ProposedSlots: []model.EventSlot{slotAt(10, 0, 11, 0)},
+		Attendees:     []model.Attendee{{UserID: 2}, {UserID: 3, Weight: -1}, {UserID: 2, Required: true}},
+	})
+
+	var validationErr *utils.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "attendees[1].weight", validationErr.Errors.Field[0].Name)
+	assert.Equal(t, "attendees[2].user_id", validationErr.Errors.Field[1].Name)
+}
+
+func TestValidateTimeZones(t *testing.T) {
+	slot := slotAt(10, 0, 11, 0)
+	slot.TimeZone = "Mars/Olympus_Mons"
+	err := validateEventRequest(model.EventRequest{
+		Event:         model.Event{Title: "Test Event", OrganizerID: 1, DurationMinutes: 30, TimeZone: "Local"},
+		ProposedSlots: []model.EventSlot{slot},
+	})
+
+	var validationErr *utils.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "time_zone", validationErr.Errors.Field[0].Name)
+	assert.Equal(t, "proposed_slots[0].time_zone", validationErr.Errors.Field[1].Name)
+
+	assert.NoError(t, validateUserProfile(model.UserProfile{UserID: 1, TimeZone: "Asia/Kolkata"}))
+	assert.Error(t, validateUserProfile(model.UserProfile{UserID: 1, TimeZone: "IST"}))
+}
+
+func TestValidateUserAvailability(t *testing.T) {
+	t.Run("Function must accept back to back slots", func(t *testing.T) {
+		err := validateUserAvailability(model.UserAvailability{Availability: []model.EventSlot{slotAt(10, 0, 11, 0), slotAt(11, 0, 11, 15)}})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Function must reject overlapping slots", func(t *testing.T) {
+		err := validateUserAvailability(model.UserAvailability{Availability: []model.EventSlot{slotAt(10, 0, 11, 0), slotAt(10, 45, 11, 15)}})
+		var validationErr *utils.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "availability[1]", validationErr.Errors.Field[0].Name)
+	})
+}
+
+func TestValidateWorkingHours(t *testing.T) {
+	t.Run("Function must accept split shifts and hours running to the end of the day", func(t *testing.T) {
+		err := validateUserProfile(model.UserProfile{UserID: 1, TimeZone: "Asia/Kolkata", WorkingHours: []model.WorkingHours{
+			{Weekday: time.Monday, Start: "09:00", End: "12:00"},
+			{Weekday: time.Monday, Start: "12:00", End: "17:30"},
+			{Weekday: time.Friday, Start: "20:00", End: "24:00"},
+		}})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Function must reject malformed and overlapping working hours", func(t *testing.T) {
+		err := validateUserProfile(model.UserProfile{UserID: 1, TimeZone: "Asia/Kolkata", WorkingHours: []model.WorkingHours{
+			{Weekday: 7, Start: "09:00", End: "17:00"},
+			{Weekday: time.Monday, Start: "9am", End: "17:00"},
+			{Weekday: time.Monday, Start: "17:00", End: "09:00"},
+			{Weekday: time.Tuesday, Start: "09:00", End: "17:00"},
+			{Weekday: time.Tuesday, Start: "16:00", End: "18:00"},
+		}})
+		var validationErr *utils.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		names := []string{}
+		for _, field := range validationErr.Errors.Field {
+			names = append(names, field.Name)
+		}
+		assert.Equal(t, []string{"working_hours[0].weekday", "working_hours[1].start", "working_hours[2].end", "working_hours[4]"}, names)
+	})
+}
+
+func TestValidateAvailabilityRules(t *testing.T) {
+	start := time.Date(2025, 01, 06, 10, 0, 0, 0, time.UTC)
+	err := validateUserAvailability(model.UserAvailability{Rules: []model.AvailabilityRule{
+		{StartTime: start, EndTime: start.Add(2 * time.Hour), RRule: "FREQ=WEEKLY;BYDAY=MO,WE"},
+		{StartTime: start, EndTime: start, TimeZone: "Asia/Kolkata", RRule: "FREQ=DAILY"},
+		{StartTime: start, EndTime: start.Add(time.Hour), RRule: "FREQ=YEARLY"},
+		{StartTime: start, EndTime: start.Add(time.Hour), RRule: "FREQ=WEEKLY;BYDAY=1MO"},
+		{StartTime: start, EndTime: start.Add(time.Hour), RRule: "FREQ=DAILY;COUNT=2;UNTIL=20250201"},
+	}})
+
+	var validationErr *utils.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	names := []string{}
+	for _, field := range validationErr.Errors.Field {
+		names = append(names, field.Name)
+	}
+	assert.Equal(t, []string{"rules[1].end_time", "rules[2].rrule", "rules[3].rrule", "rules[4].rrule"}, names)
+}
+
+func TestValidateEventException(t *testing.T) {
+	start := time.Date(2025, 07, 22, 10, 0, 0, 0, time.UTC)
+	recurring := model.Event{RRule: "FREQ=WEEKLY"}
+	testCases := []struct {
+		name          string
+		event         model.Event
+		exception     model.EventException
+		invalidFields []string
+	}{
+		{"cancelled occurrence", recurring, model.EventException{OccurrenceDate: "2025-07-21", Cancelled: true}, nil},
+		{"moved occurrence", recurring, model.EventException{OccurrenceDate: "2025-07-21", StartTime: start, EndTime: start.Add(time.Hour)}, nil},
+		{"one-off event", model.Event{}, model.EventException{OccurrenceDate: "2025-07-21", Cancelled: true}, []string{"rrule"}},
+		{"malformed date", recurring, model.EventException{OccurrenceDate: "21/07/2025", Cancelled: true}, []string{"occurrence_date"}},
+		{"moved without times", recurring, model.EventException{OccurrenceDate: "2025-07-21"}, []string{"end_time"}},
+		{"moved into the past", recurring, model.EventException{OccurrenceDate: "2025-07-21", StartTime: start.AddDate(0, -1, 0), EndTime: start.AddDate(0, -1, 0).Add(time.Hour)}, []string{"start_time"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateEventException(tc.event, tc.exception)
+			if tc.invalidFields == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			var validationErr *utils.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			fieldNames := []string{}
+			for _, field := range validationErr.Errors.Field {
+				fieldNames = append(fieldNames, field.Name)
+			}
+			assert.Equal(t, tc.invalidFields, fieldNames)
+		})
+	}
+}
+
+func TestValidateConfirmedSlot(t *testing.T) {
+	event := model.Event{DurationMinutes: 60}
+	proposed := []model.EventSlot{slotAt(9, 0, 11, 0), slotAt(14, 0, 15, 0)}
+	testCases := []struct {
+		name          string
+		slot          model.EventSlot
+		invalidFields []string
+	}{
+		{"inside a proposed slot", slotAt(9, 30, 10, 30), nil},
+		{"exactly a proposed slot", slotAt(14, 0, 15, 0), nil},
+		{"wrong length", slotAt(9, 0, 9, 30), []string{"end_time"}},
+		{"across two proposed slots", slotAt(10, 30, 11, 30), []string{"start_time"}},
+		{"in the past", model.EventSlot{StartTime: time.Date(2025, 06, 30, 10, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 06, 30, 11, 0, 0, 0, time.UTC)}, []string{"start_time", "start_time"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateConfirmedSlot(event, proposed, tc.slot)
+			if tc.invalidFields == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			var validationErr *utils.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			fieldNames := []string{}
+			for _, field := range validationErr.Errors.Field {
+				fieldNames = append(fieldNames, field.Name)
+			}
+			assert.Equal(t, tc.invalidFields, fieldNames)
+		})
+	}
+}
+
+func TestValidateResourceRequirement(t *testing.T) {
+	testCases := []struct {
+		name          string
+		requirement   model.ResourceRequirement
+		invalidFields []string
+	}{
+		{"a specific resource", model.ResourceRequirement{ResourceID: 4}, nil},
+		{"a kind of resource", model.ResourceRequirement{Kind: model.ResourceKindRoom, MinCapacity: 6, Features: []string{"projector"}}, nil},
+		{"neither resource nor kind", model.ResourceRequirement{MinCapacity: 6}, []string{"kind"}},
+		{"a resource with a capacity", model.ResourceRequirement{ResourceID: 4, MinCapacity: 6}, []string{"resource_id"}},
+		{"a duplicated feature", model.ResourceRequirement{Kind: model.ResourceKindRoom, Features: []string{"projector", "projector"}}, []string{"features[1]"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateResourceRequirement(tc.requirement)
+			if tc.invalidFields == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			var validationErr *utils.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			fieldNames := []string{}
+			for _, field := range validationErr.Errors.Field {
+				fieldNames = append(fieldNames, field.Name)
+			}
+			assert.Equal(t, tc.invalidFields, fieldNames)
+		})
+	}
+}
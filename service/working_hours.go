@@ -0,0 +1,69 @@
+package service
+
+import (
+	"log"
+	"time"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+	"github.com/rahulshewale153/meeting-scheduler-api/utils"
+)
+
+// workingSchedule holds the working hours of a user as minutes after midnight per weekday, on the wall clock of loc
+type workingSchedule struct {
+	loc   *time.Location
+	spans map[time.Weekday][][2]int
+}
+
+// newWorkingSchedule builds the schedule of a profile, ok is false when the user has no working hours
+func newWorkingSchedule(profile model.UserProfile) (schedule workingSchedule, ok bool) {
+	if len(profile.WorkingHours) == 0 {
+		return schedule, false
+	}
+	loc, err := utils.LoadTimeZone(profile.TimeZone)
+	if err != nil {
+		log.Println("Error loading user time zone:", err)
+		loc = time.UTC
+	}
+	schedule = workingSchedule{loc: loc, spans: make(map[time.Weekday][][2]int)}
+	for _, hours := range profile.WorkingHours {
+		start, err := utils.ParseClock(hours.Start)
+		if err != nil {
+			continue
+		}
+		end, err := utils.ParseClock(hours.End)
+		if err != nil {
+			continue
+		}
+		schedule.spans[hours.Weekday] = append(schedule.spans[hours.Weekday], [2]int{start, end})
+	}
+	return schedule, true
+}
+
+// covers reports whether the window lies inside one span of working hours. A window that crosses local
+// midnight is only covered when it ends exactly at midnight and the span runs to 24:00
+func (w workingSchedule) covers(start, end time.Time) bool {
+	localStart, localEnd := start.In(w.loc), end.In(w.loc)
+	startMinute := localStart.Hour()*60 + localStart.Minute()
+
+	var endMinute int
+	sy, sm, sd := localStart.Date()
+	ey, em, ed := localEnd.Date()
+	switch {
+	case sy == ey && sm == em && sd == ed:
+		endMinute = localEnd.Hour()*60 + localEnd.Minute()
+		if localEnd.Second() > 0 || localEnd.Nanosecond() > 0 {
+			endMinute++
+		}
+	case localEnd.Equal(time.Date(sy, sm, sd+1, 0, 0, 0, 0, w.loc)):
+		endMinute = 24 * 60
+	default:
+		return false
+	}
+
+	for _, span := range w.spans[localStart.Weekday()] {
+		if span[0] <= startMinute && endMinute <= span[1] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,255 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// VEVENT statuses
+const (
+	ICalStatusTentative = "TENTATIVE"
+	ICalStatusConfirmed = "CONFIRMED"
+	ICalStatusCancelled = "CANCELLED"
+)
+
+const (
+	icalUTCFormat   = "20060102T150405Z"
+	icalLocalFormat = "20060102T150405"
+	// icalLineOctets is the longest content line allowed before it is folded
+	icalLineOctets = 75
+	// icalZoneYears is how far ahead of the first event the VTIMEZONE observances reach
+	icalZoneYears = 5
+)
+
+// ICalEvent is one VEVENT. Times are written in UTC unless TimeZone is set, a recurring event needs its zone so that
+// its occurrences keep their wall clock time across daylight saving changes
+type ICalEvent struct {
+	UID     string
+	Stamp   time.Time
+	Summary string
+	Start   time.Time
+	End     time.Time
+	// RecurrenceID is set on a VEVENT that overrides one occurrence of a recurring event, it is the original start
+	RecurrenceID time.Time
+	TimeZone     *time.Location
+	Status       string
+	// Sequence is the revision of the event, it is raised when the event is cancelled so that calendars apply the change
+	Sequence int
+	// Transparent events do not make their attendees busy
+	Transparent bool
+	Organizer   ICalAttendee
+	Attendees   []ICalAttendee
+	RRule       string
+	ExDates     []time.Time
+}
+
+// ICalAttendee is a calendar user, Address is a URI such as mailto:someone@example.com
+type ICalAttendee struct {
+	Address  string
+	Name     string
+	Required bool
+}
+
+// WriteICalendar renders the events as an RFC 5545 VCALENDAR with CRLF line endings and lines folded at 75 octets
+func WriteICalendar(prodID string, events []ICalEvent) []byte {
+	w := &icalWriter{}
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", prodID)
+	w.line("CALSCALE", "GREGORIAN")
+
+	for _, zone := range icalZones(events) {
+		w.timeZone(zone.loc, zone.from)
+	}
+	for _, event := range events {
+		w.event(event)
+	}
+
+	w.line("END", "VCALENDAR")
+	return w.buf.Bytes()
+}
+
+type icalZone struct {
+	loc  *time.Location
+	from time.Time
+}
+
+// icalZones returns the zones the events are written in, with the earliest time each of them is needed from
+func icalZones(events []ICalEvent) []icalZone {
+	first := make(map[string]icalZone)
+	for _, event := range events {
+		if !icalZoned(event.TimeZone) {
+			continue
+		}
+		from := event.Start
+		if !event.RecurrenceID.IsZero() && event.RecurrenceID.Before(from) {
+			from = event.RecurrenceID
+		}
+		zone, ok := first[event.TimeZone.String()]
+		if !ok || from.Before(zone.from) {
+			first[event.TimeZone.String()] = icalZone{loc: event.TimeZone, from: from}
+		}
+	}
+
+	zones := make([]icalZone, 0, len(first))
+	for _, zone := range first {
+		zones = append(zones, zone)
+	}
+	sort.Slice(zones, func(i, j int) bool { return zones[i].loc.String() < zones[j].loc.String() })
+	return zones
+}
+
+func icalZoned(loc *time.Location) bool {
+	return loc != nil && loc != time.UTC && loc.String() != "UTC"
+}
+
+type icalWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *icalWriter) event(event ICalEvent) {
+	w.line("BEGIN", "VEVENT")
+	w.line("UID", event.UID)
+	w.line("DTSTAMP", event.Stamp.UTC().Format(icalUTCFormat))
+	w.line("SEQUENCE", strconv.Itoa(event.Sequence))
+	w.time("DTSTART", event.Start, event.TimeZone)
+	w.time("DTEND", event.End, event.TimeZone)
+	if !event.RecurrenceID.IsZero() {
+		w.time("RECURRENCE-ID", event.RecurrenceID, event.TimeZone)
+	}
+	if event.RRule != "" {
+		w.line("RRULE", strings.TrimPrefix(event.RRule, "RRULE:"))
+	}
+	for _, exDate := range event.ExDates {
+		w.time("EXDATE", exDate, event.TimeZone)
+	}
+	w.line("SUMMARY", escapeICalText(event.Summary))
+	if event.Status != "" {
+		w.line("STATUS", event.Status)
+	}
+	if event.Transparent {
+		w.line("TRANSP", "TRANSPARENT")
+	} else {
+		w.line("TRANSP", "OPAQUE")
+	}
+	if event.Organizer.Address != "" {
+		w.line("ORGANIZER"+icalNameParam(event.Organizer.Name), event.Organizer.Address)
+	}
+	for _, attendee := range event.Attendees {
+		role := "OPT-PARTICIPANT"
+		if attendee.Required {
+			role = "REQ-PARTICIPANT"
+		}
+		w.line("ATTENDEE;ROLE="+role+icalNameParam(attendee.Name), attendee.Address)
+	}
+	w.line("END", "VEVENT")
+}
+
+// time writes a DATE-TIME property in UTC, or on the wall clock of loc with a TZID parameter
+func (w *icalWriter) time(name string, t time.Time, loc *time.Location) {
+	if !icalZoned(loc) {
+		w.line(name, t.UTC().Format(icalUTCFormat))
+		return
+	}
+	w.line(name+";TZID="+loc.String(), t.In(loc).Format(icalLocalFormat))
+}
+
+// timeZone writes a VTIMEZONE with one observance per offset change of loc within icalZoneYears of from
+func (w *icalWriter) timeZone(loc *time.Location, from time.Time) {
+	w.line("BEGIN", "VTIMEZONE")
+	w.line("TZID", loc.String())
+
+	from = from.In(loc)
+	_, offset := from.Zone()
+	w.observance(from, from, offset, offset)
+
+	to := from.AddDate(icalZoneYears, 0, 0)
+	for day := from; day.Before(to); {
+		next := day.Add(24 * time.Hour)
+		if _, nextOffset := next.Zone(); nextOffset != offset {
+			// narrow the change down to the second it happens
+			before, after := day, next
+			for after.Sub(before) > time.Second {
+				mid := before.Add(after.Sub(before) / 2)
+				if _, midOffset := mid.Zone(); midOffset == offset {
+					before = mid
+				} else {
+					after = mid
+				}
+			}
+			w.observance(after.In(loc), after, offset, nextOffset)
+			offset = nextOffset
+		}
+		day = next
+	}
+
+	w.line("END", "VTIMEZONE")
+}
+
+// observance writes a STANDARD or DAYLIGHT component for an offset change at onset, its DTSTART is the local
+// time of the onset in the offset in effect before it
+func (w *icalWriter) observance(onset time.Time, instant time.Time, offsetFrom int, offsetTo int) {
+	kind := "STANDARD"
+	if onset.IsDST() {
+		kind = "DAYLIGHT"
+	}
+	name, _ := onset.Zone()
+
+	w.line("BEGIN", kind)
+	w.line("DTSTART", instant.UTC().Add(time.Duration(offsetFrom)*time.Second).Format(icalLocalFormat))
+	w.line("TZOFFSETFROM", icalOffset(offsetFrom))
+	w.line("TZOFFSETTO", icalOffset(offsetTo))
+	w.line("TZNAME", escapeICalText(name))
+	w.line("END", kind)
+}
+
+// line writes a content line, folding it with CRLF and a space so that no line exceeds icalLineOctets
+func (w *icalWriter) line(name string, value string) {
+	line := name + ":" + value
+	limit := icalLineOctets
+	for len(line) > limit {
+		cut := limit
+		// never split a multi-byte character
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.buf.WriteString(line[:cut])
+		w.buf.WriteString("\r\n ")
+		line = line[cut:]
+		// continuation lines start with the space, which counts towards the limit
+		limit = icalLineOctets - 1
+	}
+	w.buf.WriteString(line)
+	w.buf.WriteString("\r\n")
+}
+
+func icalOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	offset := fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds%3600/60)
+	if seconds%60 != 0 {
+		offset += fmt.Sprintf("%02d", seconds%60)
+	}
+	return offset
+}
+
+func icalNameParam(name string) string {
+	if name == "" {
+		return ""
+	}
+	// a parameter value cannot hold a double quote, quoting it allows ; , and :
+	return `;CN="` + strings.ReplaceAll(name, `"`, "'") + `"`
+}
+
+// escapeICalText escapes a TEXT value as RFC 5545 section 3.3.11 requires
+func escapeICalText(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(text)
+}
@@ -0,0 +1,302 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+)
+
+// icalProperty is one unfolded content line, parameter names are upper case
+type icalProperty struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+var icalDurationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// ParseICalendarBusy returns the busy time of an iCalendar object: every VEVENT that is neither transparent nor
+// cancelled and every period of a VFREEBUSY that is not FBTYPE=FREE, as far as it overlaps [from, until). Times
+// without a zone are read on the wall clock of floating, TZID parameters must name IANA zones. An override of one
+// occurrence of a recurring event is counted in addition to the occurrence it replaces
+func ParseICalendarBusy(data []byte, floating *time.Location, from time.Time, until time.Time) ([]model.EventSlot, error) {
+	properties, err := parseICalProperties(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	busy := []model.EventSlot{}
+	components := []string{}
+	var event []icalProperty
+	for _, property := range properties {
+		switch property.Name {
+		case "BEGIN":
+			components = append(components, property.Value)
+			if property.Value == "VEVENT" {
+				event = []icalProperty{}
+			}
+			continue
+		case "END":
+			if len(components) == 0 || components[len(components)-1] != property.Value {
+				return nil, fmt.Errorf("unexpected END:%s", property.Value)
+			}
+			components = components[:len(components)-1]
+			if property.Value == "VEVENT" {
+				slots, err := eventBusy(event, floating, from, until)
+				if err != nil {
+					return nil, err
+				}
+				busy = append(busy, slots...)
+			}
+			continue
+		}
+		if len(components) == 0 {
+			continue
+		}
+
+		switch components[len(components)-1] {
+		case "VEVENT":
+			event = append(event, property)
+		case "VFREEBUSY":
+			if property.Name != "FREEBUSY" || strings.EqualFold(property.Params["FBTYPE"], "FREE") {
+				continue
+			}
+			slots, err := parseICalPeriods(property.Value)
+			if err != nil {
+				return nil, err
+			}
+			busy = append(busy, slots...)
+		}
+	}
+	if len(components) != 0 {
+		return nil, fmt.Errorf("%s is not closed", components[len(components)-1])
+	}
+
+	overlapping := []model.EventSlot{}
+	for _, slot := range busy {
+		if slot.EndTime.After(from) && slot.StartTime.Before(until) {
+			overlapping = append(overlapping, slot)
+		}
+	}
+	return overlapping, nil
+}
+
+// eventBusy returns the busy slots of one VEVENT
+func eventBusy(properties []icalProperty, floating *time.Location, from time.Time, until time.Time) ([]model.EventSlot, error) {
+	var start, end *icalProperty
+	var duration, rrule string
+	exDates := []icalProperty{}
+	for i, property := range properties {
+		switch property.Name {
+		case "STATUS":
+			if strings.EqualFold(property.Value, "CANCELLED") {
+				return nil, nil
+			}
+		case "TRANSP":
+			if strings.EqualFold(property.Value, "TRANSPARENT") {
+				return nil, nil
+			}
+		case "DTSTART":
+			start = &properties[i]
+		case "DTEND":
+			end = &properties[i]
+		case "DURATION":
+			duration = property.Value
+		case "RRULE":
+			rrule = property.Value
+		case "EXDATE":
+			exDates = append(exDates, property)
+		}
+	}
+	if start == nil {
+		return nil, fmt.Errorf("VEVENT without DTSTART")
+	}
+
+	startTime, allDay, err := parseICalTime(*start, floating)
+	if err != nil {
+		return nil, err
+	}
+	var endTime time.Time
+	switch {
+	case end != nil:
+		if endTime, _, err = parseICalTime(*end, floating); err != nil {
+			return nil, err
+		}
+	case duration != "":
+		if endTime, err = addICalDuration(startTime, duration); err != nil {
+			return nil, err
+		}
+	case allDay:
+		endTime = startTime.AddDate(0, 0, 1)
+	default:
+		endTime = startTime
+	}
+	if !endTime.After(startTime) {
+		// an instant does not block any time
+		return nil, nil
+	}
+	if rrule == "" {
+		return []model.EventSlot{{StartTime: startTime, EndTime: endTime}}, nil
+	}
+
+	rule, err := ParseRRule(rrule)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported RRULE %s: %v", rrule, err)
+	}
+	excluded := make(map[int64]bool)
+	for _, exDate := range exDates {
+		for _, value := range strings.Split(exDate.Value, ",") {
+			exDate.Value = value
+			t, _, err := parseICalTime(exDate, floating)
+			if err != nil {
+				return nil, err
+			}
+			excluded[t.Unix()] = true
+		}
+	}
+
+	length := endTime.Sub(startTime)
+	slots := []model.EventSlot{}
+	rule.Each(startTime, func(occurrence time.Time) bool {
+		if !occurrence.Before(until) {
+			return false
+		}
+		if !excluded[occurrence.Unix()] && occurrence.Add(length).After(from) {
+			slots = append(slots, model.EventSlot{StartTime: occurrence, EndTime: occurrence.Add(length)})
+		}
+		return true
+	})
+	return slots, nil
+}
+
+// parseICalTime reads a DATE or DATE-TIME value, allDay is set for a DATE
+func parseICalTime(property icalProperty, floating *time.Location) (t time.Time, allDay bool, err error) {
+	loc := floating
+	if tzid, ok := property.Params["TZID"]; ok {
+		if loc, err = LoadTimeZone(tzid); err != nil {
+			return time.Time{}, false, fmt.Errorf("%s has unknown TZID %s", property.Name, tzid)
+		}
+	}
+
+	value := property.Value
+	switch {
+	case strings.EqualFold(property.Params["VALUE"], "DATE") || len(value) == len("20060102"):
+		t, err = time.ParseInLocation("20060102", value, loc)
+		allDay = true
+	case strings.HasSuffix(value, "Z"):
+		t, err = time.Parse(icalUTCFormat, value)
+	default:
+		t, err = time.ParseInLocation(icalLocalFormat, value, loc)
+	}
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("%s has invalid time %s", property.Name, value)
+	}
+	return t, allDay, nil
+}
+
+// parseICalPeriods reads a comma separated list of UTC periods, each start/end or start/duration
+func parseICalPeriods(value string) ([]model.EventSlot, error) {
+	slots := []model.EventSlot{}
+	for _, period := range strings.Split(value, ",") {
+		startValue, endValue, ok := strings.Cut(strings.TrimSpace(period), "/")
+		if !ok {
+			return nil, fmt.Errorf("FREEBUSY has invalid period %s", period)
+		}
+		start, err := time.Parse(icalUTCFormat, startValue)
+		if err != nil {
+			return nil, fmt.Errorf("FREEBUSY has invalid period %s", period)
+		}
+		var end time.Time
+		if strings.HasPrefix(endValue, "P") || strings.HasPrefix(endValue, "+P") {
+			end, err = addICalDuration(start, endValue)
+		} else {
+			end, err = time.Parse(icalUTCFormat, endValue)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("FREEBUSY has invalid period %s", period)
+		}
+		if end.After(start) {
+			slots = append(slots, model.EventSlot{StartTime: start, EndTime: end})
+		}
+	}
+	return slots, nil
+}
+
+// addICalDuration adds an RFC 5545 DURATION to t, weeks and days are nominal and keep the wall clock time
+func addICalDuration(t time.Time, value string) (time.Time, error) {
+	match := icalDurationPattern.FindStringSubmatch(value)
+	if match == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return time.Time{}, fmt.Errorf("invalid DURATION %s", value)
+	}
+	parts := make([]int, 5)
+	for i := range parts {
+		if match[i+2] != "" {
+			parts[i], _ = strconv.Atoi(match[i+2])
+		}
+	}
+	sign := 1
+	if match[1] == "-" {
+		sign = -1
+	}
+	clock := time.Duration(parts[2])*time.Hour + time.Duration(parts[3])*time.Minute + time.Duration(parts[4])*time.Second
+	return t.AddDate(0, 0, sign*(parts[0]*7+parts[1])).Add(time.Duration(sign) * clock), nil
+}
+
+// parseICalProperties unfolds the content lines and splits them into properties
+func parseICalProperties(data string) ([]icalProperty, error) {
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	data = strings.ReplaceAll(data, "\n ", "")
+	data = strings.ReplaceAll(data, "\n\t", "")
+
+	properties := []icalProperty{}
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line == "" {
+			continue
+		}
+		property, ok := parseICalLine(line)
+		if !ok {
+			return nil, fmt.Errorf("line %d is not a content line", i+1)
+		}
+		properties = append(properties, property)
+	}
+	if len(properties) == 0 || properties[0].Name != "BEGIN" || properties[0].Value != "VCALENDAR" {
+		return nil, fmt.Errorf("not an iCalendar object")
+	}
+	return properties, nil
+}
+
+// parseICalLine splits name;param=value;param="quoted:value":value, colons and semicolons inside quotes do not count
+func parseICalLine(line string) (icalProperty, bool) {
+	property := icalProperty{Params: make(map[string]string)}
+	quoted := false
+	fieldStart := 0
+	var fields []string
+	for i, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case quoted:
+		case r == ';':
+			fields = append(fields, line[fieldStart:i])
+			fieldStart = i + 1
+		case r == ':':
+			fields = append(fields, line[fieldStart:i])
+			property.Value = line[i+1:]
+			property.Name = strings.ToUpper(fields[0])
+			for _, param := range fields[1:] {
+				name, value, _ := strings.Cut(param, "=")
+				property.Params[strings.ToUpper(name)] = strings.Trim(value, `"`)
+			}
+			if property.Name == "BEGIN" || property.Name == "END" {
+				property.Value = strings.ToUpper(property.Value)
+			}
+			return property, property.Name != ""
+		}
+	}
+	return icalProperty{}, false
+}
@@ -0,0 +1,275 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
+)
+
+// Recurrence frequencies supported in availability rules
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+)
+
+// maxRecurrencePeriods bounds the periods walked for one rule so that rules which never match stop
+const maxRecurrencePeriods = 100000
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// RRule is the subset of an RFC 5545 recurrence rule used for availability: FREQ of DAILY, WEEKLY or MONTHLY with
+// INTERVAL, COUNT, UNTIL, WKST, BYDAY (plain weekdays, DAILY and WEEKLY only) and BYMONTHDAY (MONTHLY only)
+type RRule struct {
+	Freq       string
+	Interval   int
+	Count      int
+	Until      time.Time
+	WeekStart  time.Weekday
+	ByDay      []time.Weekday
+	ByMonthDay []int
+
+	// untilFloating marks an UNTIL without Z, it is read on the wall clock of the first occurrence
+	untilFloating bool
+}
+
+// ParseRRule parses an RRULE value, with or without the "RRULE:" prefix
+func ParseRRule(value string) (RRule, error) {
+	rule := RRule{Interval: 1, WeekStart: time.Monday}
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return rule, fmt.Errorf("rrule is empty")
+	}
+
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ";") {
+		name, val, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(strings.TrimSpace(name))
+		val = strings.ToUpper(strings.TrimSpace(val))
+		if !ok || val == "" {
+			return rule, fmt.Errorf("invalid rrule part %q", part)
+		}
+		if seen[name] {
+			return rule, fmt.Errorf("rrule part %s is repeated", name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			switch val {
+			case FreqDaily, FreqWeekly, FreqMonthly:
+				rule.Freq = val
+			default:
+				return rule, fmt.Errorf("unsupported rrule FREQ %s, expected DAILY, WEEKLY or MONTHLY", val)
+			}
+		case "INTERVAL":
+			if rule.Interval, err = strconv.Atoi(val); err != nil || rule.Interval < 1 {
+				return rule, fmt.Errorf("rrule INTERVAL must be a positive number")
+			}
+		case "COUNT":
+			if rule.Count, err = strconv.Atoi(val); err != nil || rule.Count < 1 {
+				return rule, fmt.Errorf("rrule COUNT must be a positive number")
+			}
+		case "UNTIL":
+			if rule.Until, rule.untilFloating, err = parseUntil(val); err != nil {
+				return rule, err
+			}
+		case "WKST":
+			weekday, ok := weekdayCodes[val]
+			if !ok {
+				return rule, fmt.Errorf("invalid rrule WKST %s", val)
+			}
+			rule.WeekStart = weekday
+		case "BYDAY":
+			for _, code := range strings.Split(val, ",") {
+				weekday, ok := weekdayCodes[code]
+				if !ok {
+					return rule, fmt.Errorf("unsupported rrule BYDAY %s, expected weekdays such as MO,WE,FR", code)
+				}
+				rule.ByDay = append(rule.ByDay, weekday)
+			}
+		case "BYMONTHDAY":
+			for _, dayStr := range strings.Split(val, ",") {
+				day, err := strconv.Atoi(dayStr)
+				if err != nil || day == 0 || day < -31 || day > 31 {
+					return rule, fmt.Errorf("invalid rrule BYMONTHDAY %s", dayStr)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, day)
+			}
+		default:
+			return rule, fmt.Errorf("unsupported rrule part %s", name)
+		}
+	}
+
+	switch {
+	case rule.Freq == "":
+		return rule, fmt.Errorf("rrule FREQ is required")
+	case rule.Count > 0 && !rule.Until.IsZero():
+		return rule, fmt.Errorf("rrule COUNT and UNTIL must not be used together")
+	case len(rule.ByDay) > 0 && rule.Freq == FreqMonthly:
+		return rule, fmt.Errorf("rrule BYDAY is only supported with FREQ=DAILY or FREQ=WEEKLY")
+	case len(rule.ByMonthDay) > 0 && rule.Freq != FreqMonthly:
+		return rule, fmt.Errorf("rrule BYMONTHDAY is only supported with FREQ=MONTHLY")
+	}
+	return rule, nil
+}
+
+// parseUntil reads a UTC date-time, a floating date-time or a date which includes the whole day
+func parseUntil(val string) (time.Time, bool, error) {
+	if until, err := time.Parse("20060102T150405Z", val); err == nil {
+		return until, false, nil
+	}
+	if until, err := time.Parse("20060102T150405", val); err == nil {
+		return until, true, nil
+	}
+	if until, err := time.Parse("20060102", val); err == nil {
+		return until.Add(24*time.Hour - time.Nanosecond), true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("invalid rrule UNTIL %s, expected a date such as 20250801 or 20250801T000000Z", val)
+}
+
+// Each calls yield with the occurrences of the rule in order, starting at dtstart and keeping its wall clock
+// in the zone of dtstart. It stops when yield returns false, COUNT or UNTIL is reached. Occurrences the rule
+// produces before dtstart are skipped and not counted.
+func (r RRule) Each(dtstart time.Time, yield func(time.Time) bool) {
+	loc := dtstart.Location()
+	until := r.Until
+	if r.untilFloating {
+		until = time.Date(until.Year(), until.Month(), until.Day(), until.Hour(), until.Minute(), until.Second(), until.Nanosecond(), loc)
+	}
+
+	count := 0
+	emit := func(occurrence time.Time) bool {
+		if occurrence.Before(dtstart) {
+			return true
+		}
+		if !until.IsZero() && occurrence.After(until) {
+			return false
+		}
+		count++
+		if !yield(occurrence) {
+			return false
+		}
+		return r.Count == 0 || count < r.Count
+	}
+
+	year, month, day := dtstart.Date()
+	hour, minute, second := dtstart.Clock()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, hour, minute, second, dtstart.Nanosecond(), loc)
+	}
+
+	// days of the week ordered from the week start
+	weekdays := r.ByDay
+	if len(weekdays) == 0 {
+		weekdays = []time.Weekday{dtstart.Weekday()}
+	}
+	fromWeekStart := func(weekday time.Weekday) int { return (int(weekday) - int(r.WeekStart) + 7) % 7 }
+	weekdays = append([]time.Weekday{}, weekdays...)
+	sort.Slice(weekdays, func(i, j int) bool { return fromWeekStart(weekdays[i]) < fromWeekStart(weekdays[j]) })
+	firstWeekDay := day - fromWeekStart(dtstart.Weekday())
+
+	for period := 0; period < maxRecurrencePeriods; period++ {
+		switch r.Freq {
+		case FreqDaily:
+			occurrence := at(year, month, day+period*r.Interval)
+			if len(r.ByDay) > 0 && !containsWeekday(r.ByDay, occurrence.Weekday()) {
+				continue
+			}
+			if !emit(occurrence) {
+				return
+			}
+		case FreqWeekly:
+			weekStart := firstWeekDay + period*7*r.Interval
+			for _, weekday := range weekdays {
+				if !emit(at(year, month, weekStart+fromWeekStart(weekday))) {
+					return
+				}
+			}
+		case FreqMonthly:
+			first := time.Date(year, month+time.Month(period*r.Interval), 1, 0, 0, 0, 0, loc)
+			for _, monthDay := range r.monthDays(first, day) {
+				if !emit(at(first.Year(), first.Month(), monthDay)) {
+					return
+				}
+			}
+		default:
+			return
+		}
+	}
+}
+
+// monthDays resolves BYMONTHDAY, or the day of the first occurrence, to the sorted days that exist in the month
+func (r RRule) monthDays(first time.Time, startDay int) []int {
+	lastDay := first.AddDate(0, 1, -1).Day()
+	byMonthDay := r.ByMonthDay
+	if len(byMonthDay) == 0 {
+		byMonthDay = []int{startDay}
+	}
+	days := []int{}
+	seen := make(map[int]bool)
+	for _, day := range byMonthDay {
+		if day < 0 {
+			day = lastDay + 1 + day
+		}
+		if day < 1 || day > lastDay || seen[day] {
+			continue
+		}
+		seen[day] = true
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	return days
+}
+
+func containsWeekday(weekdays []time.Weekday, weekday time.Weekday) bool {
+	for _, w := range weekdays {
+		if w == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// ExpandRule returns the occurrences of an availability rule that overlap [from, to). Every occurrence lasts as long
+// as the first one and starts on the same wall clock time in the zone of the rule
+func ExpandRule(rule model.AvailabilityRule, from time.Time, to time.Time) ([]model.EventSlot, error) {
+	slots := []model.EventSlot{}
+	loc, err := LoadTimeZone(rule.TimeZone)
+	if err != nil {
+		return slots, err
+	}
+	rrule, err := ParseRRule(rule.RRule)
+	if err != nil {
+		return slots, err
+	}
+
+	duration := rule.EndTime.Sub(rule.StartTime)
+	rrule.Each(rule.StartTime.In(loc), func(start time.Time) bool {
+		if !start.Before(to) {
+			return false
+		}
+		if start.Add(duration).After(from) {
+			slots = append(slots, model.EventSlot{
+				StartTime: start.UTC(),
+				EndTime:   start.Add(duration).UTC(),
+				TimeZone:  loc.String(),
+			})
+		}
+		return true
+	})
+	return slots, nil
+}
@@ -2,9 +2,15 @@ package utils
 
 import (
 	"context"
+	"fmt"
 	"time"
+
+	"github.com/rahulshewale153/meeting-scheduler-api/model"
 )
 
+// DefaultTimeZone is used for events, slots and users that never set a zone
+const DefaultTimeZone = "UTC"
+
 // convert incoming time string to utc time in string format
 func ConvertTimeToUTC(ctx context.Context, timeStr time.Time) (time.Time, error) {
 	// Parse the incoming time string
@@ -17,3 +23,50 @@ func ConvertTimeToUTC(ctx context.Context, timeStr time.Time) (time.Time, error)
 	utcTime := timeStr.In(loc)
 	return utcTime, nil
 }
+
+// LoadTimeZone resolves an IANA zone name, an empty name means DefaultTimeZone.
+// "Local" is rejected so that results never depend on the zone of the server.
+func LoadTimeZone(name string) (*time.Location, error) {
+	if name == "" {
+		name = DefaultTimeZone
+	}
+	if name == "Local" {
+		return nil, fmt.Errorf("unknown time zone %s", name)
+	}
+	return time.LoadLocation(name)
+}
+
+// SlotsInZone renders the slots in loc, or in the zone stored with each slot when loc is nil
+func SlotsInZone(slots []model.EventSlot, loc *time.Location) []model.EventSlot {
+	rendered := make([]model.EventSlot, 0, len(slots))
+	for _, slot := range slots {
+		zone := loc
+		if zone == nil {
+			var err error
+			if zone, err = LoadTimeZone(slot.TimeZone); err != nil {
+				zone = time.UTC
+			}
+		}
+		slot.StartTime = slot.StartTime.In(zone)
+		slot.EndTime = slot.EndTime.In(zone)
+		rendered = append(rendered, slot)
+	}
+	return rendered
+}
+
+// ParseClock converts an HH:MM wall clock time to minutes after midnight, 24:00 is accepted as the end of the day
+func ParseClock(clock string) (int, error) {
+	if clock == "24:00" {
+		return 24 * 60, nil
+	}
+	parsed, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("invalid clock time %q, expected HH:MM", clock)
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+// FormatClock converts minutes after midnight to HH:MM
+func FormatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"sort"
 	"time"
 
 	"github.com/rahulshewale153/meeting-scheduler-api/model"
@@ -22,6 +23,7 @@ func Unique(ints []int64) []int64 {
 	return result
 }
 
+// Difference returns the ids of all that are not present, in ascending order
 func Difference(all map[int64]bool, present []int64) []int64 {
 	presentSet := make(map[int64]bool)
 	for _, id := range present {
@@ -34,5 +36,6 @@ func Difference(all map[int64]bool, present []int64) []int64 {
 			diff = append(diff, id)
 		}
 	}
+	sort.Slice(diff, func(i, j int) bool { return diff[i] < diff[j] })
 	return diff
 }
@@ -2,6 +2,8 @@ package utils
 
 import (
 	"log"
+	"reflect"
+	"strings"
 
 	"github.com/go-playground/locales"
 	"github.com/go-playground/locales/en"
@@ -28,6 +30,14 @@ type Field struct {
 // IsValid validate the given struct based on its rule
 func init() {
 	validate = validator.New()
+	// report fields by their json name so clients can map errors back to the request body
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
 	english := en.New()
 	uni := ut.New(english, english)
 	trans, _ = uni.GetTranslator("en")
@@ -59,3 +69,30 @@ func translateError(err error, trans ut.Translator) (errs ErrorData) {
 	}
 	return errs
 }
+
+// ValidationError carries field level errors found by the service layer, in the same shape as IsValid reports them
+type ValidationError struct {
+	Errors ErrorData
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors.Field))
+	for _, field := range e.Errors.Field {
+		messages = append(messages, field.ErrorMessage)
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// Add records an invalid field along with the offending value
+func (e *ValidationError) Add(name string, value any, message string) {
+	e.Errors.Field = append(e.Errors.Field, Field{Name: name, ErrorMessage: message})
+	e.Errors.InputValue = append(e.Errors.InputValue, value)
+}
+
+// OrNil returns nil when no field was recorded, so callers can return it directly
+func (e *ValidationError) OrNil() error {
+	if len(e.Errors.Field) == 0 {
+		return nil
+	}
+	return e
+}